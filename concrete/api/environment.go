@@ -97,6 +97,13 @@ type Environment interface {
 	GetExternalCode(address common.Address) []byte
 	GetExternalCodeSize(address common.Address) int
 	GetExternalCodeHash(address common.Address) common.Hash
+	// Storage
+	// GetExternalStorage reads a slot of the storage of any account, e.g.
+	// the contract calling the precompile, unlike StorageLoad which reads the
+	// storage of the precompile itself. The storage of other accounts is
+	// read-only: there is no GetExternalStorage counterpart to write it, and
+	// changes to other contracts must go through Call.
+	GetExternalStorage(address common.Address, key common.Hash) common.Hash
 	// Call
 	CallStatic(address common.Address, data []byte, gas uint64) ([]byte, error)
 
@@ -459,6 +466,12 @@ func (env *Env) GetExternalCodeHash(address common.Address) common.Hash {
 	return common.BytesToHash(output[0])
 }
 
+func (env *Env) GetExternalStorage(address common.Address, key common.Hash) common.Hash {
+	input := [][]byte{address.Bytes(), key.Bytes()}
+	output := env.execute(GetExternalStorage_OpCode, input)
+	return common.BytesToHash(output[0])
+}
+
 func (env *Env) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
 	v := value.Bytes32()
 	input := [][]byte{address.Bytes(), data, utils.Uint64ToBytes(gas), v[:]}
@@ -215,6 +215,11 @@ func newEnvironmentMethods() JumpTable {
 			dynamicGas:  gasGetExternalCodeHash,
 			static:      true,
 		},
+		GetExternalStorage_OpCode: {
+			execute:    opGetExternalStorage,
+			dynamicGas: gasGetExternalStorage,
+			static:     true,
+		},
 		CallStatic_OpCode: {
 			execute:     opCallStatic,
 			constantGas: params.WarmStorageReadCostEIP2929,
@@ -734,6 +739,35 @@ func opGetExternalCodeHash(env *Env, args [][]byte) ([][]byte, error) {
 	return [][]byte{hash.Bytes()}, nil
 }
 
+func gasGetExternalStorage(env *Env, args [][]byte) (uint64, error) {
+	if len(args) != 2 {
+		return 0, ErrInvalidInput
+	}
+	if len(args[0]) != 20 || len(args[1]) != 32 {
+		return 0, ErrInvalidInput
+	}
+	address := common.BytesToAddress(args[0])
+	key := common.BytesToHash(args[1])
+	// Charge for accessing the account as well as the slot, as the precompile
+	// may be the first to touch either
+	gas, err := gasAccountAccessMinusWarm(env, address)
+	if err != nil {
+		return 0, err
+	}
+	if _, slotPresent := env.statedb.SlotInAccessList(address, key); !slotPresent {
+		env.statedb.AddSlotToAccessList(address, key)
+		return gas + params.ColdSloadCostEIP2929, nil
+	}
+	return gas + params.WarmStorageReadCostEIP2929, nil
+}
+
+func opGetExternalStorage(env *Env, args [][]byte) ([][]byte, error) {
+	address := common.BytesToAddress(args[0])
+	key := common.BytesToHash(args[1])
+	value := env.statedb.GetState(address, key)
+	return [][]byte{value.Bytes()}, nil
+}
+
 func gasCallStatic(env *Env, args [][]byte) (uint64, error) {
 	if len(args) != 3 {
 		return 0, ErrInvalidInput
@@ -69,6 +69,7 @@ const (
 	GetExternalCode_OpCode     OpCode = 0x62
 	GetExternalCodeSize_OpCode OpCode = 0x63
 	GetExternalCodeHash_OpCode OpCode = 0x64
+	GetExternalStorage_OpCode  OpCode = 0x65
 	// External writes
 	Call_OpCode         OpCode = 0x70
 	CallDelegate_OpCode OpCode = 0x71
@@ -116,9 +116,43 @@ func main() {
 
 	cmdDatamod.Flags().StringP("out", "o", "./", "dir to write the generated files to")
 	cmdDatamod.Flags().StringP("pkg", "p", "main", "package name for the generated files")
+	cmdDatamod.Flags().String("format", datamod.FormatDSL, "format of the schema file, dsl or json")
 	cmdDatamod.Flags().Bool("table-type-experimental", false, "whether to enable experimental features for table types")
+	cmdDatamod.Flags().Bool("sol", false, "also generate a solidity interface for the tables")
+	cmdDatamod.Flags().String("sol-pragma", "^0.8.0", "solidity version pragma for the generated interface")
+	cmdDatamod.Flags().Bool("abi", false, "also generate a JSON ABI file per table")
+	cmdDatamod.Flags().Bool("ts", false, "also generate TypeScript types for the keys and rows of the tables")
+	cmdDatamod.Flags().Bool("strict-enums", false, "panic when reading out of range enum values instead of clamping them")
+	cmdDatamod.Flags().Bool("no-packing", false, "store every value in its own slot instead of packing small values together")
+	cmdDatamod.Flags().Bool("fuzz", false, "also generate a fuzz test per table, built with the datamod_fuzz tag")
+	cmdDatamod.Flags().Bool("bench", false, "also generate a gas benchmark per table, built with the datamod_bench tag")
+	cmdDatamod.Flags().Bool("memory", false, "also generate a map backed implementation of every table for unit tests")
+	cmdDatamod.Flags().Bool("context", false, "take a context.Context as first argument of the table accessors")
+	cmdDatamod.Flags().Bool("multireturn", false, "return the row values of GetRow as named results instead of a struct")
+	cmdDatamod.Flags().Bool("lint", false, "print suggestions to make the storage layout of the tables cheaper")
+	cmdDatamod.Flags().String("import-common", datamod.DefaultImportCommon, "import path of the go-ethereum common package in the generated code")
+	cmdDatamod.Flags().String("import-uint256", datamod.DefaultImportUint256, "import path of the uint256 package in the generated code")
+	cmdDatamod.Flags().String("import-runtime", datamod.DefaultImportRuntime, "import path of the concrete packages in the generated code, e.g. for forks of go-ethereum")
+	cmdDatamod.Flags().Uint64("base-slot", 0, "number of slots to offset the base slot of every table by, e.g. to keep apart packages sharing a contract's storage")
+	cmdDatamod.Flags().Bool("mask-dirty-bytes", false, "ignore non-zero bytes above the width of values stored in their own word instead of panicking")
 	rootCmd.AddCommand(cmdDatamod)
 
+	var cmdDatamodMigrate = &cobra.Command{
+		Use:   "datamod-migrate <old path> <new path>",
+		Short: "Compare the storage layout of two datamod schemas and plan the migration of their rows",
+		Args:  cobra.ExactArgs(2),
+		Run:   runDatamodMigrate,
+	}
+
+	cmdDatamodMigrate.Flags().StringP("out", "o", "./", "dir to write the migration plan to")
+	cmdDatamodMigrate.Flags().StringP("pkg", "p", "main", "package name for the generated migration code")
+	cmdDatamodMigrate.Flags().String("format", datamod.FormatDSL, "format of the schema files, dsl or json")
+	cmdDatamodMigrate.Flags().Bool("table-type-experimental", false, "whether to enable experimental features for table types")
+	cmdDatamodMigrate.Flags().Bool("no-packing", false, "whether the tables of both schemas are generated with packing disabled")
+	cmdDatamodMigrate.Flags().Bool("go", false, "also generate go code migrating the rows of changed tables in place")
+	cmdDatamodMigrate.Flags().Bool("allow-destructive", false, "exit successfully even if the migration has destructive changes")
+	rootCmd.AddCommand(cmdDatamodMigrate)
+
 	if err := rootCmd.Execute(); err != nil {
 		logFatalNoContext(err)
 	}
@@ -197,8 +231,8 @@ func runSolgen(cmd *cobra.Command, args []string) {
 func runDatamod(cmd *cobra.Command, args []string) {
 	jsonPath := args[0]
 
-	var outPath, pkg string
-	if err := getStringFlags(cmd, &outPath, "out", &pkg, "pkg"); err != nil {
+	var outPath, pkg, format string
+	if err := getStringFlags(cmd, &outPath, "out", &pkg, "pkg", &format, "format"); err != nil {
 		logFatal(err)
 	}
 
@@ -208,6 +242,77 @@ func runDatamod(cmd *cobra.Command, args []string) {
 		logFatal(err)
 	}
 
+	var strictEnums bool
+	if strictEnums, err = cmd.Flags().GetBool("strict-enums"); err != nil {
+		logFatal(err)
+	}
+
+	var disablePacking bool
+	if disablePacking, err = cmd.Flags().GetBool("no-packing"); err != nil {
+		logFatal(err)
+	}
+
+	var fuzz bool
+	if fuzz, err = cmd.Flags().GetBool("fuzz"); err != nil {
+		logFatal(err)
+	}
+
+	var bench bool
+	if bench, err = cmd.Flags().GetBool("bench"); err != nil {
+		logFatal(err)
+	}
+
+	var memory bool
+	if memory, err = cmd.Flags().GetBool("memory"); err != nil {
+		logFatal(err)
+	}
+
+	var withContext bool
+	if withContext, err = cmd.Flags().GetBool("context"); err != nil {
+		logFatal(err)
+	}
+
+	var multiReturn bool
+	if multiReturn, err = cmd.Flags().GetBool("multireturn"); err != nil {
+		logFatal(err)
+	}
+
+	var maskDirtyBytes bool
+	if maskDirtyBytes, err = cmd.Flags().GetBool("mask-dirty-bytes"); err != nil {
+		logFatal(err)
+	}
+
+	var baseSlot uint64
+	if baseSlot, err = cmd.Flags().GetUint64("base-slot"); err != nil {
+		logFatal(err)
+	}
+
+	var lint bool
+	if lint, err = cmd.Flags().GetBool("lint"); err != nil {
+		logFatal(err)
+	}
+
+	var solidity bool
+	if solidity, err = cmd.Flags().GetBool("sol"); err != nil {
+		logFatal(err)
+	}
+	var generateABI bool
+	if generateABI, err = cmd.Flags().GetBool("abi"); err != nil {
+		logFatal(err)
+	}
+	var typeScript bool
+	if typeScript, err = cmd.Flags().GetBool("ts"); err != nil {
+		logFatal(err)
+	}
+	var solidityPragma string
+	if err := getStringFlags(cmd, &solidityPragma, "sol-pragma"); err != nil {
+		logFatal(err)
+	}
+	var importCommon, importUint256, importRuntime string
+	if err := getStringFlags(cmd, &importCommon, "import-common", &importUint256, "import-uint256", &importRuntime, "import-runtime"); err != nil {
+		logFatal(err)
+	}
+
 	var jsonIsDir, outIsDir bool
 
 	if jsonIsDir, err = isDir(jsonPath); err != nil {
@@ -226,8 +331,25 @@ func runDatamod(cmd *cobra.Command, args []string) {
 
 	config := datamod.Config{
 		SchemaFilePath: jsonPath,
+		Format:         format,
 		OutDir:         outPath,
 		Package:        pkg,
+		StrictEnums:    strictEnums,
+		Solidity:       solidity,
+		SolidityPragma: solidityPragma,
+		ABI:            generateABI,
+		TypeScript:     typeScript,
+		DisablePacking: disablePacking,
+		Fuzz:           fuzz,
+		Bench:          bench,
+		Memory:         memory,
+		Context:        withContext,
+		MaskDirtyBytes: maskDirtyBytes,
+		MultiReturn:    multiReturn,
+		ImportCommon:   importCommon,
+		ImportUint256:  importUint256,
+		ImportRuntime:  importRuntime,
+		BaseSlot:       baseSlot,
 	}
 
 	if v, err := cmd.Flags().GetBool("verbose"); err != nil {
@@ -236,6 +358,16 @@ func runDatamod(cmd *cobra.Command, args []string) {
 		logConfig(config)
 	}
 
+	if lint {
+		warnings, err := datamod.Lint(config, allowTableTypes)
+		if err != nil {
+			logFatal(err)
+		}
+		for _, warning := range warnings {
+			logWarning(warning.String())
+		}
+	}
+
 	if err := datamod.GenerateDataModel(config, allowTableTypes); err != nil {
 		logFatal(err)
 	}
@@ -243,3 +375,78 @@ func runDatamod(cmd *cobra.Command, args []string) {
 	logInfo("Data model wrappers generated successfully.")
 	logInfo("Files written to: %s", outPath)
 }
+
+func runDatamodMigrate(cmd *cobra.Command, args []string) {
+	oldPath, newPath := args[0], args[1]
+
+	var outPath, pkg, format string
+	if err := getStringFlags(cmd, &outPath, "out", &pkg, "pkg", &format, "format"); err != nil {
+		logFatal(err)
+	}
+
+	var err error
+	var allowTableTypes bool
+	if allowTableTypes, err = cmd.Flags().GetBool("table-type-experimental"); err != nil {
+		logFatal(err)
+	}
+
+	var disablePacking bool
+	if disablePacking, err = cmd.Flags().GetBool("no-packing"); err != nil {
+		logFatal(err)
+	}
+
+	var goCode bool
+	if goCode, err = cmd.Flags().GetBool("go"); err != nil {
+		logFatal(err)
+	}
+
+	var allowDestructive bool
+	if allowDestructive, err = cmd.Flags().GetBool("allow-destructive"); err != nil {
+		logFatal(err)
+	}
+
+	for _, path := range []string{oldPath, newPath} {
+		var pathIsDir bool
+		if pathIsDir, err = isDir(path); err != nil {
+			logFatal(err)
+		}
+		if pathIsDir {
+			logFatalNoContext(fmt.Errorf("schema path must be a file: %s", path))
+		}
+	}
+
+	var outIsDir bool
+	if outIsDir, err = isDir(outPath); err != nil {
+		logFatal(err)
+	}
+	if !outIsDir {
+		logFatalNoContext(fmt.Errorf("output path must be a directory"))
+	}
+
+	config := datamod.MigrationConfig{
+		OldSchemaFilePath: oldPath,
+		NewSchemaFilePath: newPath,
+		Format:            format,
+		OutDir:            outPath,
+		Package:           pkg,
+		DisablePacking:    disablePacking,
+		GoCode:            goCode,
+	}
+
+	if v, err := cmd.Flags().GetBool("verbose"); err != nil {
+		logFatal(err)
+	} else if v {
+		logConfig(config)
+	}
+
+	plan, err := datamod.GenerateMigration(config, allowTableTypes)
+	if err != nil {
+		logFatal(err)
+	}
+
+	fmt.Print(plan.Report())
+	logInfo("Migration plan written to: %s", filepath.Join(outPath, datamod.MigrationReportFile))
+	if plan.Destructive() && !allowDestructive {
+		logFatalNoContext(fmt.Errorf("the migration has destructive changes that require manual review"))
+	}
+}
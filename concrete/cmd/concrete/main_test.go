@@ -69,3 +69,26 @@ func TestDatamod(t *testing.T) {
 	}
 	t.Log(stdout.String())
 }
+
+func TestDatamodMigrate(t *testing.T) {
+	tmpDir := "./tmp-datamod-migrate"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	schemaPath := filepath.Join("..", "..", "codegen", "datamod", "testdata", "good-datamod.json")
+	cmd := exec.Command(
+		"go", "run", ".", "datamod-migrate",
+		schemaPath, schemaPath,
+		"--out", tmpDir,
+		"--pkg", "test",
+		"--table-type-experimental",
+		"--go",
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Log(stderr.String())
+		t.Fatal(err)
+	}
+	t.Log(stdout.String())
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+type abiArgument struct {
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	InternalType string        `json:"internalType,omitempty"`
+	Components   []abiArgument `json:"components,omitempty"`
+}
+
+func newABIArgument(name string, fieldType FieldType) abiArgument {
+	if fieldType.Struct == nil {
+		return abiArgument{Name: name, Type: fieldType.SolType}
+	}
+	components := make([]abiArgument, 0, len(fieldType.Struct.Members))
+	for _, member := range fieldType.Struct.Members {
+		components = append(components, newABIArgument(member.Name, member.Type))
+	}
+	return abiArgument{
+		Name:         name,
+		Type:         "tuple",
+		InternalType: "struct " + fieldType.Struct.Name,
+		Components:   components,
+	}
+}
+
+type abiMethod struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name"`
+	Inputs          []abiArgument `json:"inputs"`
+	Outputs         []abiArgument `json:"outputs"`
+	StateMutability string        `json:"stateMutability"`
+}
+
+func abiArguments(args []accessorArg) []abiArgument {
+	abiArgs := make([]abiArgument, 0, len(args))
+	for _, arg := range args {
+		abiArgs = append(abiArgs, newABIArgument(arg.Name, arg.Type))
+	}
+	return abiArgs
+}
+
+// tableABI returns the ABI of the accessor methods of a table. Methods are
+// listed in declaration order so the output is deterministic.
+func tableABI(schema TableSchema) []abiMethod {
+	methods := []abiMethod{}
+	for _, method := range accessorMethods(schema) {
+		stateMutability := "nonpayable"
+		if method.IsView {
+			stateMutability = "view"
+		}
+		methods = append(methods, abiMethod{
+			Type:            "function",
+			Name:            method.Name,
+			Inputs:          abiArguments(method.Inputs),
+			Outputs:         abiArguments(method.Outputs),
+			StateMutability: stateMutability,
+		})
+	}
+	return methods
+}
+
+func abiFileName(tableName string) string {
+	return lowerFirstLetter(formatTableName(tableName)) + ".abi.json"
+}
+
+// GenerateABIs writes a JSON ABI file for every table with accessor methods.
+func GenerateABIs(config Config, schemas []TableSchema) error {
+	for _, schema := range schemas {
+		methods := tableABI(schema)
+		if len(methods) == 0 {
+			continue
+		}
+		content, err := json.MarshalIndent(methods, "", "  ")
+		if err != nil {
+			return err
+		}
+		content = append(content, '\n')
+		if err := os.WriteFile(filepath.Join(config.OutDir, abiFileName(schema.Name)), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableABI(t *testing.T) {
+	r := require.New(t)
+
+	content, err := os.ReadFile(filepath.Join("testdata", "good-datamod.json"))
+	r.NoError(err)
+	schemas, err := UnmarshalTableSchemas(content, true)
+	r.NoError(err)
+
+	for _, schema := range schemas {
+		abiJson, err := json.Marshal(tableABI(schema))
+		r.NoError(err)
+		ABI, err := abi.JSON(bytes.NewReader(abiJson))
+		r.NoError(err)
+		if len(accessorMethods(schema)) == 0 {
+			r.Empty(ABI.Methods)
+			continue
+		}
+		r.True(ABI.Methods["get"].IsConstant())
+		r.False(ABI.Methods["set"].IsConstant())
+		r.Len(ABI.Methods["get"].Inputs, len(schema.Keys))
+	}
+
+	ABI, err := abi.JSON(bytes.NewReader(mustMarshal(t, tableABI(schemas[0]))))
+	r.NoError(err)
+	r.Equal("getValueBytes16(uint256,string,bytes,bool,address,bytes16)", ABI.Methods["getValueBytes16"].Sig)
+	r.Equal("bytes16", ABI.Methods["getValueBytes16"].Outputs[0].Type.String())
+
+	schemasByName := make(map[string]TableSchema)
+	for _, schema := range schemas {
+		schemasByName[schema.Name] = schema
+	}
+	ABI, err = abi.JSON(bytes.NewReader(mustMarshal(t, tableABI(schemasByName["StructTable"]))))
+	r.NoError(err)
+	r.Equal("setSegment(uint64,((uint64,uint64),(uint64,uint64),bytes8))", ABI.Methods["setSegment"].Sig)
+
+	ABI, err = abi.JSON(bytes.NewReader(mustMarshal(t, tableABI(schemasByName["OptionalTable"]))))
+	r.NoError(err)
+	r.Len(ABI.Methods["getScore"].Outputs, 2)
+	r.Equal("bool", ABI.Methods["getScore"].Outputs[1].Type.String())
+	r.Equal("clearScore(uint64)", ABI.Methods["clearScore"].Sig)
+	r.NotContains(ABI.Methods, "clearName")
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build datamod_cbor
+
+package datamod
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata/gotypes"
+	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORTable(t *testing.T) {
+	r := require.New(t)
+	contract := api.NewContract(common.Address{}, common.Address{}, common.Address{}, new(uint256.Int))
+	ds := lib.NewDatastore(mock.NewMockEnvironment(api.EnvConfig{}, false, contract))
+	table := testdata.NewProfileTable(ds)
+	owner := common.HexToAddress("0xc0de")
+	profile := gotypes.Profile{Name: "alice", Tags: []string{"a", "b"}, Score: 42}
+
+	// Empty rows decode to the zero value
+	row := table.Get(owner)
+	r.Equal(gotypes.Profile{}, row.GetProfile())
+
+	row.Set(profile, 7)
+	got, updated := table.Get(owner).Get()
+	r.Equal(profile, got)
+	r.Equal(uint64(7), updated)
+
+	// Values are stored as their cbor encoding
+	data := row.GetField_bytes(0)
+	r.Equal(codec.EncodeCBOR(0, profile), data)
+
+	values := row.GetValues()
+	r.True(values.Equal(testdata.ProfileTableValues{Profile: profile, Updated: 7}))
+	r.False(values.Equal(testdata.ProfileTableValues{Profile: gotypes.Profile{Name: "bob"}, Updated: 7}))
+}
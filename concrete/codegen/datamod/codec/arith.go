@@ -0,0 +1,114 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+	"golang.org/x/exp/constraints"
+)
+
+// ErrOverflow is the error returned by the generated Add and Sub helpers of
+// integer values if the result does not fit the field.
+var ErrOverflow = errors.New("integer overflow")
+
+// The checked arithmetic functions below take the field size like the codec
+// functions. Native integers always fill their go type, so only big integers
+// are checked against the size.
+
+func AddUint[T constraints.Unsigned](_ int, a, b T) (T, error) {
+	c := a + b
+	if c < a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+func SubUint[T constraints.Unsigned](_ int, a, b T) (T, error) {
+	if b > a {
+		return 0, ErrOverflow
+	}
+	return a - b, nil
+}
+
+func AddInt[T constraints.Signed](_ int, a, b T) (T, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+func SubInt[T constraints.Signed](_ int, a, b T) (T, error) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+func AddBigUint(size int, a, b *uint256.Int) (*uint256.Int, error) {
+	c, overflow := new(uint256.Int).AddOverflow(a, b)
+	if overflow || c.BitLen() > 8*size {
+		return nil, ErrOverflow
+	}
+	return c, nil
+}
+
+func SubBigUint(size int, a, b *uint256.Int) (*uint256.Int, error) {
+	c, underflow := new(uint256.Int).SubOverflow(a, b)
+	if underflow {
+		return nil, ErrOverflow
+	}
+	return c, nil
+}
+
+// AddBigInt adds signed big integers in two's complement, as decoded by
+// DecodeInt128 and DecodeInt256.
+func AddBigInt(size int, a, b *uint256.Int) (*uint256.Int, error) {
+	c := new(uint256.Int).Add(a, b)
+	if size == 32 {
+		if a.Sign() >= 0 && b.Sign() >= 0 && c.Sign() < 0 || a.Sign() < 0 && b.Sign() < 0 && c.Sign() >= 0 {
+			return nil, ErrOverflow
+		}
+		return c, nil
+	}
+	return checkSignedSize(size, c)
+}
+
+// SubBigInt subtracts signed big integers in two's complement, as decoded by
+// DecodeInt128 and DecodeInt256.
+func SubBigInt(size int, a, b *uint256.Int) (*uint256.Int, error) {
+	c := new(uint256.Int).Sub(a, b)
+	if size == 32 {
+		if a.Sign() >= 0 && b.Sign() < 0 && c.Sign() < 0 || a.Sign() < 0 && b.Sign() >= 0 && c.Sign() >= 0 {
+			return nil, ErrOverflow
+		}
+		return c, nil
+	}
+	return checkSignedSize(size, c)
+}
+
+// checkSignedSize returns ErrOverflow if a signed result of operands narrower
+// than 256 bits does not fit in size bytes.
+func checkSignedSize(size int, value *uint256.Int) (*uint256.Int, error) {
+	extended := new(uint256.Int).ExtendSign(value, uint256.NewInt(uint64(size-1)))
+	if !extended.Eq(value) {
+		return nil, ErrOverflow
+	}
+	return value, nil
+}
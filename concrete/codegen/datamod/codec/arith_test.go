@@ -0,0 +1,95 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+package codec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedArithmetic(t *testing.T) {
+	t.Run("native", func(t *testing.T) {
+		r := require.New(t)
+		c, err := AddUint[uint8](1, 200, 55)
+		r.NoError(err)
+		r.Equal(uint8(255), c)
+		_, err = AddUint[uint8](1, 200, 56)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubUint[uint64](8, 1, 2)
+		r.ErrorIs(err, ErrOverflow)
+
+		i, err := AddInt[int16](2, -100, 50)
+		r.NoError(err)
+		r.Equal(int16(-50), i)
+		_, err = AddInt[int16](2, math.MaxInt16, 1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubInt[int64](8, math.MinInt64, 1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubInt[int64](8, 0, math.MinInt64)
+		r.ErrorIs(err, ErrOverflow)
+	})
+
+	t.Run("bigUnsigned", func(t *testing.T) {
+		r := require.New(t)
+		maxUint128 := new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 128), Uint256_1)
+		c, err := AddBigUint(16, maxUint128, Uint256_0)
+		r.NoError(err)
+		r.Equal(maxUint128, c)
+		_, err = AddBigUint(16, maxUint128, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = AddBigUint(32, MaxUint256, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubBigUint(32, Uint256_0, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		c, err = SubBigUint(32, uint256.NewInt(5), uint256.NewInt(3))
+		r.NoError(err)
+		r.Equal(uint256.NewInt(2), c)
+	})
+
+	t.Run("bigSigned", func(t *testing.T) {
+		r := require.New(t)
+		minusOne := new(uint256.Int).Neg(Uint256_1)
+		maxInt128 := new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 127), Uint256_1)
+		minInt128 := new(uint256.Int).Neg(new(uint256.Int).Lsh(Uint256_1, 127))
+		c, err := AddBigInt(16, maxInt128, minusOne)
+		r.NoError(err)
+		r.Equal(new(uint256.Int).Sub(maxInt128, Uint256_1), c)
+		_, err = AddBigInt(16, maxInt128, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubBigInt(16, minInt128, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		c, err = SubBigInt(16, Uint256_0, uint256.NewInt(3))
+		r.NoError(err)
+		r.Equal(new(uint256.Int).Neg(uint256.NewInt(3)), c)
+
+		maxInt256 := new(uint256.Int).Rsh(MaxUint256, 1)
+		minInt256 := new(uint256.Int).Not(maxInt256)
+		_, err = AddBigInt(32, maxInt256, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubBigInt(32, minInt256, Uint256_1)
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubBigInt(32, Uint256_0, minInt256)
+		r.ErrorIs(err, ErrOverflow)
+		c, err = AddBigInt(32, minInt256, maxInt256)
+		r.NoError(err)
+		r.Equal(minusOne, c)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Integers wider than 64 bits annotated with @bigint are represented as
+// *big.Int holding the value itself, negative for negative signed integers.
+// They are stored like the *uint256.Int representation of the same width, and
+// values that do not fit in the field are rejected when encoding instead of
+// being truncated.
+
+// ErrIntOutOfRange is the panic value of EncodeUintBig and EncodeIntBig for
+// values that do not fit in the field.
+var ErrIntOutOfRange = errors.New("integer out of range for field")
+
+func bigToRaw(size int, signed bool, value *big.Int) []byte {
+	if value == nil {
+		// Unset values are stored as zero
+		return make([]byte, size)
+	}
+	raw, ok := fixedFromBig(size*8, signed, value)
+	if !ok {
+		panic(fmt.Errorf("%w: %s does not fit in %d bits", ErrIntOutOfRange, value, size*8))
+	}
+	return encodeUint(size, raw)
+}
+
+// EncodeUintBig encodes an unsigned integer in size bytes. It panics with
+// ErrIntOutOfRange if the value is negative or wider than the field.
+func EncodeUintBig(size int, value *big.Int) []byte {
+	return bigToRaw(size, false, value)
+}
+
+func DecodeUintBig(size int, data []byte) *big.Int {
+	return decodeUint(size, data).ToBig()
+}
+
+// EncodeIntBig encodes a signed integer in two's complement over size bytes.
+// It panics with ErrIntOutOfRange if the value does not fit in the field.
+func EncodeIntBig(size int, value *big.Int) []byte {
+	return bigToRaw(size, true, value)
+}
+
+func DecodeIntBig(size int, data []byte) *big.Int {
+	return fixedToBig(true, decodeSignedInt(size, data))
+}
+
+func checkBig(size int, signed bool, value *big.Int) (*big.Int, error) {
+	if _, ok := fixedFromBig(size*8, signed, value); !ok {
+		return nil, ErrOverflow
+	}
+	return value, nil
+}
+
+func AddUintBig(size int, a, b *big.Int) (*big.Int, error) {
+	return checkBig(size, false, new(big.Int).Add(a, b))
+}
+
+func SubUintBig(size int, a, b *big.Int) (*big.Int, error) {
+	return checkBig(size, false, new(big.Int).Sub(a, b))
+}
+
+func AddIntBig(size int, a, b *big.Int) (*big.Int, error) {
+	return checkBig(size, true, new(big.Int).Add(a, b))
+}
+
+func SubIntBig(size int, a, b *big.Int) (*big.Int, error) {
+	return checkBig(size, true, new(big.Int).Sub(a, b))
+}
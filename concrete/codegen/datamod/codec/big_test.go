@@ -0,0 +1,85 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+package codec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigCodec(t *testing.T) {
+	t.Run("unsigned", func(t *testing.T) {
+		r := require.New(t)
+		maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+		encoded := EncodeUintBig(16, maxUint128)
+		r.Equal(EncodeUint128(16, new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 128), Uint256_1)), encoded)
+		r.Equal(maxUint128, DecodeUintBig(16, encoded))
+		r.Equal(make([]byte, 32), EncodeUintBig(32, nil))
+
+		r.PanicsWithError(ErrIntOutOfRange.Error()+": 340282366920938463463374607431768211456 does not fit in 128 bits", func() {
+			EncodeUintBig(16, new(big.Int).Add(maxUint128, big.NewInt(1)))
+		})
+		r.Panics(func() { EncodeUintBig(32, big.NewInt(-1)) })
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		r := require.New(t)
+		minInt128 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+		encoded := EncodeIntBig(16, minInt128)
+		r.Equal(EncodeInt128(16, new(uint256.Int).Neg(new(uint256.Int).Lsh(Uint256_1, 127))), encoded)
+		r.Equal(minInt128, DecodeIntBig(16, encoded))
+		r.Equal(big.NewInt(-1), DecodeIntBig(32, EncodeIntBig(32, big.NewInt(-1))))
+
+		r.Panics(func() { EncodeIntBig(16, new(big.Int).Neg(minInt128)) })
+		r.Panics(func() { EncodeIntBig(16, new(big.Int).Sub(minInt128, big.NewInt(1))) })
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		r := require.New(t)
+		maxUint72 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 72), big.NewInt(1))
+		c, err := AddUintBig(9, maxUint72, new(big.Int))
+		r.NoError(err)
+		r.Equal(maxUint72, c)
+		_, err = AddUintBig(9, maxUint72, big.NewInt(1))
+		r.ErrorIs(err, ErrOverflow)
+		_, err = SubUintBig(32, new(big.Int), big.NewInt(1))
+		r.ErrorIs(err, ErrOverflow)
+
+		c, err = SubIntBig(16, new(big.Int), big.NewInt(3))
+		r.NoError(err)
+		r.Equal(big.NewInt(-3), c)
+		_, err = AddIntBig(16, new(big.Int).Lsh(big.NewInt(1), 126), new(big.Int).Lsh(big.NewInt(1), 126))
+		r.ErrorIs(err, ErrOverflow)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		r := require.New(t)
+		r.Equal("0", FormatBig(nil))
+		r.Equal("-5", FormatBig(big.NewInt(-5)))
+		value, err := ParseIntBig(16, "-5")
+		r.NoError(err)
+		r.Equal(big.NewInt(-5), value)
+		_, err = ParseUintBig(16, "-5")
+		r.ErrorIs(err, ErrIntOutOfRange)
+		_, err = ParseUintBig(16, "five")
+		r.Error(err)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build datamod_cbor
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ErrInvalidCBOR is the panic value of EncodeCBOR and DecodeCBOR for values
+// that cannot be marshalled or data that is not valid CBOR for the go type.
+var ErrInvalidCBOR = errors.New("invalid cbor value")
+
+// Values are encoded with the core deterministic encoding of RFC 8949, so
+// equal values always have the same encoding, e.g. to compare them.
+var cborEncMode cbor.EncMode
+
+func init() {
+	var err error
+	if cborEncMode, err = cbor.CoreDetEncOptions().EncMode(); err != nil {
+		panic(err)
+	}
+}
+
+// The CBOR codec is only built with the datamod_cbor build tag, required as
+// well by the code generated for tables with cbor values, so that only the
+// users of CBOR depend on its implementation.
+
+// EncodeCBOR marshals a go value to CBOR, stored as dynamic bytes. It panics
+// with ErrInvalidCBOR if the value cannot be marshalled.
+func EncodeCBOR[T any](_ int, value T) []byte {
+	data, err := cborEncMode.Marshal(value)
+	if err != nil {
+		panic(fmt.Errorf("%w: %v", ErrInvalidCBOR, err))
+	}
+	return data
+}
+
+// DecodeCBOR unmarshals the CBOR data stored by EncodeCBOR. Empty data, e.g.
+// of a value that was never set, decodes to the zero value. It panics with
+// ErrInvalidCBOR if the data is not valid CBOR for the go type.
+func DecodeCBOR[T any](_ int, data []byte) T {
+	var value T
+	if len(data) == 0 {
+		return value
+	}
+	if err := cbor.Unmarshal(data, &value); err != nil {
+		panic(fmt.Errorf("%w: %v", ErrInvalidCBOR, err))
+	}
+	return value
+}
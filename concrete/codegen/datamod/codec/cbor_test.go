@@ -0,0 +1,53 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build datamod_cbor && !tinygo
+
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cborProfile struct {
+	Name  string
+	Tags  []string
+	Score map[string]uint64
+}
+
+func TestCBOR(t *testing.T) {
+	r := require.New(t)
+
+	profile := cborProfile{Name: "alice", Tags: []string{"a", "b"}, Score: map[string]uint64{"x": 1, "yy": 2, "z": 3}}
+	encoded := EncodeCBOR(32, profile)
+	r.Equal(profile, DecodeCBOR[cborProfile](32, encoded))
+
+	// The encoding is deterministic, map keys are sorted
+	for ii := 0; ii < 10; ii++ {
+		r.Equal(encoded, EncodeCBOR(32, cborProfile{Name: "alice", Tags: []string{"a", "b"}, Score: map[string]uint64{"z": 3, "yy": 2, "x": 1}}))
+	}
+
+	// Unset values decode to the zero value
+	r.Equal(cborProfile{}, DecodeCBOR[cborProfile](32, nil))
+	r.Equal(uint64(0), DecodeCBOR[uint64](32, []byte{}))
+	r.Equal(uint64(500), DecodeCBOR[uint64](32, EncodeCBOR(32, uint64(500))))
+
+	r.PanicsWithError("invalid cbor value: cbor: cannot unmarshal UTF-8 text string into Go value of type uint64", func() {
+		DecodeCBOR[uint64](32, EncodeCBOR(32, "text"))
+	})
+	r.Panics(func() { EncodeCBOR(32, make(chan int)) })
+}
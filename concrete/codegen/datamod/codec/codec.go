@@ -16,10 +16,19 @@
 package codec
 
 import (
+	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/holiman/uint256"
+	"golang.org/x/exp/constraints"
 )
 
 var (
@@ -30,6 +39,76 @@ var (
 	MaxUint256 = new(uint256.Int).Not(Uint256_0)
 )
 
+// FitBytes truncates or right pads data with zeros to size bytes, e.g. to turn
+// arbitrary input into data of the width of a field.
+func FitBytes(size int, data []byte) []byte {
+	fitted := make([]byte, size)
+	copy(fitted, data)
+	return fitted
+}
+
+// SignExtendWord left pads the big-endian two's complement integer in data to
+// a 32-byte word, with ones if it is negative, as in the ABI encoding of signed
+// integers.
+func SignExtendWord(data []byte) []byte {
+	word := make([]byte, 32)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		for ii := range word {
+			word[ii] = 0xff
+		}
+	}
+	copy(word[32-len(data):], data)
+	return word
+}
+
+// ErrDirtyBytes is returned when a value stored in the last bytes of a word has
+// non-zero bytes above its width, e.g. if the word was written by code other
+// than the generated tables.
+var ErrDirtyBytes = errors.New("non-zero bytes above the width of the value")
+
+// WordBytes returns the last size bytes of a word holding a value, e.g. an
+// element of a dynamic array, or ErrDirtyBytes if any of the bytes before them
+// is not zero.
+func WordBytes(size int, word []byte) ([]byte, error) {
+	if !IsZero(word[:len(word)-size]) {
+		return nil, fmt.Errorf("%w: expected %d bytes, got 0x%x", ErrDirtyBytes, size, word)
+	}
+	return word[len(word)-size:], nil
+}
+
+// MustWordBytes is WordBytes, panicking with the error if the word has dirty
+// bytes.
+func MustWordBytes(size int, word []byte) []byte {
+	data, err := WordBytes(size, word)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// IsZero reports whether all the bytes of data are zero, e.g. to tell a field
+// that was never written from one holding data.
+func IsZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// JoinKeys returns a string identifying a list of encoded keys, e.g. to use
+// them as the key of a go map. Every key is prefixed with its length so lists
+// of different keys never join to the same string.
+func JoinKeys(keys ...[]byte) string {
+	var joined []byte
+	for _, key := range keys {
+		joined = binary.AppendUvarint(joined, uint64(len(key)))
+		joined = append(joined, key...)
+	}
+	return string(joined)
+}
+
 func EncodeAddress(_ int, address common.Address) []byte {
 	return address.Bytes()
 }
@@ -38,6 +117,54 @@ func DecodeAddress(_ int, data []byte) common.Address {
 	return common.BytesToAddress(data)
 }
 
+// ErrInvalidChecksum is returned by DecodeAddressChecksummed for mixed-case
+// addresses whose case does not match their EIP-55 checksum.
+var ErrInvalidChecksum = errors.New("invalid address checksum")
+
+// DecodeAddressChecksummed parses an address from its 0x-prefixed hex string,
+// e.g. in text input. Mixed-case strings must match the EIP-55 checksum of the
+// address, while strings all in lower or upper case carry no checksum and are
+// accepted as they are.
+func DecodeAddressChecksummed(s string) (common.Address, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok || len(digits) != 2*common.AddressLength {
+		return common.Address{}, fmt.Errorf("invalid address %q: expected 0x followed by %d hex digits", s, 2*common.AddressLength)
+	}
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+	address := common.BytesToAddress(data)
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && address.Hex()[2:] != digits {
+		return common.Address{}, fmt.Errorf("%w: got %s, expected %s", ErrInvalidChecksum, s, address.Hex())
+	}
+	return address, nil
+}
+
+// Function is a solidity external function pointer, i.e. the address of a
+// contract followed by the selector of one of its functions.
+type Function struct {
+	Addr     common.Address
+	Selector [4]byte
+}
+
+func (f Function) IsZero() bool {
+	return f == Function{}
+}
+
+func EncodeFunction(_ int, f Function) []byte {
+	data := make([]byte, 0, 24)
+	data = append(data, f.Addr.Bytes()...)
+	return append(data, f.Selector[:]...)
+}
+
+func DecodeFunction(_ int, data []byte) Function {
+	var f Function
+	copy(f.Addr[:], data[:20])
+	copy(f.Selector[:], data[20:24])
+	return f
+}
+
 func EncodeBool(_ int, b bool) []byte {
 	if b {
 		return []byte{1}
@@ -57,14 +184,87 @@ func DecodeHash(_ int, data []byte) common.Hash {
 	return common.BytesToHash(data)
 }
 
+// EncodeFixedBytes stores b left aligned, right padded with zeros to size
+// bytes, the way solidity stores bytesN values.
 func EncodeFixedBytes(size int, b []byte) []byte {
 	return common.RightPadBytes(b, size)
 }
 
-func DecodeFixedBytes(_ int, data []byte) []byte {
+// DecodeFixedBytes returns the first size bytes of data, dropping only the
+// right padding of a left aligned value.
+func DecodeFixedBytes(size int, data []byte) []byte {
+	if size > 0 && len(data) > size {
+		return data[:size]
+	}
 	return data
 }
 
+// EncodeFixedBytesRight stores b right aligned, left padded with zeros to size
+// bytes, e.g. for big-endian numbers stored as bytesN.
+func EncodeFixedBytesRight(size int, b []byte) []byte {
+	return common.LeftPadBytes(b, size)
+}
+
+// DecodeFixedBytesRight returns the last size bytes of data, dropping only the
+// left padding of a right aligned value.
+func DecodeFixedBytesRight(size int, data []byte) []byte {
+	if size > 0 && len(data) > size {
+		return data[len(data)-size:]
+	}
+	return data
+}
+
+// ErrStringTooLong is the panic value of EncodeFixedString for strings that do
+// not fit in the field.
+var ErrStringTooLong = errors.New("string too long for field")
+
+// EncodeFixedString stores the UTF-8 bytes of a string right padded with zeros
+// to size bytes. It panics with ErrStringTooLong if the string is longer.
+func EncodeFixedString(size int, s string) []byte {
+	if len(s) > size {
+		panic(fmt.Errorf("%w: %d bytes, at most %d allowed", ErrStringTooLong, len(s), size))
+	}
+	return common.RightPadBytes([]byte(s), size)
+}
+
+// DecodeFixedString returns the string stored by EncodeFixedString, without
+// the padding. Trailing zero bytes of the original string are trimmed too.
+func DecodeFixedString(_ int, data []byte) string {
+	return string(bytes.TrimRight(data, "\x00"))
+}
+
+// ErrValueTooLong is the error returned by the generated setters of dynamic
+// values with a maxlen annotation for values longer than the cap.
+var ErrValueTooLong = errors.New("value too long for field")
+
+// CheckMaxLen returns ErrValueTooLong if the encoded data of the named value
+// is longer than maxLen bytes.
+func CheckMaxLen(name string, maxLen int, data []byte) error {
+	if len(data) > maxLen {
+		return fmt.Errorf("%w: %s is %d bytes, at most %d allowed", ErrValueTooLong, name, len(data), maxLen)
+	}
+	return nil
+}
+
+// ErrRowNotCreated is the value the generated setters of strict initialized
+// tables panic with when the row was not created beforehand.
+var ErrRowNotCreated = errors.New("row not created")
+
+var ErrHashMismatch = errors.New("content does not match its hash")
+
+// CheckHash returns ErrHashMismatch if hash is not the keccak256 hash of the
+// data of the named value. A zero hash matches empty data, as for values that
+// were never written.
+func CheckHash(name string, data []byte, hash []byte) error {
+	if len(data) == 0 && IsZero(hash) {
+		return nil
+	}
+	if !bytes.Equal(crypto.Keccak256(data), hash) {
+		return fmt.Errorf("%w: %s", ErrHashMismatch, name)
+	}
+	return nil
+}
+
 func EncodeBytes(_ int, b []byte) []byte {
 	return b
 }
@@ -82,64 +282,263 @@ func DecodeString(_ int, data []byte) string {
 }
 
 func EncodeUint256(_ int, i *uint256.Int) []byte {
-	b := i.Bytes32()
-	return b[:]
+	return encodeUint(32, i)
 }
 
 func DecodeUint256(_ int, data []byte) *uint256.Int {
 	return new(uint256.Int).SetBytes(data)
 }
 
-func EncodeUint8(_ int, value uint8) []byte {
-	return []byte{value}
-}
+// Unsigned integers wider than 64 bits are represented as *uint256.Int. Values
+// are truncated to the field size when encoding and masked to it when
+// decoding, so they never exceed the declared width.
 
-func EncodeUint16(_ int, value uint16) []byte {
-	buf := make([]byte, 2)
-	binary.BigEndian.PutUint16(buf, value)
-	return buf
+func encodeUint(size int, value *uint256.Int) []byte {
+	data := make([]byte, size)
+	if value == nil {
+		// Unset values, e.g. elements of a zero array, are stored as zero
+		return data
+	}
+	b := value.Bytes32()
+	copy(data, b[32-size:])
+	return data
 }
 
-func EncodeUint32(_ int, value uint32) []byte {
-	buf := make([]byte, 4)
-	binary.BigEndian.PutUint32(buf, value)
-	return buf
+func decodeUint(size int, data []byte) *uint256.Int {
+	if len(data) > size {
+		data = data[len(data)-size:]
+	}
+	return new(uint256.Int).SetBytes(data)
 }
 
-func EncodeUint64(_ int, value uint64) []byte {
+func EncodeUint72(_ int, value *uint256.Int) []byte  { return encodeUint(9, value) }
+func EncodeUint80(_ int, value *uint256.Int) []byte  { return encodeUint(10, value) }
+func EncodeUint88(_ int, value *uint256.Int) []byte  { return encodeUint(11, value) }
+func EncodeUint96(_ int, value *uint256.Int) []byte  { return encodeUint(12, value) }
+func EncodeUint104(_ int, value *uint256.Int) []byte { return encodeUint(13, value) }
+func EncodeUint112(_ int, value *uint256.Int) []byte { return encodeUint(14, value) }
+func EncodeUint120(_ int, value *uint256.Int) []byte { return encodeUint(15, value) }
+func EncodeUint128(_ int, value *uint256.Int) []byte { return encodeUint(16, value) }
+func EncodeUint136(_ int, value *uint256.Int) []byte { return encodeUint(17, value) }
+func EncodeUint144(_ int, value *uint256.Int) []byte { return encodeUint(18, value) }
+func EncodeUint152(_ int, value *uint256.Int) []byte { return encodeUint(19, value) }
+func EncodeUint160(_ int, value *uint256.Int) []byte { return encodeUint(20, value) }
+func EncodeUint168(_ int, value *uint256.Int) []byte { return encodeUint(21, value) }
+func EncodeUint176(_ int, value *uint256.Int) []byte { return encodeUint(22, value) }
+func EncodeUint184(_ int, value *uint256.Int) []byte { return encodeUint(23, value) }
+func EncodeUint192(_ int, value *uint256.Int) []byte { return encodeUint(24, value) }
+func EncodeUint200(_ int, value *uint256.Int) []byte { return encodeUint(25, value) }
+func EncodeUint208(_ int, value *uint256.Int) []byte { return encodeUint(26, value) }
+func EncodeUint216(_ int, value *uint256.Int) []byte { return encodeUint(27, value) }
+func EncodeUint224(_ int, value *uint256.Int) []byte { return encodeUint(28, value) }
+func EncodeUint232(_ int, value *uint256.Int) []byte { return encodeUint(29, value) }
+func EncodeUint240(_ int, value *uint256.Int) []byte { return encodeUint(30, value) }
+func EncodeUint248(_ int, value *uint256.Int) []byte { return encodeUint(31, value) }
+
+func DecodeUint72(_ int, data []byte) *uint256.Int  { return decodeUint(9, data) }
+func DecodeUint80(_ int, data []byte) *uint256.Int  { return decodeUint(10, data) }
+func DecodeUint88(_ int, data []byte) *uint256.Int  { return decodeUint(11, data) }
+func DecodeUint96(_ int, data []byte) *uint256.Int  { return decodeUint(12, data) }
+func DecodeUint104(_ int, data []byte) *uint256.Int { return decodeUint(13, data) }
+func DecodeUint112(_ int, data []byte) *uint256.Int { return decodeUint(14, data) }
+func DecodeUint120(_ int, data []byte) *uint256.Int { return decodeUint(15, data) }
+func DecodeUint128(_ int, data []byte) *uint256.Int { return decodeUint(16, data) }
+func DecodeUint136(_ int, data []byte) *uint256.Int { return decodeUint(17, data) }
+func DecodeUint144(_ int, data []byte) *uint256.Int { return decodeUint(18, data) }
+func DecodeUint152(_ int, data []byte) *uint256.Int { return decodeUint(19, data) }
+func DecodeUint160(_ int, data []byte) *uint256.Int { return decodeUint(20, data) }
+func DecodeUint168(_ int, data []byte) *uint256.Int { return decodeUint(21, data) }
+func DecodeUint176(_ int, data []byte) *uint256.Int { return decodeUint(22, data) }
+func DecodeUint184(_ int, data []byte) *uint256.Int { return decodeUint(23, data) }
+func DecodeUint192(_ int, data []byte) *uint256.Int { return decodeUint(24, data) }
+func DecodeUint200(_ int, data []byte) *uint256.Int { return decodeUint(25, data) }
+func DecodeUint208(_ int, data []byte) *uint256.Int { return decodeUint(26, data) }
+func DecodeUint216(_ int, data []byte) *uint256.Int { return decodeUint(27, data) }
+func DecodeUint224(_ int, data []byte) *uint256.Int { return decodeUint(28, data) }
+func DecodeUint232(_ int, data []byte) *uint256.Int { return decodeUint(29, data) }
+func DecodeUint240(_ int, data []byte) *uint256.Int { return decodeUint(30, data) }
+func DecodeUint248(_ int, data []byte) *uint256.Int { return decodeUint(31, data) }
+
+// EncodeUint encodes an unsigned integer of any native width in size bytes,
+// big-endian. A single generic function covers all the widths up to 64 bits,
+// wider integers are represented as *uint256.Int and use the functions above.
+func EncodeUint[T constraints.Unsigned](size int, value T) []byte {
 	buf := make([]byte, 8)
-	binary.BigEndian.PutUint64(buf, value)
-	return buf
+	binary.BigEndian.PutUint64(buf, uint64(value))
+	return buf[8-size:]
 }
 
-func DecodeUint8(_ int, data []byte) uint8   { return data[0] }
-func DecodeUint16(_ int, data []byte) uint16 { return binary.BigEndian.Uint16(data) }
-func DecodeUint32(_ int, data []byte) uint32 { return binary.BigEndian.Uint32(data) }
-func DecodeUint64(_ int, data []byte) uint64 { return binary.BigEndian.Uint64(data) }
+func DecodeUint[T constraints.Unsigned](size int, data []byte) T {
+	var buf [8]byte
+	copy(buf[8-size:], data[:size])
+	return T(binary.BigEndian.Uint64(buf[:]))
+}
 
-func EncodeInt8(_ int, value int8) []byte {
-	return []byte{byte(value)}
+// EncodeInt encodes a signed integer of any native width in size bytes as its
+// big-endian two's complement.
+func EncodeInt[T constraints.Signed](size int, value T) []byte {
+	return EncodeUint(size, uint64(value))
+}
+
+func DecodeInt[T constraints.Signed](size int, data []byte) T {
+	shift := 64 - 8*size
+	return T(int64(DecodeUint[uint64](size, data)<<shift) >> shift)
+}
+
+// The fixed width functions below are kept for code generated by earlier
+// versions and are equivalent to the generic ones.
+
+func EncodeUint8(_ int, value uint8) []byte   { return EncodeUint(1, value) }
+func EncodeUint16(_ int, value uint16) []byte { return EncodeUint(2, value) }
+func EncodeUint32(_ int, value uint32) []byte { return EncodeUint(4, value) }
+func EncodeUint64(_ int, value uint64) []byte { return EncodeUint(8, value) }
+
+func DecodeUint8(_ int, data []byte) uint8   { return DecodeUint[uint8](1, data) }
+func DecodeUint16(_ int, data []byte) uint16 { return DecodeUint[uint16](2, data) }
+func DecodeUint32(_ int, data []byte) uint32 { return DecodeUint[uint32](4, data) }
+func DecodeUint64(_ int, data []byte) uint64 { return DecodeUint[uint64](8, data) }
+
+func EncodeInt8(_ int, value int8) []byte   { return EncodeInt(1, value) }
+func EncodeInt16(_ int, value int16) []byte { return EncodeInt(2, value) }
+func EncodeInt32(_ int, value int32) []byte { return EncodeInt(4, value) }
+func EncodeInt64(_ int, value int64) []byte { return EncodeInt(8, value) }
+
+func DecodeInt8(_ int, data []byte) int8   { return DecodeInt[int8](1, data) }
+func DecodeInt16(_ int, data []byte) int16 { return DecodeInt[int16](2, data) }
+func DecodeInt32(_ int, data []byte) int32 { return DecodeInt[int32](4, data) }
+func DecodeInt64(_ int, data []byte) int64 { return DecodeInt[int64](8, data) }
+
+// Signed integers wider than 64 bits are represented as *uint256.Int holding
+// the two's complement of the value, i.e. negative numbers are obtained with
+// Neg() and checked with Sign(). Encoding is the same as for unsigned integers
+// of the same width.
+
+func decodeSignedInt(size int, data []byte) *uint256.Int {
+	value := new(uint256.Int).SetBytes(data)
+	if size < 32 && len(data) > 0 && data[0]&0x80 != 0 {
+		value.ExtendSign(value, uint256.NewInt(uint64(size-1)))
+	}
+	return value
+}
+
+func EncodeInt128(_ int, value *uint256.Int) []byte { return encodeUint(16, value) }
+func EncodeInt256(_ int, value *uint256.Int) []byte { return encodeUint(32, value) }
+
+func DecodeInt128(_ int, data []byte) *uint256.Int { return decodeSignedInt(16, data) }
+func DecodeInt256(_ int, data []byte) *uint256.Int { return decodeSignedInt(32, data) }
+
+// Little-endian variants store the least significant byte first, e.g. to match
+// the layout used by an off-chain system. They take the same space as the
+// big-endian ones. Integers wider than 64 bits use the generic UintLE and IntLE
+// functions, which take the field size.
+
+func reverseBytes(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for ii, b := range data {
+		reversed[len(data)-1-ii] = b
+	}
+	return reversed
 }
 
-func EncodeInt16(_ int, value int16) []byte {
+func EncodeUint16LE(_ int, value uint16) []byte {
 	buf := make([]byte, 2)
-	binary.BigEndian.PutUint16(buf, uint16(value))
+	binary.LittleEndian.PutUint16(buf, value)
 	return buf
 }
 
-func EncodeInt32(_ int, value int32) []byte {
+func EncodeUint32LE(_ int, value uint32) []byte {
 	buf := make([]byte, 4)
-	binary.BigEndian.PutUint32(buf, uint32(value))
+	binary.LittleEndian.PutUint32(buf, value)
 	return buf
 }
 
-func EncodeInt64(_ int, value int64) []byte {
+func EncodeUint64LE(_ int, value uint64) []byte {
 	buf := make([]byte, 8)
-	binary.BigEndian.PutUint64(buf, uint64(value))
+	binary.LittleEndian.PutUint64(buf, value)
 	return buf
 }
 
-func DecodeInt8(_ int, data []byte) int8   { return int8(data[0]) }
-func DecodeInt16(_ int, data []byte) int16 { return int16(binary.BigEndian.Uint16(data)) }
-func DecodeInt32(_ int, data []byte) int32 { return int32(binary.BigEndian.Uint32(data)) }
-func DecodeInt64(_ int, data []byte) int64 { return int64(binary.BigEndian.Uint64(data)) }
+func DecodeUint16LE(_ int, data []byte) uint16 { return binary.LittleEndian.Uint16(data) }
+func DecodeUint32LE(_ int, data []byte) uint32 { return binary.LittleEndian.Uint32(data) }
+func DecodeUint64LE(_ int, data []byte) uint64 { return binary.LittleEndian.Uint64(data) }
+
+func EncodeInt16LE(_ int, value int16) []byte { return EncodeUint16LE(2, uint16(value)) }
+func EncodeInt32LE(_ int, value int32) []byte { return EncodeUint32LE(4, uint32(value)) }
+func EncodeInt64LE(_ int, value int64) []byte { return EncodeUint64LE(8, uint64(value)) }
+
+func DecodeInt16LE(_ int, data []byte) int16 { return int16(DecodeUint16LE(2, data)) }
+func DecodeInt32LE(_ int, data []byte) int32 { return int32(DecodeUint32LE(4, data)) }
+func DecodeInt64LE(_ int, data []byte) int64 { return int64(DecodeUint64LE(8, data)) }
+
+func EncodeUintLE(size int, value *uint256.Int) []byte {
+	return reverseBytes(encodeUint(size, value))
+}
+
+func DecodeUintLE(size int, data []byte) *uint256.Int {
+	return decodeUint(size, reverseBytes(data))
+}
+
+func EncodeIntLE(size int, value *uint256.Int) []byte {
+	return reverseBytes(encodeUint(size, value))
+}
+
+func DecodeIntLE(size int, data []byte) *uint256.Int {
+	return decodeSignedInt(size, reverseBytes(data))
+}
+
+// EncodeTimestamp encodes a time as uint64 unix seconds, dropping sub-second
+// precision. The zero time is encoded as 0. It panics for other times before
+// the unix epoch.
+func EncodeTimestamp(_ int, t time.Time) []byte {
+	if t.IsZero() {
+		return EncodeUint64(8, 0)
+	}
+	seconds := t.Unix()
+	if seconds < 0 {
+		panic("timestamp before unix epoch")
+	}
+	return EncodeUint64(8, uint64(seconds))
+}
+
+// DecodeTimestamp decodes uint64 unix seconds as a UTC time, with 0 decoded as
+// the zero time.
+func DecodeTimestamp(_ int, data []byte) time.Time {
+	seconds := DecodeUint64(8, data)
+	if seconds == 0 {
+		return time.Time{}
+	}
+	if seconds > math.MaxInt64 {
+		seconds = math.MaxInt64
+	}
+	return time.Unix(int64(seconds), 0).UTC()
+}
+
+// EncodeFloat64 encodes a float as its IEEE-754 bits in big-endian order. The
+// bits are stored as they are, so NaN payloads, infinities and negative zero
+// round-trip unchanged. The stored value is not meant for arithmetic on chain.
+func EncodeFloat64(_ int, f float64) []byte {
+	return EncodeUint64(8, math.Float64bits(f))
+}
+
+func DecodeFloat64(_ int, data []byte) float64 {
+	return math.Float64frombits(DecodeUint64(8, data))
+}
+
+// EncodeDuration encodes a duration as uint64 seconds, dropping sub-second
+// precision. It panics for negative durations.
+func EncodeDuration(_ int, d time.Duration) []byte {
+	if d < 0 {
+		panic("negative duration")
+	}
+	return EncodeUint64(8, uint64(d/time.Second))
+}
+
+// DecodeDuration decodes uint64 seconds as a duration, saturating at the
+// largest representable whole number of seconds so that the value round-trips.
+func DecodeDuration(_ int, data []byte) time.Duration {
+	seconds := DecodeUint64(8, data)
+	if seconds > uint64(math.MaxInt64/time.Second) {
+		seconds = uint64(math.MaxInt64 / time.Second)
+	}
+	return time.Duration(seconds) * time.Second
+}
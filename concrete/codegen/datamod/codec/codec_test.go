@@ -18,9 +18,14 @@
 package codec
 
 import (
+	"encoding/binary"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +40,42 @@ func TestCodec(t *testing.T) {
 		r.Equal(addr, decoded)
 	})
 
+	t.Run("address checksum", func(t *testing.T) {
+		r := require.New(t)
+		checksummed := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		addr := common.HexToAddress(checksummed)
+		for _, s := range []string{checksummed, strings.ToLower(checksummed), "0x" + strings.ToUpper(checksummed[2:])} {
+			decoded, err := DecodeAddressChecksummed(s)
+			r.NoError(err, s)
+			r.Equal(addr, decoded)
+		}
+
+		// Mixed case must match the checksum
+		_, err := DecodeAddressChecksummed("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+		r.ErrorIs(err, ErrInvalidChecksum)
+		r.ErrorContains(err, "expected "+checksummed)
+
+		for _, s := range []string{checksummed[2:], "0X" + checksummed[2:], checksummed[:41], checksummed + "0", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg"} {
+			_, err := DecodeAddressChecksummed(s)
+			r.ErrorContains(err, "invalid address", s)
+			r.NotErrorIs(err, ErrInvalidChecksum)
+		}
+	})
+
+	t.Run("function", func(t *testing.T) {
+		f := Function{Addr: common.HexToAddress("0x1234"), Selector: [4]byte{1, 2, 3, 4}}
+		encoded := EncodeFunction(24, f)
+		r.Len(encoded, 24)
+		r.Equal(f.Addr.Bytes(), encoded[:20])
+		r.Equal(f.Selector[:], encoded[20:])
+		r.Equal(f, DecodeFunction(24, encoded))
+
+		// All-zero data decodes to the zero function pointer
+		zero := DecodeFunction(24, make([]byte, 24))
+		r.True(zero.IsZero())
+		r.False(f.IsZero())
+	})
+
 	t.Run("bool", func(t *testing.T) {
 		encoded := EncodeBool(1, true)
 		decoded := DecodeBool(1, encoded)
@@ -57,9 +98,24 @@ func TestCodec(t *testing.T) {
 			encoded := EncodeFixedBytes(size, b)
 			decoded := DecodeFixedBytes(size, encoded)
 			r.Equal(b, decoded)
+			encoded = EncodeFixedBytesRight(size, b)
+			decoded = DecodeFixedBytesRight(size, encoded)
+			r.Equal(b, decoded)
 		}
 	})
 
+	t.Run("bytesN alignment", func(t *testing.T) {
+		b := []byte{0x00, 0x01, 0x02, 0x00}
+		r.Equal([]byte{0x00, 0x01, 0x02, 0x00, 0x00, 0x00}, EncodeFixedBytes(6, b))
+		r.Equal([]byte{0x00, 0x00, 0x00, 0x01, 0x02, 0x00}, EncodeFixedBytesRight(6, b))
+
+		// Padding is only stripped on the declared side
+		word := common.RightPadBytes(b, 32)
+		r.Equal(b, DecodeFixedBytes(4, word))
+		word = common.LeftPadBytes(b, 32)
+		r.Equal(b, DecodeFixedBytesRight(4, word))
+	})
+
 	t.Run("bytes", func(t *testing.T) {
 		b := []byte{0x01, 0x02, 0x03}
 		encoded := EncodeBytes(-1, b)
@@ -67,6 +123,21 @@ func TestCodec(t *testing.T) {
 		r.Equal(b, decoded)
 	})
 
+	t.Run("max length", func(t *testing.T) {
+		r.NoError(CheckMaxLen("data", 3, []byte{0x01, 0x02, 0x03}))
+		r.NoError(CheckMaxLen("data", 3, nil))
+		err := CheckMaxLen("data", 3, []byte{0x01, 0x02, 0x03, 0x04})
+		r.ErrorIs(err, ErrValueTooLong)
+		r.ErrorContains(err, "data is 4 bytes, at most 3 allowed")
+	})
+
+	t.Run("join keys", func(t *testing.T) {
+		r.Equal(JoinKeys([]byte{0x01}, []byte{0x02}), JoinKeys([]byte{0x01}, []byte{0x02}))
+		r.NotEqual(JoinKeys([]byte{0x01}, []byte{0x02}), JoinKeys([]byte{0x01, 0x02}))
+		r.NotEqual(JoinKeys([]byte{0x01}, nil), JoinKeys(nil, []byte{0x01}))
+		r.Equal("", JoinKeys())
+	})
+
 	t.Run("string", func(t *testing.T) {
 		str := "hello world"
 		encoded := EncodeString(-1, str)
@@ -81,6 +152,9 @@ func TestCodec(t *testing.T) {
 			decoded := DecodeUint256(32, encoded)
 			r.Equal(u.Uint64(), decoded.Uint64())
 		}
+		// Nil values are stored as zero
+		r.Equal(make([]byte, 32), EncodeUint256(32, nil))
+		r.Equal(make([]byte, 16), EncodeUint128(16, nil))
 	})
 
 	t.Run("uint8", func(t *testing.T) {
@@ -138,4 +212,254 @@ func TestCodec(t *testing.T) {
 		decoded := DecodeInt64(8, encoded)
 		r.Equal(u, decoded)
 	})
+
+	t.Run("generic", func(t *testing.T) {
+		// The generic codec matches encoding/binary at every native width
+		r.Equal([]byte{0xfe}, EncodeUint(1, uint8(0xfe)))
+		r.Equal(binary.BigEndian.AppendUint16(nil, 0xbeef), EncodeUint(2, uint16(0xbeef)))
+		r.Equal(binary.BigEndian.AppendUint32(nil, 0xdeadbeef), EncodeUint(4, uint32(0xdeadbeef)))
+		r.Equal(binary.BigEndian.AppendUint64(nil, math.MaxUint64), EncodeUint(8, uint64(math.MaxUint64)))
+		r.Equal(uint16(0xbeef), DecodeUint[uint16](2, []byte{0xbe, 0xef}))
+		r.Equal(uint32(math.MaxUint32), DecodeUint[uint32](4, EncodeUint(4, uint32(math.MaxUint32))))
+
+		// Signed values are sign extended from the field size
+		for _, v := range []int64{0, 1, -1, math.MinInt8, math.MaxInt8} {
+			r.Equal(int8(v), DecodeInt[int8](1, EncodeInt(1, int8(v))))
+		}
+		r.Equal([]byte{0xff, 0xfe}, EncodeInt(2, int16(-2)))
+		r.Equal(int16(-2), DecodeInt[int16](2, []byte{0xff, 0xfe}))
+		r.Equal(int32(math.MinInt32), DecodeInt[int32](4, EncodeInt(4, int32(math.MinInt32))))
+		r.Equal(int64(math.MinInt64), DecodeInt[int64](8, EncodeInt(8, int64(math.MinInt64))))
+
+		// The fixed width functions are equivalent
+		r.Equal(EncodeInt64(8, -12345), EncodeInt(8, int64(-12345)))
+		r.Equal(DecodeUint32(4, []byte{1, 2, 3, 4}), DecodeUint[uint32](4, []byte{1, 2, 3, 4}))
+	})
+
+	t.Run("int128", func(t *testing.T) {
+		max := new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 127), Uint256_1)
+		min := new(uint256.Int).Neg(new(uint256.Int).Lsh(Uint256_1, 127))
+		for _, u := range []*uint256.Int{
+			uint256.NewInt(123),
+			new(uint256.Int).Neg(uint256.NewInt(123)),
+			max,
+			min,
+		} {
+			encoded := EncodeInt128(16, u)
+			r.Len(encoded, 16)
+			decoded := DecodeInt128(16, encoded)
+			r.Equal(u, decoded)
+		}
+	})
+
+	t.Run("int256", func(t *testing.T) {
+		max := new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 255), Uint256_1)
+		min := new(uint256.Int).Neg(new(uint256.Int).Lsh(Uint256_1, 255))
+		for _, u := range []*uint256.Int{
+			uint256.NewInt(123),
+			new(uint256.Int).Neg(uint256.NewInt(123)),
+			max,
+			min,
+		} {
+			encoded := EncodeInt256(32, u)
+			r.Len(encoded, 32)
+			decoded := DecodeInt256(32, encoded)
+			r.Equal(u, decoded)
+		}
+	})
+	t.Run("littleEndian", func(t *testing.T) {
+		r.Equal([]byte{0x02, 0x01}, EncodeUint16LE(2, 0x0102))
+		r.Equal(uint16(0x0102), DecodeUint16LE(2, []byte{0x02, 0x01}))
+		r.Equal([]byte{0x04, 0x03, 0x02, 0x01}, EncodeUint32LE(4, 0x01020304))
+		r.Equal(uint32(0x01020304), DecodeUint32LE(4, EncodeUint32LE(4, 0x01020304)))
+		r.Equal(uint64(123), DecodeUint64LE(8, EncodeUint64LE(8, 123)))
+		r.Equal([]byte{0xfe, 0xff}, EncodeInt16LE(2, -2))
+		r.Equal(int16(-2), DecodeInt16LE(2, EncodeInt16LE(2, -2)))
+		r.Equal(int32(-123), DecodeInt32LE(4, EncodeInt32LE(4, -123)))
+		r.Equal(int64(-123), DecodeInt64LE(8, EncodeInt64LE(8, -123)))
+
+		u := uint256.NewInt(0x0102)
+		encoded := EncodeUintLE(16, u)
+		r.Len(encoded, 16)
+		r.Equal([]byte{0x02, 0x01}, encoded[:2])
+		r.Equal(u, DecodeUintLE(16, encoded))
+
+		neg := new(uint256.Int).Neg(uint256.NewInt(123))
+		encoded = EncodeIntLE(16, neg)
+		r.Len(encoded, 16)
+		r.Equal(byte(0xff), encoded[15])
+		r.Equal(neg, DecodeIntLE(16, encoded))
+		r.Equal(neg, DecodeIntLE(32, EncodeIntLE(32, neg)))
+	})
+	t.Run("uintN", func(t *testing.T) {
+		u := new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 160), Uint256_1)
+		encoded := EncodeUint160(20, u)
+		r.Len(encoded, 20)
+		r.Equal(u, DecodeUint160(20, encoded))
+
+		// Values wider than the field are truncated
+		encoded = EncodeUint128(16, u)
+		r.Len(encoded, 16)
+		r.Equal(new(uint256.Int).Sub(new(uint256.Int).Lsh(Uint256_1, 128), Uint256_1), DecodeUint128(16, encoded))
+
+		// High bytes are masked off when decoding
+		data := common.FromHex("0xffff000000000000000000000000000000000000000000000000000000000001")
+		r.Equal(Uint256_1, DecodeUint224(28, data))
+	})
+	t.Run("timestamp", func(t *testing.T) {
+		ts := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+		encoded := EncodeTimestamp(8, ts)
+		r.Equal(EncodeUint64(8, uint64(ts.Unix())), encoded)
+		r.Equal(ts, DecodeTimestamp(8, encoded))
+
+		// Sub-second precision is dropped
+		r.Equal(ts, DecodeTimestamp(8, EncodeTimestamp(8, ts.Add(500*time.Millisecond))))
+
+		r.Equal(time.Time{}, DecodeTimestamp(8, EncodeTimestamp(8, time.Time{})))
+		r.Panics(func() { EncodeTimestamp(8, time.Unix(-1, 0)) })
+	})
+	t.Run("duration", func(t *testing.T) {
+		d := 90 * time.Minute
+		encoded := EncodeDuration(8, d)
+		r.Equal(EncodeUint64(8, 5400), encoded)
+		r.Equal(d, DecodeDuration(8, encoded))
+
+		r.Panics(func() { EncodeDuration(8, -time.Second) })
+		max := DecodeDuration(8, EncodeUint64(8, math.MaxUint64))
+		r.Equal(math.MaxInt64/time.Second*time.Second, max)
+		r.Equal(max, DecodeDuration(8, EncodeDuration(8, max)))
+	})
+	t.Run("float64", func(t *testing.T) {
+		encoded := EncodeFloat64(8, 1.5)
+		r.Equal(common.FromHex("0x3ff8000000000000"), encoded)
+		r.Equal(1.5, DecodeFloat64(8, encoded))
+
+		for _, f := range []float64{0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1), math.SmallestNonzeroFloat64, -math.MaxFloat64} {
+			r.Equal(math.Float64bits(f), math.Float64bits(DecodeFloat64(8, EncodeFloat64(8, f))))
+		}
+
+		// NaN payloads are kept
+		nan := math.Float64frombits(0x7ff8000000000123)
+		r.True(math.IsNaN(DecodeFloat64(8, EncodeFloat64(8, nan))))
+		r.Equal(uint64(0x7ff8000000000123), math.Float64bits(DecodeFloat64(8, EncodeFloat64(8, nan))))
+	})
+	t.Run("fixed", func(t *testing.T) {
+		parse := func(bits, decimals int, signed bool, s string) *uint256.Int {
+			raw, err := ParseFixed(bits, decimals, signed, s)
+			r.NoError(err)
+			return raw
+		}
+
+		raw := parse(128, 18, false, "1.5")
+		r.Equal(uint256.NewInt(1_500_000_000_000_000_000), raw)
+		r.Equal("1.5", FixedString(18, false, raw))
+		r.Equal(1.5, FixedFloat64(18, false, raw))
+		r.Equal(raw, DecodeUfixed(16, EncodeUfixed(16, raw)))
+
+		neg := parse(128, 18, true, "-2.25")
+		r.Equal("-2.25", FixedString(18, true, neg))
+		r.Equal(-2.25, FixedFloat64(18, true, neg))
+		encoded := EncodeFixed(16, neg)
+		r.Len(encoded, 16)
+		r.Equal(neg, DecodeFixed(16, encoded))
+
+		r.Equal("0.001", FixedString(3, false, uint256.NewInt(1)))
+		r.Equal("7", FixedString(0, false, uint256.NewInt(7)))
+		r.Equal("-0.75", FixedString(18, true, FixedAdd(128, true, neg, raw)))
+		r.Equal("3.75", FixedString(18, true, FixedSub(128, true, raw, neg)))
+		r.Equal("-3.375", FixedString(18, true, FixedMul(128, 18, true, neg, raw)))
+		r.Equal("-1.5", FixedString(18, true, FixedDiv(128, 18, true, neg, raw)))
+		r.Equal(-1, FixedCmp(true, neg, raw))
+		r.Equal(1, FixedCmp(false, neg, raw))
+
+		for _, s := range []string{"", "-", "1.2345", "1e3", "-1", "256"} {
+			_, err := ParseFixed(8, 3, false, s)
+			r.Error(err, s)
+		}
+		_, err := ParseFixed(8, 0, true, "-128")
+		r.NoError(err)
+		_, err = ParseFixed(8, 0, true, "128")
+		r.Error(err)
+
+		max := parse(8, 0, false, "255")
+		r.True(FixedInRange(8, false, max))
+		r.False(FixedInRange(8, true, max))
+		r.Panics(func() { FixedAdd(8, false, max, Uint256_1) })
+		r.Panics(func() { FixedSub(8, false, Uint256_0, Uint256_1) })
+		r.Panics(func() { FixedDiv(8, 0, false, max, Uint256_0) })
+	})
+	t.Run("fixedString", func(t *testing.T) {
+		encoded := EncodeFixedString(8, "label")
+		r.Equal([]byte{'l', 'a', 'b', 'e', 'l', 0, 0, 0}, encoded)
+		r.Equal("label", DecodeFixedString(8, encoded))
+		r.Equal("", DecodeFixedString(8, make([]byte, 8)))
+		r.Equal("ünï", DecodeFixedString(32, EncodeFixedString(32, "ünï")))
+		r.Equal("12345678", DecodeFixedString(8, EncodeFixedString(8, "12345678")))
+		r.PanicsWithError("string too long for field: 9 bytes, at most 8 allowed", func() { EncodeFixedString(8, "123456789") })
+	})
+
+	t.Run("wordBytes", func(t *testing.T) {
+		word := common.HexToHash("0x0102").Bytes()
+		value, err := WordBytes(2, word)
+		r.NoError(err)
+		r.Equal([]byte{1, 2}, value)
+		r.Equal([]byte{1, 2}, MustWordBytes(2, word))
+		_, err = WordBytes(1, word)
+		r.ErrorIs(err, ErrDirtyBytes)
+		r.Panics(func() { MustWordBytes(1, word) })
+	})
+
+	t.Run("checkHash", func(t *testing.T) {
+		data := []byte("entry")
+		r.NoError(CheckHash("entry", data, crypto.Keccak256(data)))
+		r.NoError(CheckHash("entry", nil, make([]byte, 32)))
+		r.ErrorIs(CheckHash("entry", data, make([]byte, 32)), ErrHashMismatch)
+		r.EqualError(CheckHash("entry", nil, crypto.Keccak256(data)), "content does not match its hash: entry")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		minusOne := new(uint256.Int).Neg(uint256.NewInt(1))
+		r.Equal("-1", FormatInt(minusOne))
+		r.Equal("0", FormatInt(nil))
+		value, err := ParseInt(16, "-1")
+		r.NoError(err)
+		r.Equal(minusOne, value)
+		_, err = ParseInt(1, "128")
+		r.Error(err)
+
+		f := Function{Addr: common.HexToAddress("0x01"), Selector: [4]byte{0xde, 0xad, 0xbe, 0xef}}
+		text, err := f.MarshalText()
+		r.NoError(err)
+		r.Equal("0x0000000000000000000000000000000000000001deadbeef", string(text))
+		var decoded Function
+		r.NoError(decoded.UnmarshalText(text))
+		r.Equal(f, decoded)
+		r.Error(decoded.UnmarshalText([]byte("0x01")))
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		r.Equal(-1, Compare(1, 2))
+		r.Equal(1, Compare("b", "a"))
+		r.Equal(0, Compare(math.NaN(), math.NaN()))
+		r.Equal(-1, Compare(math.NaN(), math.Inf(-1)))
+		r.Equal(1, Compare(0.0, math.NaN()))
+		r.Equal(-1, CompareBool(false, true))
+		r.Equal(0, CompareBool(true, true))
+		r.Equal(1, CompareBytes([]byte{1, 0}, []byte{1}))
+		r.Equal(0, CompareUint256(nil, new(uint256.Int)))
+		r.Equal(1, CompareUint256(MaxUint256, Uint256_1))
+		r.Equal(-1, CompareInt256(MaxUint256, Uint256_1))
+		r.Equal(-1, CompareInt256(MaxUint256, nil))
+		r.Equal(1, CompareInt256(MaxUint256, new(uint256.Int).Sub(MaxUint256, Uint256_1)))
+		f := Function{Addr: common.HexToAddress("0x01"), Selector: [4]byte{2}}
+		r.Equal(-1, CompareFunction(f, Function{Addr: common.HexToAddress("0x02")}))
+		r.Equal(1, CompareFunction(f, Function{Addr: f.Addr, Selector: [4]byte{1}}))
+		r.Equal(-1, CompareSlices([]int{1, 2}, []int{1, 2, 0}, Compare[int]))
+		r.Equal(1, CompareSlices([]int{2}, []int{1, 2}, Compare[int]))
+		r.Equal(0, CompareOptional(false, false, func() int { return 1 }))
+		r.Equal(-1, CompareOptional(false, true, func() int { return 1 }))
+		r.Equal(1, CompareOptional(true, true, func() int { return 1 }))
+		r.Equal(-1, CompareAll(0, -1, 1))
+		r.Equal(0, CompareAll())
+	})
 }
@@ -0,0 +1,141 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/holiman/uint256"
+	"golang.org/x/exp/constraints"
+)
+
+// Comparison functions used by the generated Equal and Compare methods of row
+// values. They all return -1, 0 or 1 as bytes.Compare.
+
+// Compare compares two ordered values. NaNs are equal to each other and less
+// than any other float, so that floats have a total order.
+func Compare[T constraints.Ordered](a, b T) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || a < b:
+		if bNaN {
+			return 1
+		}
+		return -1
+	case bNaN || a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareBool orders false before true.
+func CompareBool[T ~bool](a, b T) int {
+	switch {
+	case a == b:
+		return 0
+	case !bool(a):
+		return -1
+	}
+	return 1
+}
+
+func CompareBytes[T ~[]byte](a, b T) int {
+	return bytes.Compare(a, b)
+}
+
+// CompareUint256 compares unsigned integers, with nil as zero.
+func CompareUint256(a, b *uint256.Int) int {
+	if a == nil {
+		a = Uint256_0
+	}
+	if b == nil {
+		b = Uint256_0
+	}
+	return a.Cmp(b)
+}
+
+// CompareInt256 compares signed integers in two's complement, with nil as zero.
+func CompareInt256(a, b *uint256.Int) int {
+	if a == nil {
+		a = Uint256_0
+	}
+	if b == nil {
+		b = Uint256_0
+	}
+	aNeg, bNeg := a.Sign() < 0, b.Sign() < 0
+	if aNeg != bNeg {
+		if aNeg {
+			return -1
+		}
+		return 1
+	}
+	// Two's complement values of the same sign order as unsigned ones
+	return a.Cmp(b)
+}
+
+// CompareBig compares integers represented as *big.Int, with nil as zero.
+func CompareBig(a, b *big.Int) int {
+	if a == nil {
+		a = new(big.Int)
+	}
+	if b == nil {
+		b = new(big.Int)
+	}
+	return a.Cmp(b)
+}
+
+// CompareFunction orders function pointers by address, then by selector.
+func CompareFunction(a, b Function) int {
+	if c := a.Addr.Cmp(b.Addr); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.Selector[:], b.Selector[:])
+}
+
+// CompareSlices compares two slices element by element, a shorter slice being
+// less than a longer one it is a prefix of.
+func CompareSlices[T any](a, b []T, compare func(a, b T) int) int {
+	for ii := 0; ii < len(a) && ii < len(b); ii++ {
+		if c := compare(a[ii], b[ii]); c != 0 {
+			return c
+		}
+	}
+	return Compare(len(a), len(b))
+}
+
+// CompareOptional compares two optional values, absent values being equal to
+// each other and less than present ones. The values are only compared if both
+// are present.
+func CompareOptional(hasA, hasB bool, compare func() int) int {
+	if hasA && hasB {
+		return compare()
+	}
+	return CompareBool(hasA, hasB)
+}
+
+// CompareAll returns the first non zero comparison result, e.g. to compare the
+// members of a struct in order.
+func CompareAll(results ...int) int {
+	for _, c := range results {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+// Fixed-point decimals are handled through their raw integer value, i.e. the
+// decimal value scaled by 10^decimals. Raw values of signed decimals are two's
+// complement numbers sign extended to 256 bits, as with signed integers.
+// Arithmetic is checked and panics if the result does not fit in the declared
+// number of bits.
+
+const fixedOverflow = "fixed-point overflow"
+
+func EncodeFixed(size int, raw *uint256.Int) []byte {
+	return encodeUint(size, raw)
+}
+
+func DecodeFixed(size int, data []byte) *uint256.Int {
+	return decodeSignedInt(size, data)
+}
+
+func EncodeUfixed(size int, raw *uint256.Int) []byte {
+	return encodeUint(size, raw)
+}
+
+func DecodeUfixed(size int, data []byte) *uint256.Int {
+	return decodeUint(size, data)
+}
+
+func fixedToBig(signed bool, raw *uint256.Int) *big.Int {
+	if signed && raw.Sign() < 0 {
+		abs := new(uint256.Int).Neg(raw)
+		return new(big.Int).Neg(abs.ToBig())
+	}
+	return raw.ToBig()
+}
+
+func fixedFromBig(bits int, signed bool, v *big.Int) (*uint256.Int, bool) {
+	if signed {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		if v.Cmp(limit) >= 0 || v.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, false
+		}
+		raw, _ := uint256.FromBig(new(big.Int).Abs(v))
+		if v.Sign() < 0 {
+			raw.Neg(raw)
+		}
+		return raw, true
+	}
+	if v.Sign() < 0 || v.BitLen() > bits {
+		return nil, false
+	}
+	raw, _ := uint256.FromBig(v)
+	return raw, true
+}
+
+func mustFixedFromBig(bits int, signed bool, v *big.Int) *uint256.Int {
+	raw, ok := fixedFromBig(bits, signed, v)
+	if !ok {
+		panic(fixedOverflow)
+	}
+	return raw
+}
+
+func fixedScale(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// FixedInRange reports whether raw is a valid raw value for a decimal of the
+// given number of bits.
+func FixedInRange(bits int, signed bool, raw *uint256.Int) bool {
+	_, ok := fixedFromBig(bits, signed, fixedToBig(signed, raw))
+	return ok
+}
+
+func FixedAdd(bits int, signed bool, a, b *uint256.Int) *uint256.Int {
+	sum := new(big.Int).Add(fixedToBig(signed, a), fixedToBig(signed, b))
+	return mustFixedFromBig(bits, signed, sum)
+}
+
+func FixedSub(bits int, signed bool, a, b *uint256.Int) *uint256.Int {
+	diff := new(big.Int).Sub(fixedToBig(signed, a), fixedToBig(signed, b))
+	return mustFixedFromBig(bits, signed, diff)
+}
+
+// FixedMul multiplies two decimals, truncating the result towards zero.
+func FixedMul(bits int, decimals int, signed bool, a, b *uint256.Int) *uint256.Int {
+	prod := new(big.Int).Mul(fixedToBig(signed, a), fixedToBig(signed, b))
+	return mustFixedFromBig(bits, signed, prod.Quo(prod, fixedScale(decimals)))
+}
+
+// FixedDiv divides two decimals, truncating the result towards zero. It panics
+// if b is zero.
+func FixedDiv(bits int, decimals int, signed bool, a, b *uint256.Int) *uint256.Int {
+	if b.IsZero() {
+		panic("fixed-point division by zero")
+	}
+	num := new(big.Int).Mul(fixedToBig(signed, a), fixedScale(decimals))
+	return mustFixedFromBig(bits, signed, num.Quo(num, fixedToBig(signed, b)))
+}
+
+// FixedCmp compares two decimals and returns -1, 0 or +1.
+func FixedCmp(signed bool, a, b *uint256.Int) int {
+	return fixedToBig(signed, a).Cmp(fixedToBig(signed, b))
+}
+
+// FixedString formats a decimal without trailing zeros, e.g. "-1.25".
+func FixedString(decimals int, signed bool, raw *uint256.Int) string {
+	v := fixedToBig(signed, raw)
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+		v.Neg(v)
+	}
+	intPart, fracPart := new(big.Int).QuoRem(v, fixedScale(decimals), new(big.Int))
+	if fracPart.Sign() == 0 {
+		return sign + intPart.String()
+	}
+	frac := fmt.Sprintf("%0*s", decimals, fracPart.String())
+	return sign + intPart.String() + "." + strings.TrimRight(frac, "0")
+}
+
+// FixedFloat64 returns the nearest float64 to a decimal.
+func FixedFloat64(decimals int, signed bool, raw *uint256.Int) float64 {
+	v := new(big.Float).SetInt(fixedToBig(signed, raw))
+	f, _ := v.Quo(v, new(big.Float).SetInt(fixedScale(decimals))).Float64()
+	return f
+}
+
+// ParseFixed parses a decimal string such as "-1.25" into its raw value. It
+// fails if the string has more fractional digits than decimals or if the
+// value does not fit in the given number of bits.
+func ParseFixed(bits int, decimals int, signed bool, s string) (*uint256.Int, error) {
+	str := s
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = str[1:]
+	}
+	intStr, fracStr, _ := strings.Cut(str, ".")
+	if intStr == "" || len(fracStr) > decimals || strings.Trim(intStr+fracStr, "0123456789") != "" {
+		return nil, fmt.Errorf("invalid decimal %q with %d decimals", s, decimals)
+	}
+	v, _ := new(big.Int).SetString(intStr+fracStr+strings.Repeat("0", decimals-len(fracStr)), 10)
+	if negative {
+		v.Neg(v)
+	}
+	raw, ok := fixedFromBig(bits, signed, v)
+	if !ok {
+		return nil, fmt.Errorf("decimal %q out of range", s)
+	}
+	return raw, nil
+}
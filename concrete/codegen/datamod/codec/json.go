@@ -0,0 +1,93 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package codec
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+// Values are marshaled to JSON in a readable form by the generated MarshalJSON
+// methods: bytes as hex strings and signed integers wider than 64 bits as
+// decimal strings, as *uint256.Int already does for unsigned ones.
+
+// FormatInt formats a signed integer represented as a *uint256.Int in two's
+// complement as a decimal string, e.g. "-1". A nil value formats as "0".
+func FormatInt(value *uint256.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return FixedString(0, true, value)
+}
+
+// ParseInt parses a decimal string such as "-1" into a signed integer of size
+// bytes represented as a *uint256.Int in two's complement.
+func ParseInt(size int, s string) (*uint256.Int, error) {
+	return ParseFixed(size*8, 0, true, s)
+}
+
+// FormatBig formats an integer represented as a *big.Int as a decimal string,
+// as FormatInt does. A nil value formats as "0".
+func FormatBig(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
+
+// ParseUintBig parses a decimal string into an unsigned integer of size bytes
+// represented as a *big.Int.
+func ParseUintBig(size int, s string) (*big.Int, error) {
+	return parseBig(size, false, s)
+}
+
+// ParseIntBig parses a decimal string such as "-1" into a signed integer of
+// size bytes represented as a *big.Int.
+func ParseIntBig(size int, s string) (*big.Int, error) {
+	return parseBig(size, true, s)
+}
+
+func parseBig(size int, signed bool, s string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	if _, ok := fixedFromBig(size*8, signed, value); !ok {
+		return nil, fmt.Errorf("%w: %s does not fit in %d bits", ErrIntOutOfRange, s, size*8)
+	}
+	return value, nil
+}
+
+// MarshalText encodes the function as the hex string of its 24 bytes, the
+// address followed by the selector, as solidity encodes function types.
+func (f Function) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(EncodeFunction(24, f)).MarshalText()
+}
+
+func (f *Function) UnmarshalText(input []byte) error {
+	var data hexutil.Bytes
+	if err := data.UnmarshalText(input); err != nil {
+		return err
+	}
+	if len(data) != 24 {
+		return fmt.Errorf("invalid function: expected 24 bytes, got %d", len(data))
+	}
+	*f = DecodeFunction(24, data)
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var orderAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+@order\s+([0-9]+)$`)
+
+// splitOrderAnnotation splits a value type into its type and its rank in the
+// ordering of rows, e.g. `uint64 @order 0`. The rank is -1 if the value does
+// not take part in the ordering.
+func splitOrderAnnotation(typeStr string) (string, int, error) {
+	matches := orderAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, -1, nil
+	}
+	order, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, err
+	}
+	return matches[1], order, nil
+}
+
+// valuesMethods are the generated methods of the values struct of a row.
+var valuesMethods = map[string]bool{"Equal": true, "Compare": true, "Less": true}
+
+// checkOrderedValues returns an error if a table value is ordered, two values
+// have the same rank or a value clashes with a method of the values struct.
+func checkOrderedValues(tableName string, values []FieldSchema) error {
+	ranks := make(map[int]FieldSchema)
+	for _, value := range values {
+		if value.Type.Type != TableType && valuesMethods[value.Title] {
+			return fmt.Errorf("invalid value schema for table '%s': value '%s' conflicts with values method %s", tableName, value.Name, value.Title)
+		}
+		if !value.Ordered {
+			continue
+		}
+		if value.Type.Type == TableType {
+			return fmt.Errorf("invalid order schema for table '%s': value '%s' is a table", tableName, value.Name)
+		}
+		if other, ok := ranks[value.Order]; ok {
+			return fmt.Errorf("invalid order schema for table '%s': values '%s' and '%s' both have rank %d", tableName, other.Name, value.Name, value.Order)
+		}
+		ranks[value.Order] = value
+	}
+	return nil
+}
+
+// OrderedValues returns the values the rows are ordered by, by increasing rank.
+func (s TableSchema) OrderedValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		if value.Ordered {
+			values = append(values, value)
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Order < values[j].Order
+	})
+	return values
+}
+
+// OrderedNames returns the comma separated names of the ordered values.
+func (s TableSchema) OrderedNames() string {
+	var names []string
+	for _, value := range s.OrderedValues() {
+		names = append(names, value.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// CompareExpr returns a go expression comparing the value in the values
+// structs a and b, evaluating to -1, 0 or 1.
+func (f FieldSchema) CompareExpr(a, b string) string {
+	expr := compareExpr(f.Type, a+"."+f.Title, b+"."+f.Title)
+	if f.Optional {
+		return fmt.Sprintf("codec.CompareOptional(%s.Has%s, %s.Has%s, func() int { return %s })", a, f.Title, b, f.Title, expr)
+	}
+	return expr
+}
+
+// compareExpr returns a go expression comparing two values of the given type
+// with the comparison functions of the codec package.
+func compareExpr(t FieldType, a, b string) string {
+	switch {
+	case t.Elem != nil:
+		elem := fmt.Sprintf("func(x, y %s) int { return %s }", t.Elem.GoType, compareExpr(*t.Elem, "x", "y"))
+		if t.Type == DynamicArrayType {
+			return fmt.Sprintf("codec.CompareSlices(%s, %s, %s)", a, b, elem)
+		}
+		return fmt.Sprintf("codec.CompareSlices(%s[:], %s[:], %s)", a, b, elem)
+	case t.Struct != nil:
+		var members []string
+		for _, member := range t.Struct.Members {
+			members = append(members, compareExpr(member.Type, a+"."+member.Title, b+"."+member.Title))
+		}
+		return fmt.Sprintf("codec.CompareAll(%s)", strings.Join(members, ", "))
+	case t.IsCBOR():
+		return fmt.Sprintf("codec.CompareBytes(%s(0, %s), %s(0, %s))", t.EncodeFunc, a, t.EncodeFunc, b)
+	case t.GoTypeOverride != nil:
+		return compareExpr(t.GoTypeOverride.Base, a, b)
+	case t.Fixed != nil:
+		return fmt.Sprintf("%s.Cmp(%s)", a, b)
+	case t.Flags != nil && t.Flags.IsArray():
+		return fmt.Sprintf("codec.CompareBytes(%s[:], %s[:])", a, b)
+	}
+	switch t.GoType {
+	case "common.Address", "common.Hash":
+		// Named types of overrides have no Cmp method
+		return fmt.Sprintf("codec.CompareBytes(%s[:], %s[:])", a, b)
+	case "[]byte":
+		return fmt.Sprintf("codec.CompareBytes(%s, %s)", a, b)
+	case "bool":
+		return fmt.Sprintf("codec.CompareBool(%s, %s)", a, b)
+	case "*uint256.Int":
+		if strings.HasPrefix(t.Name, "int") {
+			return fmt.Sprintf("codec.CompareInt256(%s, %s)", a, b)
+		}
+		return fmt.Sprintf("codec.CompareUint256(%s, %s)", a, b)
+	case "*big.Int":
+		return fmt.Sprintf("codec.CompareBig(%s, %s)", a, b)
+	case "time.Time":
+		return fmt.Sprintf("%s.Compare(%s)", a, b)
+	case "codec.Function":
+		return fmt.Sprintf("codec.CompareFunction(%s, %s)", a, b)
+	}
+	// Integers, strings, floats, durations, enums and flags
+	return fmt.Sprintf("codec.Compare(%s, %s)", a, b)
+}
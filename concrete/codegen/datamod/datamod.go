@@ -20,8 +20,11 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/bits"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -31,27 +34,403 @@ import (
 //go:embed table.tpl
 var tableTpl string
 
+//go:embed enum.tpl
+var enumTpl string
+
+//go:embed gotype.tpl
+var goTypeTpl string
+
+//go:embed struct.tpl
+var structTpl string
+
+//go:embed fixed.tpl
+var fixedTpl string
+
+//go:embed fuzz.tpl
+var fuzzTpl string
+
+//go:embed bench.tpl
+var benchTpl string
+
+//go:embed memory.tpl
+var memoryTpl string
+
+//go:embed view.tpl
+var viewTpl string
+
+//go:embed flags.tpl
+var flagsTpl string
+
 type FieldSchema struct {
 	Name  string
 	Title string
 	Index int
 	Type  FieldType
+	// Optional values track whether they are set with a bit in the presence
+	// bitmap of the row.
+	Optional    bool
+	PresenceBit int
+	// Pinned values are stored at a fixed slot of the row instead of being
+	// packed after the previous values.
+	Pinned bool
+	Slot   int
+	// Ordered values are compared by increasing Order in the generated
+	// Compare and Less methods of the values struct.
+	Ordered bool
+	Order   int
+	// Values with a default read as it while all the slots of the row are
+	// zero, e.g. before the row is first written. Default is the go
+	// expression of the value, empty if there is none.
+	Default string
+	// Capped dynamic values cannot be set to more than MaxLen bytes, the
+	// generated setters return an error instead.
+	MaxLen int
+	// Hashed dynamic values store the keccak256 hash of their content in an
+	// extra slot of the row, written along with the content and checked by
+	// the generated Verify method.
+	Hashed bool
 }
 
 type TableSchema struct {
 	Name   string
 	Keys   []FieldSchema
 	Values []FieldSchema
+	// CompositeKey derives row slots from the hash of all keys concatenated
+	// instead of nesting one mapping per key. Keys are concatenated as with
+	// abi.encodePacked in solidity, or abi.encode with PaddedKeys, so
+	// little-endian keys are hashed big-endian.
+	CompositeKey bool
+	// PaddedKeys hashes every key as a 32-byte ABI word, as solidity does
+	// for the keys of mappings, instead of its encoding without padding. The
+	// slot of the value for a key in a mapping at slot s is then
+	// keccak256(abi.encode(key, s)), and keccak256(abi.encode(key1, key2,
+	// ..., s)) for composite keys. Dynamic keys are hashed unpadded either way,
+	// and keys that are already words, such as uint256 or bytes32, hash the
+	// same with both packings.
+	PaddedKeys bool
+	// Iterable maintains an index of the keys of all written rows so they
+	// can be enumerated, at the cost of extra writes.
+	Iterable bool
+	// KeyHashOrder keeps the index of an iterable table sorted by the
+	// keccak256 hash of the packed keys of the rows instead of in insertion
+	// order, so that tables with the same rows enumerate them identically
+	// whatever the order they were written in. Inserting or deleting a row
+	// moves every key after it in the index.
+	KeyHashOrder bool
+	// BaseSlot is the number of slots the base slot of the table is offset
+	// by, e.g. to keep apart the tables of packages sharing the storage of a
+	// contract. GenerateDataModel adds Config.BaseSlot to it.
+	BaseSlot uint64
+	// Emit is the signature of the event logged by tables created with
+	// New<Table>WithEvents every time a value of a row is written, empty if
+	// none. Its arguments are the hash of the packed keys, the index of the
+	// value and its new encoding.
+	Emit string
+	// Initialized stores a flag in an extra field of every row, set by the
+	// generated Create method and cleared by Delete, so that created rows can
+	// be told apart from rows whose values are all zero.
+	Initialized bool
+	// StrictInit makes the setters of rows of initialized tables panic with
+	// codec.ErrRowNotCreated unless the row was created.
+	StrictInit bool
+}
+
+// Key packings of the keyPacking property of tables. Keys are packed by
+// default.
+const (
+	KeyPackingPacked = "packed"
+	KeyPackingPadded = "padded"
+)
+
+// Iteration orders of the order property of iterable tables. Rows are
+// enumerated in insertion order by default, with deleted rows replaced by the
+// last one.
+const (
+	OrderInsertion = "insertion"
+	OrderKeyHash   = "keyhash"
+)
+
+// InitializedStrict is the value of the initialized property of tables whose
+// rows must be created before being written.
+const InitializedStrict = "strict"
+
+// DefaultEmitSignature is the signature of the events of tables annotated with
+// `"emit": true`.
+const DefaultEmitSignature = "RowUpdated(bytes32,uint256,bytes)"
+
+// PresenceByte returns the index of the byte of the presence bitmap holding
+// the presence bit of an optional value.
+func (f FieldSchema) PresenceByte() int {
+	return f.PresenceBit / 8
+}
+
+// PresenceFlag returns the mask of the presence bit of an optional value within
+// its byte of the presence bitmap.
+func (f FieldSchema) PresenceFlag() string {
+	return fmt.Sprintf("0x%02x", 1<<(f.PresenceBit%8))
+}
+
+// RowValues returns the values that are part of the values struct of a row,
+// i.e. all except tables.
+func (s TableSchema) RowValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		if value.Type.Type != TableType {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// PackedValues returns the values stored in the row slots themselves, which
+// can be read and written together.
+func (s TableSchema) PackedValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		if value.Type.Type == ValueType {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// PackedIndices returns the comma separated row field indices of the packed
+// values, followed by the index of the presence bitmap if any.
+func (s TableSchema) PackedIndices() string {
+	var indices []string
+	for _, value := range s.PackedValues() {
+		indices = append(indices, fmt.Sprint(value.Index))
+	}
+	if s.HasOptional() {
+		indices = append(indices, fmt.Sprint(s.PresenceIndex()))
+	}
+	return strings.Join(indices, ", ")
+}
+
+// FuzzValues returns the values covered by the generated fuzz and benchmark
+// harnesses, i.e.
+// scalar values and bytes. Enums and structs are left out as decoding them
+// does not accept arbitrary data, and so are cbor values.
+func (s TableSchema) FuzzValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		fieldType := value.Type
+		if fieldType.Type > BytesType || fieldType.Elem != nil || fieldType.Enum != nil || fieldType.Struct != nil || fieldType.IsCBOR() {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// HasCBOR reports whether any field of the table is a cbor value, in which
+// case the generated file is guarded by the CBORBuildTag build tag.
+func (s TableSchema) HasCBOR() bool {
+	for _, field := range append(append([]FieldSchema{}, s.Keys...), s.Values...) {
+		if field.Type.IsCBOR() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPinnedSlots reports whether any value of the table is pinned to a slot.
+func (s TableSchema) HasPinnedSlots() bool {
+	for _, value := range s.Values {
+		if value.Pinned {
+			return true
+		}
+	}
+	return false
+}
+
+func (s TableSchema) optionalCount() int {
+	count := 0
+	for _, value := range s.Values {
+		if value.Optional {
+			count++
+		}
+	}
+	return count
+}
+
+// HasOptional reports whether the table has optional values, in which case
+// the row struct has an extra field holding the presence bitmap.
+func (s TableSchema) HasOptional() bool {
+	return s.optionalCount() > 0
+}
+
+// HasDefaults reports whether the table has values with a default.
+func (s TableSchema) HasDefaults() bool {
+	for _, value := range s.Values {
+		if value.Default != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CappedValues returns the values with a maximum length, whose setters return
+// an error.
+func (s TableSchema) CappedValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		if value.MaxLen > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Packable reports whether the values of the table can be concatenated as
+// with abi.encodePacked, which solidity does not allow for structs.
+func (s TableSchema) Packable() bool {
+	for _, value := range s.RowValues() {
+		if value.Type.Struct != nil || value.Type.IsCBOR() {
+			return false
+		}
+	}
+	return true
+}
+
+// rowResult is a named result of the multi-return row getters.
+type rowResult struct {
+	Name   string
+	GoType string
+}
+
+// RowResults returns the named results of the multi-return row getters, the
+// row values followed by whether they are set for optional values.
+func (s TableSchema) RowResults() []rowResult {
+	var results []rowResult
+	for _, value := range s.RowValues() {
+		results = append(results, rowResult{value.Name, value.Type.GoType})
+		if value.Optional {
+			results = append(results, rowResult{"has" + value.Title, "bool"})
+		}
+	}
+	return results
+}
+
+// KeyEncodeExpr returns a go expression encoding a key as it is hashed to
+// derive the slot of a row, according to the key packing of the table.
+func (s TableSchema) KeyEncodeExpr(key FieldSchema) string {
+	switch {
+	case s.PaddedKeys:
+		return key.Type.PaddedEncodeExpr(key.Name)
+	case s.CompositeKey:
+		return key.Type.PackedEncodeExpr(key.Name)
+	default:
+		return fmt.Sprintf("%s(%d, %s)", key.Type.EncodeFunc, key.Type.Size, key.Name)
+	}
+}
+
+// EmitName returns the name of the event of the table.
+func (s TableSchema) EmitName() string {
+	name, _, _ := strings.Cut(s.Emit, "(")
+	return name
+}
+
+// PresenceIndex returns the row field index of the presence bitmap.
+func (s TableSchema) PresenceIndex() int {
+	return len(s.Values)
+}
+
+// HashedValues returns the hashed values of the table.
+func (s TableSchema) HashedValues() []FieldSchema {
+	var values []FieldSchema
+	for _, value := range s.Values {
+		if value.Hashed {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// HashIndex returns the row field index of the hash of a hashed value. Hashes
+// are stored after the values and the presence bitmap, in schema order.
+func (s TableSchema) HashIndex(value FieldSchema) int {
+	index := len(s.Values)
+	if s.HasOptional() {
+		index++
+	}
+	for _, other := range s.Values[:value.Index] {
+		if other.Hashed {
+			index++
+		}
+	}
+	return index
+}
+
+// ExistsIndex returns the row field index of the flag of initialized tables,
+// stored after the presence bitmap and the hashes.
+func (s TableSchema) ExistsIndex() int {
+	index := len(s.Values) + len(s.HashedValues())
+	if s.HasOptional() {
+		index++
+	}
+	return index
+}
+
+// PresenceSize returns the size in bytes of the presence bitmap.
+func (s TableSchema) PresenceSize() int {
+	return (s.optionalCount() + 7) / 8
+}
+
+// PresenceMask returns a go expression for the presence bitmap with all
+// optional values set.
+func (s TableSchema) PresenceMask() string {
+	mask := make([]string, s.PresenceSize())
+	for ii := range mask {
+		bits := s.optionalCount() - ii*8
+		if bits > 8 {
+			bits = 8
+		}
+		mask[ii] = fmt.Sprintf("0x%02x", (1<<bits)-1)
+	}
+	return fmt.Sprintf("[]byte{%s}", strings.Join(mask, ", "))
 }
 
 func newFieldSchema(name string, index int, typeStr string) (FieldSchema, error) {
 	if !isValidName(name) {
 		return FieldSchema{}, fmt.Errorf("invalid field name '%s'", name)
 	}
-	fieldType, err := nameToFieldType(typeStr)
+	baseTypeStr, bigInt := splitBigIntAnnotation(typeStr)
+	baseTypeStr, goTypeStr := splitGoTypeAnnotation(baseTypeStr)
+	baseTypeStr, endian := splitEndianAnnotation(baseTypeStr)
+	baseTypeStr, align := splitAlignAnnotation(baseTypeStr)
+	fieldType, err := nameToFieldType(baseTypeStr)
 	if err != nil {
 		return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': %w", typeStr, name, err)
 	}
+	if align != "" {
+		fieldType, err = withAlign(fieldType, align)
+		if err != nil {
+			return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': %w", typeStr, name, err)
+		}
+	}
+	if endian != "" {
+		fieldType, err = withEndian(fieldType, endian)
+		if err != nil {
+			return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': %w", typeStr, name, err)
+		}
+	}
+	if bigInt {
+		fieldType, err = withBigInt(fieldType)
+		if err != nil {
+			return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': %w", typeStr, name, err)
+		}
+	}
+	if fieldType.Name == "cbor" && goTypeStr == "" {
+		return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': cbor values need a gotype annotation", typeStr, name)
+	}
+	if goTypeStr != "" {
+		override, err := newGoTypeOverride(fieldType, goTypeStr)
+		if err != nil {
+			return FieldSchema{}, fmt.Errorf("invalid type '%s' for field '%s': %w", typeStr, name, err)
+		}
+		fieldType = withGoTypeOverride(fieldType, override)
+	}
 	return FieldSchema{
 		Name:  lowerFirstLetter(name),
 		Title: upperFirstLetter(name),
@@ -60,7 +439,102 @@ func newFieldSchema(name string, index int, typeStr string) (FieldSchema, error)
 	}, nil
 }
 
+// rowMethods are the methods of a generated row other than value accessors.
+var rowMethods = map[string]bool{
+	"Get": true, "Set": true, "Delete": true, "Clear": true, "IsZero": true,
+	"GetValues": true, "SetValues": true, "GetField": true, "SetField": true,
+	"GetFields": true, "SetFields": true, "GetField_slot": true,
+	"GetField_bytes": true, "SetField_bytes": true, "GetBase_slot": true,
+}
+
+// checkTableValueNames checks that the accessor named after every table value
+// does not clash with another method of the row.
+func checkTableValueNames(tableName string, values []FieldSchema) error {
+	accessors := make(map[string]string)
+	for _, value := range values {
+		for _, prefix := range []string{"Get", "Set", "Clear"} {
+			accessors[prefix+value.Title] = value.Name
+		}
+		accessors["Get"+value.Title+"Array"] = value.Name
+		if value.Type.Counter {
+			for _, prefix := range []string{"Next", "Current", "Increment", "Decrement"} {
+				accessors[prefix+value.Title] = value.Name
+			}
+		}
+	}
+	for _, value := range values {
+		if value.Type.Type != TableType {
+			continue
+		}
+		if rowMethods[value.Title] {
+			return fmt.Errorf("invalid value schema for table '%s': table value '%s' conflicts with row method %s", tableName, value.Name, value.Title)
+		}
+		if other, ok := accessors[value.Title]; ok {
+			return fmt.Errorf("invalid value schema for table '%s': table value '%s' conflicts with the accessors of value '%s'", tableName, value.Name, other)
+		}
+	}
+	return nil
+}
+
+// UnmarshalTableSchemas parses the table schemas of a schema file in the DSL
+// format. Errors in the schema of a table are *SchemaError values giving the
+// position of the offending element.
 func UnmarshalTableSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSchema, error) {
+	schemas, _, err := unmarshalDSLSchemas(jsonContent, allowTableTypes)
+	return schemas, err
+}
+
+// unmarshalDSLSchemas parses the table and view schemas of a schema file in
+// the DSL format.
+func unmarshalDSLSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSchema, []ViewSchema, error) {
+	positions := indexPositions(jsonContent)
+	return unmarshalTableSchemas(jsonContent, allowTableTypes, func(err error, path []string) error {
+		return positions.wrap(err, path...)
+	})
+}
+
+// unmarshalTableSchemas parses table and view schemas in the DSL format and
+// positions errors with wrap, given the path of the element being parsed in
+// the DSL.
+func unmarshalTableSchemas(jsonContent []byte, allowTableTypes bool, wrap func(err error, path []string) error) ([]TableSchema, []ViewSchema, error) {
+	var path []string
+	schemas, views, err := parseTableSchemas(jsonContent, allowTableTypes, &path)
+	if err != nil {
+		return []TableSchema{}, nil, wrap(err, path)
+	}
+	return schemas, views, nil
+}
+
+// parseTableSchemas parses table and view schemas in the DSL format, keeping
+// at set to the path of the element being parsed. Views are parsed after all
+// the tables they can refer to.
+func parseTableSchemas(jsonContent []byte, allowTableTypes bool, at *[]string) ([]TableSchema, []ViewSchema, error) {
+	tableSchemas, err := parseTables(jsonContent, allowTableTypes, at)
+	if err != nil {
+		return []TableSchema{}, nil, err
+	}
+	jsonSchemas := orderedmap.New()
+	if err := json.Unmarshal(jsonContent, &jsonSchemas); err != nil {
+		return []TableSchema{}, nil, err
+	}
+	var viewSchemas []ViewSchema
+	for _, viewName := range jsonSchemas.Keys() {
+		jsonViewSchema, _ := jsonSchemas.Get(viewName)
+		if !isViewSchema(jsonViewSchema) {
+			continue
+		}
+		*at = []string{viewName}
+		viewSchema, err := parseViewSchema(viewName, jsonViewSchema.(orderedmap.OrderedMap), tableSchemas, at)
+		if err != nil {
+			return []TableSchema{}, nil, err
+		}
+		viewSchemas = append(viewSchemas, viewSchema)
+	}
+	return tableSchemas, viewSchemas, nil
+}
+
+// parseTables parses the table schemas in the DSL format, skipping views.
+func parseTables(jsonContent []byte, allowTableTypes bool, at *[]string) ([]TableSchema, error) {
 	jsonSchemas := orderedmap.New()
 	err := json.Unmarshal(jsonContent, &jsonSchemas)
 	if err != nil {
@@ -69,6 +543,7 @@ func UnmarshalTableSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSch
 
 	var tableSchemas []TableSchema
 	for _, tableName := range jsonSchemas.Keys() {
+		*at = []string{tableName}
 		_jsonTableSchema, _ := jsonSchemas.Get(tableName)
 		jsonTableSchema, ok := _jsonTableSchema.(orderedmap.OrderedMap)
 		if !ok {
@@ -78,6 +553,9 @@ func UnmarshalTableSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSch
 		if !isValidName(tableName) {
 			return []TableSchema{}, fmt.Errorf("invalid table name '%s'", tableName)
 		}
+		if isViewSchema(jsonTableSchema) {
+			continue
+		}
 		if len(jsonTableSchema.Keys()) == 0 {
 			return []TableSchema{}, fmt.Errorf("no schema for table '%s'", tableName)
 		}
@@ -86,11 +564,13 @@ func UnmarshalTableSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSch
 
 		_jsonKeySchema, ok := jsonTableSchema.Get("keySchema")
 		if ok {
+			*at = []string{tableName, "keySchema"}
 			jsonKeySchema, ok := _jsonKeySchema.(orderedmap.OrderedMap)
 			if !ok {
 				return []TableSchema{}, fmt.Errorf("invalid key schema for table '%s'", tableName)
 			}
 			for _, keyName := range jsonKeySchema.Keys() {
+				*at = []string{tableName, "keySchema", keyName}
 				_keyType, _ := jsonKeySchema.Get(keyName)
 				keyType, ok := _keyType.(string)
 				if !ok {
@@ -103,89 +583,813 @@ func UnmarshalTableSchemas(jsonContent []byte, allowTableTypes bool) ([]TableSch
 				if fieldSchema.Type.Type == TableType {
 					return []TableSchema{}, fmt.Errorf("table '%s' cannot have table keys", tableName)
 				}
+				if fieldSchema.Type.Elem != nil {
+					return []TableSchema{}, fmt.Errorf("table '%s' cannot have array keys", tableName)
+				}
+				if fieldSchema.Type.Struct != nil {
+					return []TableSchema{}, fmt.Errorf("table '%s' cannot have struct keys", tableName)
+				}
+				if fieldSchema.Type.Counter {
+					return []TableSchema{}, fmt.Errorf("table '%s' cannot have counter keys", tableName)
+				}
 				tableSchema.Keys = append(tableSchema.Keys, fieldSchema)
 			}
 		}
 
+		_compositeKey, ok := jsonTableSchema.Get("compositeKey")
+		if ok {
+			*at = []string{tableName, "compositeKey"}
+			compositeKey, ok := _compositeKey.(bool)
+			if !ok {
+				return []TableSchema{}, fmt.Errorf("invalid composite key schema for table '%s'", tableName)
+			}
+			if compositeKey {
+				for _, key := range tableSchema.Keys {
+					if key.Type.Type == BytesType {
+						return []TableSchema{}, fmt.Errorf("invalid composite key schema for table '%s': key '%s' has dynamic type", tableName, key.Name)
+					}
+				}
+			}
+			tableSchema.CompositeKey = compositeKey
+		}
+
+		_keyPacking, ok := jsonTableSchema.Get("keyPacking")
+		if ok {
+			*at = []string{tableName, "keyPacking"}
+			keyPacking, ok := _keyPacking.(string)
+			if !ok || (keyPacking != KeyPackingPacked && keyPacking != KeyPackingPadded) {
+				return []TableSchema{}, fmt.Errorf("invalid key packing schema for table '%s': expected %s or %s", tableName, KeyPackingPacked, KeyPackingPadded)
+			}
+			if len(tableSchema.Keys) == 0 {
+				return []TableSchema{}, fmt.Errorf("invalid key packing schema for table '%s': table has no keys", tableName)
+			}
+			tableSchema.PaddedKeys = keyPacking == KeyPackingPadded
+		}
+
+		*at = []string{tableName}
 		_jsonValueSchema, ok := jsonTableSchema.Get("schema")
 		if !ok {
 			return []TableSchema{}, fmt.Errorf("no value schema for table '%s'", tableName)
 		}
+		*at = []string{tableName, "schema"}
 		jsonValueSchema, ok := _jsonValueSchema.(orderedmap.OrderedMap)
 		if !ok {
 			return []TableSchema{}, fmt.Errorf("invalid value schema for table '%s'", tableName)
 		}
 		for _, valueName := range jsonValueSchema.Keys() {
+			*at = []string{tableName, "schema", valueName}
 			_valueType, _ := jsonValueSchema.Get(valueName)
 			valueType, ok := _valueType.(string)
 			if !ok {
 				return []TableSchema{}, fmt.Errorf("invalid schema for value '%s' in table '%s'", valueName, tableName)
 			}
+			optional := strings.HasPrefix(valueType, "optional ")
+			if optional {
+				valueType = strings.TrimSpace(strings.TrimPrefix(valueType, "optional "))
+			}
+			valueType, order, err := splitOrderAnnotation(valueType)
+			if err != nil {
+				return []TableSchema{}, fmt.Errorf("invalid order schema for table '%s': %w", tableName, err)
+			}
+			valueType, slot, err := splitSlotAnnotation(valueType)
+			if err != nil {
+				return []TableSchema{}, fmt.Errorf("invalid slot schema for table '%s': %w", tableName, err)
+			}
+			valueType, defaultLiteral, hasDefault := splitDefaultAnnotation(valueType)
+			valueType, hashed := splitHashedAnnotation(valueType)
+			valueType, maxLen, err := splitMaxLenAnnotation(valueType)
+			if err != nil {
+				return []TableSchema{}, fmt.Errorf("invalid maxlen schema for table '%s': %w", tableName, err)
+			}
 			fieldSchema, err := newFieldSchema(valueName, len(tableSchema.Values), valueType)
 			if err != nil {
 				return []TableSchema{}, err
 			}
+			if maxLen > 0 {
+				if fieldSchema.Type.Type != BytesType {
+					return []TableSchema{}, fmt.Errorf("invalid maxlen schema for table '%s': value '%s' is not bytes or a string", tableName, valueName)
+				}
+				fieldSchema.MaxLen = maxLen
+			}
+			if hashed {
+				if fieldSchema.Type.Type != BytesType {
+					return []TableSchema{}, fmt.Errorf("invalid hashed schema for table '%s': value '%s' is not bytes or a string", tableName, valueName)
+				}
+				fieldSchema.Hashed = true
+			}
+			if hasDefault {
+				if optional {
+					return []TableSchema{}, fmt.Errorf("invalid default schema for table '%s': optional value '%s' cannot have a default", tableName, valueName)
+				}
+				fieldSchema.Default, err = defaultExpr(fieldSchema.Type, defaultLiteral)
+				if err != nil {
+					return []TableSchema{}, fmt.Errorf("invalid default schema for table '%s': value '%s': %w", tableName, valueName, err)
+				}
+			}
+			if slot >= 0 {
+				fieldSchema.Pinned = true
+				fieldSchema.Slot = slot
+			}
+			if order >= 0 {
+				fieldSchema.Ordered = true
+				fieldSchema.Order = order
+			}
+			if optional {
+				fieldType := fieldSchema.Type
+				if fieldType.Type != ValueType || fieldType.Elem != nil || fieldType.Struct != nil {
+					return []TableSchema{}, fmt.Errorf("invalid optional schema for table '%s': value '%s' is not a scalar", tableName, valueName)
+				}
+				if fieldType.Counter {
+					return []TableSchema{}, fmt.Errorf("invalid optional schema for table '%s': counter '%s' cannot be optional", tableName, valueName)
+				}
+				fieldSchema.Optional = true
+				fieldSchema.PresenceBit = tableSchema.optionalCount()
+			}
 			if fieldSchema.Type.Type == TableType {
 				if !allowTableTypes {
 					return []TableSchema{}, fmt.Errorf("invalid type '%s' for field '%s': table values cannot be tables", fieldSchema.Type.Name, fieldSchema.Name)
 				}
-				_, ok := jsonSchemas.Get(fieldSchema.Type.Name)
-				if !ok {
+				ref, ok := jsonSchemas.Get(fieldSchema.Type.Name)
+				if !ok || isViewSchema(ref) {
 					return []TableSchema{}, fmt.Errorf("table '%s' does not exist", fieldSchema.Type.Name)
 				}
 			}
 			tableSchema.Values = append(tableSchema.Values, fieldSchema)
 		}
+		*at = []string{tableName, "schema"}
+		if err := checkPinnedSlots(tableName, tableSchema.Values); err != nil {
+			return []TableSchema{}, err
+		}
+		if err := checkTableValueNames(tableName, tableSchema.Values); err != nil {
+			return []TableSchema{}, err
+		}
+		if err := checkOrderedValues(tableName, tableSchema.Values); err != nil {
+			return []TableSchema{}, err
+		}
+		_iterable, ok := jsonTableSchema.Get("iterable")
+		if ok {
+			*at = []string{tableName, "iterable"}
+			iterable, ok := _iterable.(bool)
+			if !ok {
+				return []TableSchema{}, fmt.Errorf("invalid iterable schema for table '%s'", tableName)
+			}
+			if iterable {
+				if len(tableSchema.Keys) == 0 {
+					return []TableSchema{}, fmt.Errorf("invalid iterable schema for table '%s': iterable tables must have keys", tableName)
+				}
+				for _, key := range tableSchema.Keys {
+					if key.Type.Type == BytesType {
+						return []TableSchema{}, fmt.Errorf("invalid iterable schema for table '%s': key '%s' has dynamic type", tableName, key.Name)
+					}
+				}
+				for _, value := range tableSchema.Values {
+					if value.Type.Type == TableType {
+						return []TableSchema{}, fmt.Errorf("invalid iterable schema for table '%s': value '%s' is a table", tableName, value.Name)
+					}
+				}
+			}
+			tableSchema.Iterable = iterable
+		}
+		_order, ok := jsonTableSchema.Get("order")
+		if ok {
+			*at = []string{tableName, "order"}
+			order, ok := _order.(string)
+			if !ok || (order != OrderInsertion && order != OrderKeyHash) {
+				return []TableSchema{}, fmt.Errorf("invalid order schema for table '%s': expected %s or %s", tableName, OrderInsertion, OrderKeyHash)
+			}
+			if !tableSchema.Iterable {
+				return []TableSchema{}, fmt.Errorf("invalid order schema for table '%s': table is not iterable", tableName)
+			}
+			tableSchema.KeyHashOrder = order == OrderKeyHash
+		}
+		_baseSlot, ok := jsonTableSchema.Get("baseSlot")
+		if ok {
+			*at = []string{tableName, "baseSlot"}
+			baseSlot, err := parseBaseSlot(tableName, _baseSlot)
+			if err != nil {
+				return []TableSchema{}, err
+			}
+			tableSchema.BaseSlot = baseSlot
+		}
+		_initialized, ok := jsonTableSchema.Get("initialized")
+		if ok {
+			*at = []string{tableName, "initialized"}
+			switch initialized := _initialized.(type) {
+			case bool:
+				tableSchema.Initialized = initialized
+			case string:
+				if initialized != InitializedStrict {
+					return []TableSchema{}, fmt.Errorf("invalid initialized schema for table '%s': expected a boolean or %s", tableName, InitializedStrict)
+				}
+				tableSchema.Initialized, tableSchema.StrictInit = true, true
+			default:
+				return []TableSchema{}, fmt.Errorf("invalid initialized schema for table '%s': expected a boolean or %s", tableName, InitializedStrict)
+			}
+			if tableSchema.Initialized && len(tableSchema.Keys) == 0 {
+				return []TableSchema{}, fmt.Errorf("invalid initialized schema for table '%s': initialized tables must have keys", tableName)
+			}
+		}
+		_emit, ok := jsonTableSchema.Get("emit")
+		if ok {
+			*at = []string{tableName, "emit"}
+			emit, err := parseEmitSchema(tableName, _emit)
+			if err != nil {
+				return []TableSchema{}, err
+			}
+			if emit != "" {
+				for _, value := range tableSchema.Values {
+					if value.Type.Type == DynamicArrayType {
+						return []TableSchema{}, fmt.Errorf("invalid emit schema for table '%s': value '%s' is a dynamic array", tableName, value.Name)
+					}
+				}
+			}
+			tableSchema.Emit = emit
+		}
+
 		tableSchemas = append(tableSchemas, tableSchema)
 	}
 	return tableSchemas, nil
 }
 
+// parseEmitSchema returns the event signature of an emit annotation, which is
+// either a boolean or a signature with the arguments of DefaultEmitSignature.
+func parseEmitSchema(tableName string, emit interface{}) (string, error) {
+	switch emit := emit.(type) {
+	case bool:
+		if emit {
+			return DefaultEmitSignature, nil
+		}
+		return "", nil
+	case string:
+		signature := strings.ReplaceAll(emit, " ", "")
+		name, args, ok := strings.Cut(signature, "(")
+		if !ok || !isValidName(name) || args != "bytes32,uint256,bytes)" {
+			return "", fmt.Errorf("invalid emit schema for table '%s': event must have the signature <name>(bytes32,uint256,bytes)", tableName)
+		}
+		return signature, nil
+	default:
+		return "", fmt.Errorf("invalid emit schema for table '%s'", tableName)
+	}
+}
+
+// parseBaseSlot returns the offset of a baseSlot annotation, either a JSON
+// integer or a decimal or 0x prefixed hex string, as JSON numbers above 2^53
+// lose precision.
+func parseBaseSlot(tableName string, baseSlot interface{}) (uint64, error) {
+	switch baseSlot := baseSlot.(type) {
+	case float64:
+		if baseSlot < 0 || baseSlot != math.Trunc(baseSlot) || baseSlot > 1<<53 {
+			return 0, fmt.Errorf("invalid base slot schema for table '%s': expected an integer between 0 and 2^53, or a string for larger ones", tableName)
+		}
+		return uint64(baseSlot), nil
+	case string:
+		offset, err := strconv.ParseUint(baseSlot, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid base slot schema for table '%s': %q is not a 64-bit unsigned integer", tableName, baseSlot)
+		}
+		return offset, nil
+	default:
+		return 0, fmt.Errorf("invalid base slot schema for table '%s': expected an integer", tableName)
+	}
+}
+
+// collectEnums returns the enums declared in the table schemas in declaration
+// order. An enum can be used in several fields as long as all declarations
+// are identical.
+func collectEnums(schemas []TableSchema) ([]*EnumSchema, error) {
+	tableNames := make(map[string]bool)
+	for _, schema := range schemas {
+		tableNames[formatTableName(schema.Name)] = true
+	}
+	var enums []*EnumSchema
+	enumsByName := make(map[string]*EnumSchema)
+	for _, schema := range schemas {
+		for _, fieldType := range schemaFieldTypes(schema) {
+			enum := fieldType.Enum
+			if enum == nil {
+				continue
+			}
+			if tableNames[enum.Name] {
+				return nil, fmt.Errorf("enum '%s' has the same name as a table", enum.Name)
+			}
+			if other, ok := enumsByName[enum.Name]; ok {
+				if !other.Equal(enum) {
+					return nil, fmt.Errorf("enum '%s' is declared with different values", enum.Name)
+				}
+				continue
+			}
+			enumsByName[enum.Name] = enum
+			enums = append(enums, enum)
+		}
+	}
+	return enums, nil
+}
+
+// schemaFieldTypes returns the types of all keys and values of a table,
+// including the types of struct members.
+func schemaFieldTypes(schema TableSchema) []FieldType {
+	var types []FieldType
+	var add func(fieldType FieldType)
+	add = func(fieldType FieldType) {
+		if fieldType.Struct != nil {
+			for _, member := range fieldType.Struct.Members {
+				add(member.Type)
+			}
+		}
+		types = append(types, fieldType)
+	}
+	for _, field := range schema.Keys {
+		add(field.Type)
+	}
+	for _, field := range schema.Values {
+		add(field.Type)
+	}
+	return types
+}
+
+// collectStructs returns the structs declared in the table schemas, with nested
+// structs before the structs containing them. As with enums, a struct can be
+// declared several times as long as all declarations are identical.
+func collectStructs(schemas []TableSchema, enums []*EnumSchema) ([]*StructSchema, error) {
+	names := make(map[string]bool)
+	for _, schema := range schemas {
+		names[formatTableName(schema.Name)] = true
+	}
+	for _, enum := range enums {
+		names[enum.Name] = true
+	}
+	var structs []*StructSchema
+	structsByName := make(map[string]*StructSchema)
+	for _, schema := range schemas {
+		for _, fieldType := range schemaFieldTypes(schema) {
+			structSchema := fieldType.Struct
+			if structSchema == nil {
+				continue
+			}
+			if names[structSchema.Name] {
+				return nil, fmt.Errorf("struct '%s' has the same name as a table or enum", structSchema.Name)
+			}
+			if other, ok := structsByName[structSchema.Name]; ok {
+				if !other.Equal(structSchema) {
+					return nil, fmt.Errorf("struct '%s' is declared with different members", structSchema.Name)
+				}
+				continue
+			}
+			structsByName[structSchema.Name] = structSchema
+			structs = append(structs, structSchema)
+		}
+	}
+	return structs, nil
+}
+
+// collectFixed returns the fixed-point decimal types used in the table schemas
+// in declaration order.
+func collectFixed(schemas []TableSchema, enums []*EnumSchema, structs []*StructSchema) ([]*FixedSchema, error) {
+	names := make(map[string]bool)
+	for _, schema := range schemas {
+		names[formatTableName(schema.Name)] = true
+	}
+	for _, enum := range enums {
+		names[enum.Name] = true
+	}
+	for _, structSchema := range structs {
+		names[structSchema.Name] = true
+	}
+	var fixed []*FixedSchema
+	seen := make(map[string]bool)
+	for _, schema := range schemas {
+		for _, fieldType := range schemaFieldTypes(schema) {
+			if fieldType.Elem != nil {
+				fieldType = *fieldType.Elem
+			}
+			fixedSchema := fieldType.Fixed
+			if fixedSchema == nil || seen[fixedSchema.Name] {
+				continue
+			}
+			if names[fieldType.GoType] {
+				return nil, fmt.Errorf("fixed-point type '%s' has the same name as a table, enum or struct", fieldType.GoType)
+			}
+			seen[fixedSchema.Name] = true
+			fixed = append(fixed, fixedSchema)
+		}
+	}
+	return fixed, nil
+}
+
+// collectFlags returns the flags declared in the table schemas in declaration
+// order. As with enums, flags can be declared several times as long as all
+// declarations are identical.
+func collectFlags(schemas []TableSchema, enums []*EnumSchema, structs []*StructSchema, fixed []*FixedSchema) ([]*FlagsSchema, error) {
+	names := make(map[string]bool)
+	for _, schema := range schemas {
+		names[formatTableName(schema.Name)] = true
+	}
+	for _, enum := range enums {
+		names[enum.Name] = true
+	}
+	for _, structSchema := range structs {
+		names[structSchema.Name] = true
+	}
+	for _, fixedSchema := range fixed {
+		names[upperFirstLetter(fixedSchema.Name)] = true
+	}
+	var flags []*FlagsSchema
+	flagsByName := make(map[string]*FlagsSchema)
+	for _, schema := range schemas {
+		for _, fieldType := range schemaFieldTypes(schema) {
+			if fieldType.Elem != nil {
+				fieldType = *fieldType.Elem
+			}
+			flagsSchema := fieldType.Flags
+			if flagsSchema == nil {
+				continue
+			}
+			if names[flagsSchema.Name] || names[flagsSchema.FlagName()] {
+				return nil, fmt.Errorf("flags '%s' has the same name as a table, enum, struct or fixed-point type", flagsSchema.Name)
+			}
+			if other, ok := flagsByName[flagsSchema.Name]; ok {
+				if !other.Equal(flagsSchema) {
+					return nil, fmt.Errorf("flags '%s' is declared with different values", flagsSchema.Name)
+				}
+				continue
+			}
+			flagsByName[flagsSchema.Name] = flagsSchema
+			flags = append(flags, flagsSchema)
+		}
+	}
+	return flags, nil
+}
+
+// withStdImports adds the math/big and time packages to imports if any of the
+// given field types requires them.
+func withStdImports(imports []goImport, types []FieldType) []goImport {
+	imports = withStdImport(imports, "math/big", types, FieldType.usesBig)
+	return withStdImport(imports, "time", types, FieldType.usesTime)
+}
+
+func withStdImport(imports []goImport, path string, types []FieldType, uses func(FieldType) bool) []goImport {
+	for _, imp := range imports {
+		if imp.Path == path {
+			return imports
+		}
+	}
+	for _, fieldType := range types {
+		if uses(fieldType) {
+			return append(imports, goImport{Path: path})
+		}
+	}
+	return imports
+}
+
 type Config struct {
 	SchemaFilePath string
-	OutDir         string
-	Package        string
+	// Format is the format of the schema file, FormatDSL if empty.
+	Format  string
+	OutDir  string
+	Package string
+	// StrictEnums makes decoding an out of range enum value panic instead of
+	// clamping it to the last valid value.
+	StrictEnums bool
+	// Solidity enables generating a solidity interface for the tables in
+	// the output directory, using SolidityPragma as version pragma.
+	Solidity       bool
+	SolidityPragma string
+	// ABI enables generating a JSON ABI file per table describing its
+	// accessors as contract methods.
+	ABI bool
+	// TypeScript enables generating TypeScript declarations of the keys and
+	// row values of the tables, with the types of their ABI representation.
+	TypeScript bool
+	// DisablePacking stores every value at the start of its own slot instead
+	// of packing consecutive values smaller than a slot together.
+	DisablePacking bool
+	// Fuzz enables generating a fuzz test per table, guarded by the
+	// FuzzBuildTag build tag, checking that row values round-trip.
+	Fuzz bool
+	// Bench enables generating a benchmark per table, guarded by the
+	// BenchBuildTag build tag, reporting the gas used and the slots accessed
+	// by each accessor.
+	Bench bool
+	// Memory enables generating a map backed implementation of every table
+	// without table values, implementing the <Table>RowStore interface over
+	// the whole row accessors of the table.
+	Memory bool
+	// Context makes the accessors of the tables and views taking their keys
+	// take a context.Context as first argument, e.g. Get(ctx, keys...). Rows
+	// got with a context panic with its error once it is done, see
+	// lib.WithContext.
+	Context bool
+	// MaskDirtyBytes makes values stored alone in a word, i.e. the elements of
+	// dynamic arrays and the keys of iterable tables, ignore non-zero bytes
+	// above their width instead of panicking with codec.ErrDirtyBytes.
+	MaskDirtyBytes bool
+	// MultiReturn makes the GetRow accessors of the tables return the row
+	// values as named results, e.g. GetRow(key) (balance *uint256.Int, nonce
+	// uint64), instead of a values struct.
+	MultiReturn bool
+	// ImportCommon, ImportUint256 and ImportRuntime override the import paths
+	// of the go-ethereum common package, of the uint256 package and of the
+	// concrete packages the generated code depends on, e.g. for forks of
+	// go-ethereum under another module path. The concrete packages are
+	// imported from their usual locations under ImportRuntime, such as
+	// <runtime>/lib and <runtime>/codegen/datamod/codec. The default import
+	// paths are used if empty.
+	ImportCommon  string
+	ImportUint256 string
+	ImportRuntime string
+	// BaseSlot is added to the base slot offset of every table, see
+	// TableSchema.BaseSlot, so that packages generated separately can store
+	// their tables in distinct ranges of slots.
+	BaseSlot uint64
 }
 
+// Default import paths of the packages the generated code depends on.
+const (
+	DefaultImportCommon  = "github.com/ethereum/go-ethereum/common"
+	DefaultImportUint256 = "github.com/holiman/uint256"
+	DefaultImportRuntime = "github.com/ethereum/go-ethereum/concrete"
+)
+
+// importPaths are the import paths of the packages the generated code depends
+// on.
+type importPaths struct {
+	Common  string
+	Hexutil string
+	Uint256 string
+	API     string
+	Codec   string
+	Crypto  string
+	Lib     string
+	Mock    string
+	Storage string
+}
+
+func (c Config) importPaths() (importPaths, error) {
+	common, uint256, runtime := c.ImportCommon, c.ImportUint256, c.ImportRuntime
+	if common == "" {
+		common = DefaultImportCommon
+	}
+	if uint256 == "" {
+		uint256 = DefaultImportUint256
+	}
+	if runtime == "" {
+		runtime = DefaultImportRuntime
+	}
+	for _, path := range []string{common, uint256, runtime} {
+		if !isValidImportPath(path) {
+			return importPaths{}, fmt.Errorf("invalid import path: %s", path)
+		}
+	}
+	return importPaths{
+		Common:  common,
+		Hexutil: common + "/hexutil",
+		Uint256: uint256,
+		API:     runtime + "/api",
+		Codec:   runtime + "/codegen/datamod/codec",
+		Crypto:  runtime + "/crypto",
+		Lib:     runtime + "/lib",
+		Mock:    runtime + "/mock",
+		Storage: runtime + "/storage",
+	}, nil
+}
+
+// generated returns the import paths of the packages imported by all generated
+// files by package name.
+func (p importPaths) generated() map[string]string {
+	return map[string]string{
+		"common":  p.Common,
+		"codec":   p.Codec,
+		"crypto":  p.Crypto,
+		"lib":     p.Lib,
+		"uint256": p.Uint256,
+	}
+}
+
+// FuzzBuildTag is the build tag required to build the generated fuzz tests,
+// e.g. `go test -tags datamod_fuzz -fuzz Fuzz_MyTable`.
+const FuzzBuildTag = "datamod_fuzz"
+
+// BenchBuildTag is the build tag required to build the generated benchmarks,
+// e.g. `go test -tags datamod_bench -bench .`.
+const BenchBuildTag = "datamod_bench"
+
+// CBORBuildTag is the build tag required to build the generated tables with
+// cbor values, which depend on the cbor codec.
+const CBORBuildTag = "datamod_cbor"
+
 func GenerateDataModel(config Config, allowTableTypes bool) error {
 	if !isValidName(config.Package) {
 		return fmt.Errorf("invalid package name: %s", config.Package)
 	}
 
-	jsonContent, err := os.ReadFile(config.SchemaFilePath)
+	paths, err := config.importPaths()
 	if err != nil {
 		return err
 	}
-	schemas, err := UnmarshalTableSchemas(jsonContent, allowTableTypes)
+
+	schemas, views, err := loadSchemaFile(config.SchemaFilePath, config.Format, allowTableTypes)
+	if err != nil {
+		return err
+	}
+
+	enums, err := collectEnums(schemas)
+	if err != nil {
+		return err
+	}
+	structs, err := collectStructs(schemas, enums)
 	if err != nil {
 		return err
 	}
 
+	fixed, err := collectFixed(schemas, enums, structs)
+	if err != nil {
+		return err
+	}
+
+	flags, err := collectFlags(schemas, enums, structs, fixed)
+	if err != nil {
+		return err
+	}
+
+	for ii := range schemas {
+		var carry uint64
+		schemas[ii].BaseSlot, carry = bits.Add64(schemas[ii].BaseSlot, config.BaseSlot, 0)
+		if carry != 0 {
+			return fmt.Errorf("invalid base slot schema for table '%s': the offset overflows 64 bits", lowerFirstLetter(schemas[ii].Name))
+		}
+	}
+	if err := checkTableSlots(schemas, !config.DisablePacking); err != nil {
+		return err
+	}
+
 	funcMap := template.FuncMap{
-		"sub": func(a, b int) int { return a - b },
+		"add":   func(a, b int) int { return a + b },
+		"sub":   func(a, b int) int { return a - b },
+		"upper": upperFirstLetter,
+		// indent indents the lines of a multi-line statement after the first
+		"indent": func(depth int, stmt string) string {
+			return strings.ReplaceAll(stmt, "\n", "\n"+strings.Repeat("\t", depth))
+		},
+	}
+
+	var allFields []FieldSchema
+	for _, schema := range schemas {
+		for _, field := range append(append([]FieldSchema{}, schema.Keys...), schema.Values...) {
+			// Cbor values are marshalled directly and need no conversions
+			if !field.Type.IsCBOR() {
+				allFields = append(allFields, field)
+			}
+		}
+	}
+	overrides, overrideImports, err := collectGoTypeOverrides(allFields, paths.generated())
+	if err != nil {
+		return err
+	}
+	if len(overrides) > 0 {
+		data := map[string]interface{}{
+			"Package":   config.Package,
+			"Paths":     paths,
+			"Overrides": overrides,
+			"Imports":   overrideImports,
+		}
+		tpl, err := template.New("gotype").Funcs(funcMap).Parse(goTypeTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, "gotypes.go")); err != nil {
+			return err
+		}
+	}
+
+	if len(enums) > 0 {
+		data := map[string]interface{}{
+			"Package":     config.Package,
+			"Paths":       paths,
+			"Enums":       enums,
+			"StrictEnums": config.StrictEnums,
+		}
+		tpl, err := template.New("enum").Funcs(funcMap).Parse(enumTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, "enums.go")); err != nil {
+			return err
+		}
+	}
+
+	if len(structs) > 0 {
+		var structTypes []FieldType
+		for _, structSchema := range structs {
+			for _, member := range structSchema.Members {
+				structTypes = append(structTypes, member.Type)
+			}
+		}
+		data := map[string]interface{}{
+			"Package": config.Package,
+			"Paths":   paths,
+			"Imports": withStdImports(nil, structTypes),
+			"Structs": structs,
+		}
+		tpl, err := template.New("struct").Funcs(funcMap).Parse(structTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, "structs.go")); err != nil {
+			return err
+		}
+	}
+
+	if len(fixed) > 0 {
+		data := map[string]interface{}{
+			"Package": config.Package,
+			"Paths":   paths,
+			"Fixed":   fixed,
+		}
+		tpl, err := template.New("fixed").Funcs(funcMap).Parse(fixedTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, "fixed.go")); err != nil {
+			return err
+		}
+	}
+
+	if len(flags) > 0 {
+		data := map[string]interface{}{
+			"Package": config.Package,
+			"Paths":   paths,
+			"Flags":   flags,
+		}
+		tpl, err := template.New("flags").Funcs(funcMap).Parse(flagsTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, "flags.go")); err != nil {
+			return err
+		}
 	}
 
 	for _, schema := range schemas {
 		tableName := formatTableName(schema.Name)
 		rowName := formatRowName(schema.Name)
 
-		_sizes := make([]string, len(schema.Values))
-		for i, field := range schema.Values {
-			_sizes[i] = fmt.Sprint(field.Type.Size)
+		if config.Context {
+			for _, key := range schema.Keys {
+				if key.Name == "ctx" {
+					return fmt.Errorf("invalid schema for table '%s': key 'ctx' conflicts with the context argument", lowerFirstLetter(schema.Name))
+				}
+			}
+		}
+		if config.MultiReturn {
+			params := make(map[string]bool)
+			if config.Context {
+				params["ctx"] = true
+			}
+			for _, key := range schema.Keys {
+				params[key.Name] = true
+			}
+			for _, result := range schema.RowResults() {
+				if params[result.Name] {
+					return fmt.Errorf("invalid schema for table '%s': result '%s' of the row getter conflicts with an argument", lowerFirstLetter(schema.Name), result.Name)
+				}
+			}
+		}
+
+		sizes, pins := rowSizes(schema)
+		sizesStr := intSliceLiteral(sizes)
+		// Offsets are only generated for pinned or unpacked layouts so that
+		// the layout of other tables is computed by the datastore as before
+		offsetsStr := ""
+		if schema.HasPinnedSlots() || config.DisablePacking {
+			offsetsStr = intSliceLiteral(rowLayout(sizes, pins, !config.DisablePacking))
 		}
-		sizesStr := fmt.Sprintf("[]int{%s}", strings.Join(_sizes, ", "))
 
 		_keys := make([]string, len(schema.Keys))
 		for i, field := range schema.Keys {
 			_keys[i] = fmt.Sprint(field.Type.Size)
 		}
 
+		_, imports, err := collectGoTypeOverrides(append(append([]FieldSchema{}, schema.Keys...), schema.Values...), paths.generated())
+		if err != nil {
+			return err
+		}
+
 		data := map[string]interface{}{
 			"Package":         config.Package,
+			"Paths":           paths,
+			"Imports":         withStdImports(imports, schemaFieldTypes(schema)),
 			"Schema":          schema,
 			"TableStructName": tableName,
 			"RowStructName":   rowName,
 			"SizesStr":        sizesStr,
+			"OffsetsStr":      offsetsStr,
+			"Context":         config.Context,
+			"MaskDirtyBytes":  config.MaskDirtyBytes,
+			"MultiReturn":     config.MultiReturn,
+		}
+
+		if schema.HasCBOR() {
+			data["BuildTag"] = CBORBuildTag
 		}
 
 		filename := lowerFirstLetter(tableName) + ".go"
@@ -197,6 +1401,118 @@ func GenerateDataModel(config Config, allowTableTypes bool) error {
 		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, filename)); err != nil {
 			return err
 		}
+
+		if config.Memory && len(schema.RowValues()) == len(schema.Values) {
+			// The memory table only refers to the go types of the keys and of
+			// the values it checks the length of, or of all the values if they
+			// are returned by GetRow
+			referenced := schema.CappedValues()
+			if config.MultiReturn {
+				referenced = schema.RowValues()
+			}
+			var memoryFields []FieldSchema
+			var memoryTypes []FieldType
+			for _, field := range append(append([]FieldSchema{}, schema.Keys...), referenced...) {
+				memoryFields = append(memoryFields, field)
+				memoryTypes = append(memoryTypes, field.Type)
+			}
+			_, memoryImports, err := collectGoTypeOverrides(memoryFields, paths.generated())
+			if err != nil {
+				return err
+			}
+			memoryData := map[string]interface{}{
+				"Package":         config.Package,
+				"Paths":           paths,
+				"Imports":         withStdImports(memoryImports, memoryTypes),
+				"Schema":          schema,
+				"TableStructName": tableName,
+				"BuildTag":        data["BuildTag"],
+				"Context":         config.Context,
+				"MultiReturn":     config.MultiReturn,
+			}
+			tpl, err := template.New("memory").Funcs(funcMap).Parse(memoryTpl)
+			if err != nil {
+				return err
+			}
+			memoryFilename := lowerFirstLetter(tableName) + "_memory.go"
+			if err = ExecuteTemplate(tpl, memoryData, filepath.Join(config.OutDir, memoryFilename)); err != nil {
+				return err
+			}
+		}
+
+		if config.Fuzz && len(schema.FuzzValues()) > 0 {
+			data["BuildTag"] = FuzzBuildTag
+			if schema.HasCBOR() {
+				data["BuildTag"] = FuzzBuildTag + " && " + CBORBuildTag
+			}
+			tpl, err := template.New("fuzz").Funcs(funcMap).Parse(fuzzTpl)
+			if err != nil {
+				return err
+			}
+			fuzzFilename := lowerFirstLetter(tableName) + "_fuzz_test.go"
+			if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, fuzzFilename)); err != nil {
+				return err
+			}
+		}
+
+		if config.Bench && len(schema.FuzzValues()) > 0 {
+			data["BuildTag"] = BenchBuildTag
+			if schema.HasCBOR() {
+				data["BuildTag"] = BenchBuildTag + " && " + CBORBuildTag
+			}
+			tpl, err := template.New("bench").Funcs(funcMap).Parse(benchTpl)
+			if err != nil {
+				return err
+			}
+			benchFilename := lowerFirstLetter(tableName) + "_bench_test.go"
+			if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, benchFilename)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, view := range views {
+		_, imports, err := collectGoTypeOverrides(view.fieldSchemas(), paths.generated())
+		if err != nil {
+			return err
+		}
+		var types []FieldType
+		for _, field := range view.fieldSchemas() {
+			types = append(types, field.Type)
+		}
+		data := map[string]interface{}{
+			"Package": config.Package,
+			"Paths":   paths,
+			"Imports": withStdImports(imports, types),
+			"View":    view,
+			"Context": config.Context,
+		}
+		if view.HasCBOR() {
+			data["BuildTag"] = CBORBuildTag
+		}
+		tpl, err := template.New("view").Funcs(funcMap).Parse(viewTpl)
+		if err != nil {
+			return err
+		}
+		if err = ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, lowerFirstLetter(view.Name)+".go")); err != nil {
+			return err
+		}
+	}
+
+	if config.Solidity {
+		if err := GenerateSolidityInterfaces(config, schemas); err != nil {
+			return err
+		}
+	}
+	if config.ABI {
+		if err := GenerateABIs(config, schemas); err != nil {
+			return err
+		}
+	}
+	if config.TypeScript {
+		if err := GenerateTypeScriptTypes(config, schemas); err != nil {
+			return err
+		}
 	}
 	return nil
 }
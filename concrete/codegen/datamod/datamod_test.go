@@ -16,17 +16,31 @@
 package datamod
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata/gotypes"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
 	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/concrete/storage"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
@@ -39,10 +53,392 @@ func TestDatamod(t *testing.T) {
 		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
 		OutDir:         filepath.Join(tmpDir),
 		Package:        "test",
+		Solidity:       true,
+		ABI:            true,
 	}
 	if err := GenerateDataModel(config, true); err != nil {
 		t.Fatal(err)
 	}
+	if _, err := os.Stat(filepath.Join(tmpDir, SolidityInterfaceFileName)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatamodDisablePacking(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-unpacked"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		DisablePacking: true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "keyedTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "offsets := []int{0, 32, 64, 96, 128, 160}")
+	// Pinned slots are still honored
+	content, err = os.ReadFile(filepath.Join(tmpDir, "pinnedTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "offsets := []int{64, 32, 96, 0, 128, 160}")
+}
+
+func TestDatamodMaskDirtyBytes(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-masked"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		MaskDirtyBytes: true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dynamicArrayTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "codec.DecodeUint[uint64](8, data[32-8:])")
+	r.NotContains(string(content), "MustWordBytes")
+	content, err = os.ReadFile(filepath.Join(tmpDir, "iterableTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "codec.DecodeAddress(20, accountData[32-20:])")
+}
+
+func TestDatamodFuzz(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-fuzz"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		Fuzz:           true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "optionalTable_fuzz_test.go"))
+	r.NoError(err)
+	r.Contains(string(content), "//go:build "+FuzzBuildTag)
+	r.Contains(string(content), "func Fuzz_OptionalTable(f *testing.F)")
+	r.Contains(string(content), "codec.DecodeFixedBytes(16, codec.FitBytes(16, rawNickname))")
+	// Harnesses of tables with cbor values need both build tags
+	content, err = os.ReadFile(filepath.Join(tmpDir, "profileTable_fuzz_test.go"))
+	r.NoError(err)
+	r.Contains(string(content), "//go:build "+FuzzBuildTag+" && "+CBORBuildTag)
+	// Tables without fuzzable values get no harness
+	_, err = os.Stat(filepath.Join(tmpDir, "keyedWithKeyedTableValue_fuzz_test.go"))
+	r.True(os.IsNotExist(err))
+}
+
+func TestDatamodBench(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-bench"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		Bench:          true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "optionalTable_bench_test.go"))
+	r.NoError(err)
+	r.Contains(string(content), "//go:build "+BenchBuildTag)
+	r.Contains(string(content), "func Benchmark_OptionalTable(b *testing.B)")
+	r.Contains(string(content), `b.Run("SetNickname", func(b *testing.B) {`)
+	// Rows with packed values compare reading them together and one by one
+	r.Contains(string(content), `b.Run("GetValues", func(b *testing.B) {`)
+	r.Contains(string(content), `b.Run("GetEach", func(b *testing.B) {`)
+	content, err = os.ReadFile(filepath.Join(tmpDir, "poolTable_bench_test.go"))
+	r.NoError(err)
+	r.NotContains(string(content), "GetValues")
+	_, err = os.Stat(filepath.Join(tmpDir, "keyedWithKeyedTableValue_bench_test.go"))
+	r.True(os.IsNotExist(err))
+}
+
+func TestDatamodMemory(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-memory"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		Memory:         true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "profileTable_memory.go"))
+	r.NoError(err)
+	r.Contains(string(content), "//go:build "+CBORBuildTag)
+	r.Contains(string(content), "var _ ProfileTableRowStore = (*MemoryProfileTable)(nil)")
+	// Tables with table values have no memory implementation
+	_, err = os.Stat(filepath.Join(tmpDir, "keyedWithKeyedTableValue_memory.go"))
+	r.True(os.IsNotExist(err))
+}
+
+func TestDatamodContext(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-context"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		Memory:         true,
+		Context:        true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "keyedTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (m *KeyedTable) Get(\n\tctx context.Context,\n")
+	r.Contains(string(content), "bound.dsSlot = lib.WithContext(ctx, m.dsSlot)")
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keylessTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (m *KeylessTable) Get(ctx context.Context) *KeylessTableRow {")
+	// Memory tables and views take the context as well
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keyedTable_memory.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (m *MemoryKeyedTable) Has(\n\t_ context.Context,\n")
+	content, err = os.ReadFile(filepath.Join(tmpDir, "accountView.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (v *AccountView) Get(\n\tctx context.Context,\n")
+
+	// Tables without the option are generated as before
+	config.Context = false
+	r.NoError(GenerateDataModel(config, true))
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keylessTable.go"))
+	r.NoError(err)
+	r.NotContains(string(content), "context")
+}
+
+func TestDatamodContextKeyName(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	r.NoError(os.WriteFile(schemaPath, []byte(`{"table": {"keySchema": {"ctx": "uint"}, "schema": {"value": "uint"}}}`), 0644))
+	err := GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test", Context: true}, false)
+	r.ErrorContains(err, "key 'ctx' conflicts with the context argument")
+	r.NoError(GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test"}, false))
+}
+
+func TestDatamodMultiReturn(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         tmpDir,
+		Package:        "test",
+		Memory:         true,
+		MultiReturn:    true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "keylessTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (m *KeylessTable) GetRow() (\n\tvalueUint *uint256.Int,\n\tvalueString string,\n")
+	r.Contains(string(content), "return m.Get().GetValues().tuple()")
+	// Optional values are returned along with whether they are set
+	content, err = os.ReadFile(filepath.Join(tmpDir, "optionalTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "\tnickname []byte,\n\thasNickname bool,\n")
+	// The store interface is implemented by memory tables with the same getter
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keyedTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "\t) (\n\t\tvalueUint *uint256.Int,\n")
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keyedTable_memory.go"))
+	r.NoError(err)
+	r.Contains(string(content), "return row.tuple()")
+
+	// Tables without the option return a values struct
+	config.MultiReturn = false
+	r.NoError(GenerateDataModel(config, true))
+	content, err = os.ReadFile(filepath.Join(tmpDir, "keylessTable.go"))
+	r.NoError(err)
+	r.Contains(string(content), "func (m *KeylessTable) GetRow() KeylessTableValues {")
+	r.NotContains(string(content), "tuple")
+}
+
+func TestDatamodMultiReturnNames(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	r.NoError(os.WriteFile(schemaPath, []byte(`{"table": {"keySchema": {"id": "uint"}, "schema": {"id": "uint"}}}`), 0644))
+	err := GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test", MultiReturn: true}, false)
+	r.ErrorContains(err, "result 'id' of the row getter conflicts with an argument")
+	r.NoError(GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test"}, false))
+}
+
+func TestDatamodCompositeKeyDisabled(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	// Dynamic keys are only rejected in composite keys
+	r.NoError(os.WriteFile(schemaPath, []byte(`{"table": {"keySchema": {"name": "string"}, "compositeKey": false, "schema": {"value": "uint"}}}`), 0644))
+	r.NoError(GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test"}, false))
+}
+
+func TestDatamodImportPaths(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         tmpDir,
+		Package:        "test",
+		Memory:         true,
+		Fuzz:           true,
+		ImportCommon:   "example.com/fork/common",
+		ImportUint256:  "example.com/uint256",
+		ImportRuntime:  "example.com/fork/concrete",
+	}
+	r.NoError(GenerateDataModel(config, true))
+	for _, name := range []string{"keyedTable.go", "keyedTable_memory.go", "keyedTable_fuzz_test.go", "accountView.go", "enums.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		r.NoError(err)
+		r.NotContains(string(content), "github.com/ethereum/go-ethereum", name)
+		r.NotContains(string(content), "github.com/holiman/uint256", name)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "keyedTable.go"))
+	r.NoError(err)
+	for _, path := range []string{"example.com/fork/common", "example.com/fork/common/hexutil", "example.com/uint256", "example.com/fork/concrete/lib", "example.com/fork/concrete/codegen/datamod/codec", "example.com/fork/concrete/storage"} {
+		r.Contains(string(content), "\t\""+path+"\"\n")
+	}
+
+	config.ImportRuntime = "example.com/fork concrete"
+	r.ErrorContains(GenerateDataModel(config, true), "invalid import path: example.com/fork concrete")
+}
+
+func TestDatamodBaseSlot(t *testing.T) {
+	r := require.New(t)
+	tmpDir := t.TempDir()
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	r.NoError(os.WriteFile(schemaPath, []byte(`{"a": {"schema": {"x": "uint64"}}, "b": {"baseSlot": 3, "schema": {"x": "uint64"}}}`), 0644))
+	config := Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test", BaseSlot: 100}
+	r.NoError(GenerateDataModel(config, false))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "a.go"))
+	r.NoError(err)
+	r.Contains(string(content), `return storage.OffsetSlot(storage.TableSlot("A"), 100).Bytes()`)
+	content, err = os.ReadFile(filepath.Join(tmpDir, "b.go"))
+	r.NoError(err)
+	r.Contains(string(content), `return storage.OffsetSlot(storage.TableSlot("B"), 103).Bytes()`)
+
+	config.BaseSlot = math.MaxUint64
+	r.ErrorContains(GenerateDataModel(config, false), "invalid base slot schema for table 'b': the offset overflows 64 bits")
+}
+
+func TestDatamodJSONFormat(t *testing.T) {
+	r := require.New(t)
+	dslDir, jsonDir := "./tmp-format-dsl", "./tmp-format-json"
+	for _, dir := range []string{dslDir, jsonDir} {
+		os.Mkdir(dir, 0755)
+		defer os.RemoveAll(dir)
+	}
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         dslDir,
+		Package:        "test",
+		Solidity:       true,
+		ABI:            true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	config.SchemaFilePath = filepath.Join("testdata", "good-datamod-format.json")
+	config.Format = FormatJSON
+	config.OutDir = jsonDir
+	r.NoError(GenerateDataModel(config, true))
+
+	// Both formats describe the same tables and produce the same files
+	files, err := os.ReadDir(dslDir)
+	r.NoError(err)
+	jsonFiles, err := os.ReadDir(jsonDir)
+	r.NoError(err)
+	r.Equal(len(files), len(jsonFiles))
+	for _, file := range files {
+		dslContent, err := os.ReadFile(filepath.Join(dslDir, file.Name()))
+		r.NoError(err)
+		jsonContent, err := os.ReadFile(filepath.Join(jsonDir, file.Name()))
+		r.NoError(err, file.Name())
+		r.Equal(string(dslContent), string(jsonContent), file.Name())
+	}
+}
+
+func TestBadJSONFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{"unknownTableKey", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64"}], "packed": true}]}`, "unknown field \"packed\""},
+		{"unknownFieldKey", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64", "size": 8}]}]}`, "unknown field \"size\""},
+		{"unknownRootKey", `{"tables": [], "version": 1}`, "unknown field \"version\""},
+		{"missingTableName", `{"tables": [{"values": [{"name": "a", "type": "uint64"}]}]}`, "missing table name"},
+		{"duplicateTable", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64"}]}, {"name": "t", "values": [{"name": "a", "type": "uint64"}]}]}`, "duplicate table"},
+		{"duplicateField", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64"}, {"name": "a", "type": "bool"}]}]}`, "duplicate field 'a'"},
+		{"missingType", `{"tables": [{"name": "t", "values": [{"name": "a"}]}]}`, "missing type"},
+		{"noValues", `{"tables": [{"name": "t", "keys": [{"name": "a", "type": "uint64"}]}]}`, "no value schema"},
+		{"optionalKey", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64", "optional": true}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot be optional"},
+		{"pinnedKey", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64", "slot": 1}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot be pinned"},
+		{"orderedKey", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64", "order": 0}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot be ordered"},
+		{"badType", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint7"}]}]}`, "invalid type 'uint7'"},
+		{"keyMaxLen", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "string", "maxLen": 8}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot have a maximum length"},
+		{"badMaxLen", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64", "maxLen": 8}]}]}`, "is not bytes or a string"},
+		{"keyDefault", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64", "default": 1}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot have a default"},
+		{"badDefault", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "int8", "default": 128}]}]}`, "default 128 out of range for int8"},
+		{"badAlign", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint32", "align": "right"}]}]}`, "invalid type 'uint32 align:\"right\"'"},
+		{"cborWithoutGoType", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "cbor"}]}]}`, "cbor values need a gotype annotation"},
+		{"unknownViewKey", `{"tables": [], "views": [{"name": "v", "fields": [], "keys": []}]}`, "unknown field \"keys\""},
+		{"duplicateView", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64"}]}], "views": [{"name": "t", "fields": [{"name": "a", "table": "t", "value": "a"}]}]}`, "duplicate table or view"},
+		{"badEmit", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64"}], "emit": 1}]}`, "invalid emit schema for table 't'"},
+		{"badEndian", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "bool", "endian": "little"}]}]}`, "invalid type 'bool endian:\"little\"'"},
+		{"optionalCounter", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "counter", "optional": true}]}]}`, "counter 'a' cannot be optional"},
+		{"counterKey", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "counter64"}], "values": [{"name": "a", "type": "uint64"}]}]}`, "cannot have counter keys"},
+		{"counterGoType", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "counter64", "goType": "time.Duration"}]}]}`, "not supported for counters"},
+		{"badCounter", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "counter7"}]}]}`, "invalid type 'counter7'"},
+		{"badBigInt", `{"tables": [{"name": "t", "values": [{"name": "a", "type": "uint64", "bigInt": true}]}]}`, "bigint is only supported for integers wider than 64 bits"},
+		{"badInitialized", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64"}], "initialized": "lazy", "values": [{"name": "a", "type": "uint64"}]}]}`, "invalid initialized schema for table 't': expected a boolean or strict"},
+		{"keylessInitialized", `{"tables": [{"name": "t", "initialized": true, "values": [{"name": "a", "type": "uint64"}]}]}`, "initialized tables must have keys"},
+		{"negativeBaseSlot", `{"tables": [{"name": "t", "baseSlot": -1, "values": [{"name": "a", "type": "uint64"}]}]}`, "invalid base slot schema for table 't': expected an integer between 0 and 2^53"},
+		{"badBaseSlot", `{"tables": [{"name": "t", "baseSlot": "0x1ffffffffffffffff", "values": [{"name": "a", "type": "uint64"}]}]}`, "is not a 64-bit unsigned integer"},
+		{"boolBaseSlot", `{"tables": [{"name": "t", "baseSlot": true, "values": [{"name": "a", "type": "uint64"}]}]}`, "invalid base slot schema for table 't': expected an integer"},
+		{"badOrder", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64"}], "iterable": true, "order": "random", "values": [{"name": "a", "type": "uint64"}]}]}`, "expected insertion or keyhash"},
+		{"orderNotIterable", `{"tables": [{"name": "t", "keys": [{"name": "k", "type": "uint64"}], "order": "keyhash", "values": [{"name": "a", "type": "uint64"}]}]}`, "table is not iterable"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := unmarshalSchemas(FormatJSON, []byte(test.schema), false)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+	_, err := unmarshalSchemas("yaml", []byte("{}"), false)
+	require.ErrorContains(t, err, "unknown schema format")
+}
+
+func TestBadViewSchema(t *testing.T) {
+	tables := `"balances": {"keySchema": {"account": "address"}, "schema": {"balance": "uint64", "bonus": "optional uint64"}},
+		"nonces": {"keySchema": {"id": "uint64"}, "schema": {"nonce": "uint64"}}`
+	tests := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{"noFields", `"v": {"view": {}}`, "invalid schema for view 'v'"},
+		{"extraSchema", `"v": {"view": {"a": "balances.balance"}, "schema": {}}`, "views only have a view schema"},
+		{"badReference", `"v": {"view": {"a": "balance"}}`, "must reference a value as <table>.<value>"},
+		{"missingTable", `"v": {"view": {"a": "missing.balance"}}`, "table 'missing' does not exist"},
+		{"missingValue", `"v": {"view": {"a": "balances.missing"}}`, "table 'balances' has no value 'missing'"},
+		{"optionalValue", `"v": {"view": {"a": "balances.bonus"}}`, "is optional or a table"},
+		{"differentKeys", `"v": {"view": {"a": "balances.balance", "b": "nonces.nonce"}}`, "table 'nonces' has different keys than table 'balances'"},
+		{"tableName", `"nonces2": {"view": {"a": "balances.balance"}}, "Nonces2": {"schema": {"a": "uint64"}}`, "view has the same name as a table"},
+		{"viewValue", `"v": {"view": {"a": "balances.balance"}}, "t": {"schema": {"a": "table v"}}`, "table 'v' does not exist"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := unmarshalDSLSchemas([]byte("{"+tables+", "+test.schema+"}"), true)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
 }
 
 func TestBadDatamod(t *testing.T) {
@@ -64,6 +460,8 @@ func TestBadDatamod(t *testing.T) {
 				t.Fatalf("Expected error but got nil")
 			} else if !strings.Contains(err.Error(), "schema for table") { // Fragile
 				t.Fatalf("Unexpected error: %s", err)
+			} else if !strings.HasPrefix(err.Error(), filepath.Join(dirPath, file.Name())+":") {
+				t.Fatalf("Error without position: %s", err)
 			}
 		})
 	}
@@ -141,9 +539,209 @@ func TestTables(t *testing.T) {
 		})
 	})
 
+	t.Run("RowValues", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewKeyedTable(ds)
+		key := uint256.NewInt(200)
+		r.Equal(testdata.KeyedTableValues{
+			ValueUint:    new(uint256.Int),
+			ValueBytes:   []byte{},
+			ValueBytes16: make([]byte, 16),
+		}, table.GetRow(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+
+		values := testdata.KeyedTableValues{
+			ValueUint:    uintVal,
+			ValueString:  stringVal,
+			ValueBytes:   bytesVal,
+			ValueBool:    boolVal,
+			ValueAddress: addrVal,
+			ValueBytes16: bytes16Val,
+		}
+		table.SetRow(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val, values)
+		r.Equal(values, table.GetRow(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+		row := table.Get(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
+		r.Equal(addrVal, row.GetValueAddress())
+		r.Equal(stringVal, row.GetValueString())
+
+		optional := testdata.NewOptionalTable(ds)
+		optionalValues := testdata.OptionalTableValues{
+			Required:  1,
+			Score:     uint256.NewInt(0),
+			HasScore:  true,
+			Name:      "name",
+			Active:    true,
+			HasActive: false,
+		}
+		optional.SetRow(2, optionalValues)
+		_, ok := optional.Get(2).GetNickname()
+		r.False(ok)
+		_, ok = optional.Get(2).GetActive()
+		r.False(ok)
+		score, ok := optional.Get(2).GetScore()
+		r.True(ok)
+		r.Equal(uint256.NewInt(0), score)
+		// Unset values are read back as zero values
+		optionalValues.Active = false
+		r.Equal(optionalValues, optional.GetRow(2))
+
+		iterable := testdata.NewIterableTable(ds)
+		iterable.SetRow(common.Address{0xaa}, testdata.IterableTableValues{Balance: 1, Tags: []uint8{1, 2}})
+		r.Contains(iterable.Keys(), common.Address{0xaa})
+		r.Equal([]uint8{1, 2}, iterable.GetRow(common.Address{0xaa}).Tags)
+		iterable.Delete(common.Address{0xaa})
+		r.Zero(iterable.Len())
+	})
+
+	t.Run("DeleteRow", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewKeyedTable(ds)
+		longString := strings.Repeat("long string ", 8)
+		key := uint256.NewInt(100)
+		r.False(table.Has(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+
+		row := table.Get(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
+		row.SetValueString(longString)
+		r.True(table.Has(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+		stringSlot := row.GetField_slot(1)
+		dataSlot := ds.Get(crypto.Keccak256(stringSlot.Slot().Bytes()))
+		r.NotEqual(common.Hash{}, dataSlot.Bytes32())
+
+		table.Delete(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
+		r.False(table.Has(key, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+		r.Equal("", row.GetValueString())
+		r.Equal(common.Hash{}, dataSlot.Bytes32())
+
+		arrayRow := testdata.NewDynamicArrayTable(ds).Get()
+		arrayRow.SetAmounts([]uint64{1, 2, 3})
+		item := arrayRow.GetField_slot(1).ContiguousArray().Get(0)
+		testdata.NewDynamicArrayTable(ds).Delete()
+		r.False(testdata.NewDynamicArrayTable(ds).Has())
+		r.Empty(arrayRow.GetAmounts())
+		r.Equal(common.Hash{}, item.Bytes32())
+	})
+
+	t.Run("IterableTable", func(t *testing.T) {
+		r := require.New(t)
+		// Logic depending on the table can use its store interface
+		var table testdata.IterableTableStore = testdata.NewIterableTable(ds)
+		accounts := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}}
+		r.Zero(table.Len())
+		r.Empty(table.Keys())
+
+		table.Get(accounts[0]).SetBalance(1)
+		table.Get(accounts[1]).SetName("name")
+		table.Get(accounts[2]).GetTagsArray().Push(1)
+		table.Get(accounts[3]).Set(4, "", []uint8{})
+		table.Get(accounts[0]).SetBalance(2)
+		r.Equal(uint64(4), table.Len())
+		r.Equal(accounts, table.Keys())
+		r.Equal(accounts[2], table.KeyAt(2))
+		r.Panics(func() { table.KeyAt(4) })
+
+		// Pages are clamped to the end of the index
+		r.Equal(accounts[1:3], table.KeysPage(1, 2))
+		r.Equal(accounts[2:], table.KeysPage(2, 10))
+		r.Equal(accounts[3:], table.KeysPage(3, math.MaxUint64))
+		r.Empty(table.KeysPage(1, 0))
+		r.NotNil(table.KeysPage(4, 1))
+		r.Empty(table.KeysPage(4, 1))
+		r.Empty(table.KeysPage(math.MaxUint64, math.MaxUint64))
+
+		// Rows that are only read are not indexed
+		table.Get(common.Address{0x05}).GetBalance()
+		r.Equal(uint64(4), table.Len())
+
+		table.Delete(accounts[1])
+		r.Equal([]common.Address{accounts[0], accounts[3], accounts[2]}, table.Keys())
+		table.Get(accounts[2]).Delete()
+		r.Equal([]common.Address{accounts[0], accounts[3]}, table.Keys())
+		table.Delete(accounts[3])
+		table.Delete(accounts[3])
+		r.Equal([]common.Address{accounts[0]}, testdata.NewIterableTable(ds).Keys())
+		r.Equal(uint64(2), table.Get(accounts[0]).GetBalance())
+
+		table.Get(accounts[1]).SetBalance(1)
+		r.Equal([]common.Address{accounts[0], accounts[1]}, table.Keys())
+	})
+
+	t.Run("IterableMultiKeyTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewIterableMultiKeyTable(ds)
+		table.Get(addrVal, 1).SetValue(uintVal)
+		table.Get(addrVal, 2).SetValue(uintVal)
+		table.Get(common.Address{}, 1).SetValue(uintVal)
+		table.Delete(addrVal, 1)
+
+		owner, id := table.KeyAt(0)
+		r.Equal(common.Address{}, owner)
+		r.Equal(uint64(1), id)
+		r.Equal([]testdata.IterableMultiKeyTableKey{
+			{Owner: common.Address{}, Id: 1},
+			{Owner: addrVal, Id: 2},
+		}, table.Keys())
+		r.Equal([]testdata.IterableMultiKeyTableKey{{Owner: addrVal, Id: 2}}, table.KeysPage(1, 5))
+		r.Empty(table.KeysPage(2, 5))
+	})
+
+	t.Run("KeyHashIterableTable", func(t *testing.T) {
+		r := require.New(t)
+		keys := make([]testdata.KeyHashIterableTableKey, 8)
+		for ii := range keys {
+			keys[ii] = testdata.KeyHashIterableTableKey{Owner: common.Address{byte(ii % 2)}, Id: uint64(ii)}
+		}
+		sorted := func(keys []testdata.KeyHashIterableTableKey) []testdata.KeyHashIterableTableKey {
+			hash := func(key testdata.KeyHashIterableTableKey) []byte {
+				return crypto.Keccak256(key.Owner.Bytes(), binary.BigEndian.AppendUint64(nil, key.Id))
+			}
+			sorted := append([]testdata.KeyHashIterableTableKey{}, keys...)
+			sort.Slice(sorted, func(i, j int) bool {
+				return bytes.Compare(hash(sorted[i]), hash(sorted[j])) < 0
+			})
+			return sorted
+		}
+
+		// Tables with the same rows enumerate them in the same order whatever
+		// the order they were written and deleted in
+		newTable := func() *testdata.KeyHashIterableTable {
+			contract := api.NewContract(common.Address{}, common.Address{}, addr, new(uint256.Int))
+			return testdata.NewKeyHashIterableTable(lib.NewDatastore(mock.NewMockEnvironment(config, meterGas, contract)))
+		}
+		a, b := newTable(), newTable()
+		for ii := range keys {
+			a.Get(keys[ii].Owner, keys[ii].Id).SetValue(uintVal)
+			b.Get(keys[len(keys)-1-ii].Owner, keys[len(keys)-1-ii].Id).SetValue(uintVal)
+		}
+		b.Get(keys[0].Owner, keys[0].Id).SetValue(uintVal)
+		r.Equal(sorted(keys), a.Keys())
+		r.Equal(a.Keys(), b.Keys())
+
+		for _, ii := range []int{3, 0, 6} {
+			a.Delete(keys[ii].Owner, keys[ii].Id)
+		}
+		for _, ii := range []int{6, 3, 0} {
+			b.Get(keys[ii].Owner, keys[ii].Id).Delete()
+		}
+		remaining := []testdata.KeyHashIterableTableKey{keys[1], keys[2], keys[4], keys[5], keys[7]}
+		r.Equal(uint64(len(remaining)), a.Len())
+		r.Equal(sorted(remaining), a.Keys())
+		r.Equal(a.Keys(), b.Keys())
+		owner, id := b.KeyAt(1)
+		r.Equal(sorted(remaining)[1], testdata.KeyHashIterableTableKey{Owner: owner, Id: id})
+
+		// Deleting every row leaves the index empty, and later rows are
+		// inserted in order again
+		for _, key := range remaining {
+			a.Delete(key.Owner, key.Id)
+		}
+		r.Zero(a.Len())
+		a.Get(keys[3].Owner, keys[3].Id).SetValue(uintVal)
+		a.Get(keys[6].Owner, keys[6].Id).SetValue(uintVal)
+		r.Equal(sorted([]testdata.KeyHashIterableTableKey{keys[3], keys[6]}), a.Keys())
+	})
+
 	t.Run("KeylessTable", func(t *testing.T) {
 		testRow(t, func() testRowInterface {
-			return testdata.NewKeylessTable(ds)
+			return testdata.NewKeylessTable(ds).Get()
 		})
 	})
 
@@ -160,22 +758,1090 @@ func TestTables(t *testing.T) {
 		table := testdata.NewKeyedWithKeylessTableValue(ds)
 		row := table.Get(uintVal)
 		testRow(t, func() testRowInterface {
-			return row.GetValueTable()
+			return row.GetValueTable().Get()
 		})
 	})
 
 	t.Run("keylessWithKeyedTable", func(t *testing.T) {
-		row := testdata.NewKeylessWithKeyedTableValue(ds)
+		row := testdata.NewKeylessWithKeyedTableValue(ds).Get()
 		subTable := row.GetValueTable()
 		testRow(t, func() testRowInterface {
 			return subTable.Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
 		})
 	})
 
+	t.Run("NestedTables", func(t *testing.T) {
+		r := require.New(t)
+		pools := testdata.NewPoolTable(ds)
+		pool := pools.Get(7)
+		pool.SetFee(30)
+		pool.Settings().Get().Set(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
+		pools.Get(7).Holders().Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val).SetValueUint(uint256.NewInt(5))
+
+		r.Equal(uint64(30), pools.Get(7).GetFee())
+		r.Equal(uintVal, pools.Get(7).Settings().Get().GetValueUint())
+		r.Equal(stringVal, pools.Get(7).Settings().Get().GetValueString())
+		r.Equal(uint256.NewInt(5), pool.Holders().Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val).GetValueUint())
+		r.False(pools.Get(8).Settings().Has())
+
+		// Nested tables are rooted at keccak256(rowSlot . uint256(index) . "datamod.v1.table")
+		rowSlot := ds.Get(testdata.PoolTableDefaultKey()).Mapping().GetNested(codec.EncodeUint64(8, 7))
+		settingsSlot := ds.Get(crypto.Keccak256(rowSlot.Slot().Bytes(), uint256.NewInt(2).PaddedBytes(32), []byte("datamod.v1.table")))
+		r.Equal(uintVal, settingsSlot.Uint256())
+		r.Equal(settingsSlot.Slot(), pool.GetSettings().Get().GetBase_slot().Slot())
+		holdersSlot := ds.Get(crypto.Keccak256(rowSlot.Slot().Bytes(), uint256.NewInt(1).PaddedBytes(32), []byte("datamod.v1.table")))
+		holderSlot := holdersSlot.Mapping().GetNested(
+			codec.EncodeUint256(32, uintVal),
+			codec.EncodeString(32, stringVal),
+			codec.EncodeBytes(32, bytesVal),
+			codec.EncodeBool(1, boolVal),
+			codec.EncodeAddress(20, addrVal),
+			codec.EncodeFixedBytes(16, bytes16Val),
+		)
+		r.Equal(uint256.NewInt(5), holderSlot.Uint256())
+
+		// Clearing the settings leaves the fee and the holders untouched
+		pool.Settings().Delete()
+		r.Equal(uint64(30), pool.GetFee())
+		r.Equal(uint256.NewInt(5), pool.Holders().Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val).GetValueUint())
+	})
+
+	t.Run("CompositeKeyTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewCompositeKeyTable(ds)
+		owner, spender := common.Address{0x01}, common.Address{0x02}
+		table.Get(owner, spender).SetValue(uintVal)
+		r.Equal(uintVal, table.Get(owner, spender).GetValue())
+		r.True(table.Get(spender, owner).GetValue().IsZero())
+
+		slot := ds.Get(testdata.CompositeKeyTableDefaultKey()).Mapping().GetComposite(owner.Bytes(), spender.Bytes())
+		r.Equal(uintVal, slot.Uint256())
+	})
+
+	t.Run("PackedKeyTable", func(t *testing.T) {
+		r := require.New(t)
+		owner := common.HexToAddress("0xc0de")
+		row := testdata.NewPackedKeyTable(ds).Get(0x01020304, -2, true, []byte{0xaa, 0xbb}, owner)
+
+		// Composite keys are hashed as abi.encodePacked(id, delta, flag, tag,
+		// owner, base) in solidity, with the little-endian id hashed big-endian
+		packed := []byte{0x01, 0x02, 0x03, 0x04, 0xff, 0xfe, 0x01, 0xaa, 0xbb, 0x00, 0x00}
+		packed = append(packed, owner.Bytes()...)
+		packed = append(packed, storage.TableSlot("PackedKeyTable").Bytes()...)
+		r.Equal(crypto.Keccak256Hash(packed), row.GetBase_slot().Slot())
+	})
+
+	t.Run("PaddedKeyTable", func(t *testing.T) {
+		r := require.New(t)
+		owner := common.HexToAddress("0xc0de")
+		row := testdata.NewPaddedKeyTable(ds).Get(owner, -2, []byte{0xaa, 0xbb, 0xcc, 0xdd}, "name")
+
+		// Padded keys are hashed as in solidity mappings, each key abi.encoded
+		// as a 32 bytes word except strings and bytes
+		delta := common.LeftPadBytes([]byte{0xfe}, 32)
+		for ii := 0; ii < 31; ii++ {
+			delta[ii] = 0xff
+		}
+		slot := crypto.Keccak256(common.LeftPadBytes(owner.Bytes(), 32), storage.TableSlot("PaddedKeyTable").Bytes())
+		slot = crypto.Keccak256(delta, slot)
+		slot = crypto.Keccak256(common.RightPadBytes([]byte{0xaa, 0xbb, 0xcc, 0xdd}, 32), slot)
+		slot = crypto.Keccak256([]byte("name"), slot)
+		r.Equal(common.BytesToHash(slot), row.GetBase_slot().Slot())
+
+		// Padded composite keys are hashed as abi.encode(id, owner, base)
+		compositeRow := testdata.NewPaddedCompositeKeyTable(ds).Get(0x01020304, owner)
+		encoded := common.LeftPadBytes([]byte{0x01, 0x02, 0x03, 0x04}, 32)
+		encoded = append(encoded, common.LeftPadBytes(owner.Bytes(), 32)...)
+		encoded = append(encoded, storage.TableSlot("PaddedCompositeKeyTable").Bytes()...)
+		r.Equal(crypto.Keccak256Hash(encoded), compositeRow.GetBase_slot().Slot())
+	})
+
+	t.Run("UintKeyTable", func(t *testing.T) {
+		r := require.New(t)
+		abiEncode := func(types []string, values ...interface{}) []byte {
+			var args abi.Arguments
+			for _, typ := range types {
+				abiType, err := abi.NewType(typ, "", nil)
+				r.NoError(err)
+				args = append(args, abi.Argument{Type: abiType})
+			}
+			data, err := args.Pack(values...)
+			r.NoError(err)
+			return data
+		}
+		id := new(big.Int).Lsh(big.NewInt(1), 200)
+		row := testdata.NewUintKeyTable(ds).Get(uint256.MustFromBig(id))
+		row.SetBalance(uint256.NewInt(5))
+
+		// uint256 keys are words, so rows are at the slots of
+		// mapping(uint256 => ...) in solidity, keccak256(abi.encode(id, base))
+		base := storage.TableSlot("UintKeyTable")
+		slot := crypto.Keccak256Hash(abiEncode([]string{"uint256", "bytes32"}, id, [32]byte(base)))
+		r.Equal(slot, row.GetBase_slot().Slot())
+		r.Equal(common.BigToHash(big.NewInt(5)), row.GetField_slot(0).Bytes32())
+
+		// Narrower integers are padded to a word with the padded key packing,
+		// as for mapping(uint64 => mapping(uint8 => ...))
+		paddedRow := testdata.NewPaddedUintKeyTable(ds).Get(0x0102030405060708, 9)
+		base = storage.TableSlot("PaddedUintKeyTable")
+		slot = crypto.Keccak256Hash(abiEncode([]string{"uint64", "bytes32"}, uint64(0x0102030405060708), [32]byte(base)))
+		slot = crypto.Keccak256Hash(abiEncode([]string{"uint8", "bytes32"}, uint8(9), [32]byte(slot)))
+		r.Equal(slot, paddedRow.GetBase_slot().Slot())
+	})
+
+	t.Run("DynamicArrayTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewDynamicArrayTable(ds).Get()
+		r.Empty(row.GetHolders())
+
+		holders := []common.Address{{0x01}, {0x02}, {0x03}}
+		row.SetHolders(holders)
+		r.Equal(holders, row.GetHolders())
+
+		arr := row.GetHoldersArray()
+		r.Equal(uint64(3), arr.Len())
+		arr.Set(1, common.Address{0x04})
+		arr.Push(common.Address{0x05})
+		r.Equal([]common.Address{{0x01}, {0x04}, {0x03}, {0x05}}, row.GetHolders())
+		r.Panics(func() { arr.Get(4) })
+
+		row.SetHolders(holders[:1])
+		r.Equal(holders[:1], testdata.NewDynamicArrayTable(ds).Get().GetHolders())
+
+		row.SetAmounts([]uint64{1, 2})
+		holdersOut, amountsOut := row.Get()
+		r.Equal(holders[:1], holdersOut)
+		r.Equal([]uint64{1, 2}, amountsOut)
+
+		// Words written by other code can have bytes above the width of the value
+		row.GetField_slot(1).ContiguousArray().Get(0).SetBytes32(common.HexToHash("0x010000000000000001"))
+		r.PanicsWithError("non-zero bytes above the width of the value: expected 8 bytes, got 0x0000000000000000000000000000000000000000000000010000000000000001", func() { row.GetAmountsArray().Get(0) })
+	})
+
+	t.Run("GoTypeTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewGoTypeTable(ds)
+		id := gotypes.AccountID{0x01}
+		table.Get(id).SetBalance(gotypes.Balance(10))
+		r.Equal(gotypes.Balance(10), table.Get(id).GetBalance())
+		r.Equal(codec.EncodeUint64(8, 10), table.Get(id).GetField(0))
+	})
+
+	t.Run("StructTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewStructTable(ds).Get(1)
+		position := testdata.Point{X: 1, Y: 2}
+		segment := testdata.Segment{
+			Start: testdata.Point{X: 3, Y: 4},
+			End:   testdata.Point{X: 5, Y: 6},
+			Label: []byte("segment1"),
+		}
+		row.Set(position, segment)
+
+		row = testdata.NewStructTable(ds).Get(1)
+		r.Equal(position, row.GetPosition())
+		r.Equal(segment, row.GetSegment())
+		r.Equal(append(codec.EncodeUint64(8, 1), codec.EncodeUint64(8, 2)...), row.GetField(0))
+		r.Zero(testdata.NewStructTable(ds).Get(2).GetSegment().End.Y)
+	})
+
+	t.Run("TimeTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewTimeTable(ds).Get(1)
+		createdAt, ttl, history := row.Get()
+		r.True(createdAt.IsZero())
+		r.Zero(ttl)
+		r.Empty(history)
+
+		createdAt = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+		row.SetCreatedAt(createdAt)
+		row.SetTtl(time.Hour)
+		row.SetHistory([]time.Time{createdAt, createdAt.Add(time.Minute)})
+
+		row = testdata.NewTimeTable(ds).Get(1)
+		r.Equal(createdAt, row.GetCreatedAt())
+		r.Equal(time.Hour, row.GetTtl())
+		r.Equal([]time.Time{createdAt, createdAt.Add(time.Minute)}, row.GetHistory())
+		r.Equal(codec.EncodeUint64(8, 3600), row.GetField(1))
+		r.Panics(func() { row.SetTtl(-time.Hour) })
+	})
+
+	t.Run("FixedTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewFixedTable(ds).Get(1)
+		r.True(row.GetPrice().IsZero())
+		r.Equal("0", row.GetDelta().String())
+
+		price, err := testdata.ParseUfixed128x18("1.5")
+		r.NoError(err)
+		delta, err := testdata.ParseFixed64x4("-0.25")
+		r.NoError(err)
+		rate, err := testdata.ParseUfixed32x2("0.05")
+		r.NoError(err)
+		row.SetPrice(price.Mul(price))
+		row.SetDelta(delta)
+		row.SetRates([3]testdata.Ufixed32x2{rate, rate.Add(rate), {}})
+		row.GetHistoryArray().Push(delta.Sub(delta).Sub(delta))
+
+		row = testdata.NewFixedTable(ds).Get(1)
+		r.Equal("2.25", row.GetPrice().String())
+		r.Equal(2.25, row.GetPrice().Float64())
+		r.Equal("-0.25", row.GetDelta().String())
+		r.Equal(-1, row.GetDelta().Cmp(testdata.Fixed64x4{}))
+		rates := row.GetRates()
+		r.Equal("0.1", rates[1].String())
+		r.True(rates[2].IsZero())
+		r.Equal("0.25", row.GetHistoryArray().Get(0).String())
+
+		// Decimals are stored as their raw integer value
+		r.Equal(codec.EncodeInt64(8, -2500), row.GetField(1))
+		r.Panics(func() { testdata.NewUfixed32x2(new(uint256.Int).Lsh(uint256.NewInt(1), 32)) })
+		_, err = testdata.ParseUfixed32x2("0.001")
+		r.Error(err)
+	})
+
+	t.Run("OptionalTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewOptionalTable(ds).Get(1)
+		nickname, ok := row.GetNickname()
+		r.False(ok)
+		r.Nil(nickname)
+		_, ok = row.GetActive()
+		r.False(ok)
+
+		// Zero values are distinguished from unset values
+		row.SetActive(false)
+		row.SetScore(uint256.NewInt(0))
+		active, ok := row.GetActive()
+		r.True(ok)
+		r.False(active)
+		score, ok := row.GetScore()
+		r.True(ok)
+		r.Equal(uint256.NewInt(0), score)
+		_, ok = row.GetNickname()
+		r.False(ok)
+
+		row.ClearScore()
+		_, ok = row.GetScore()
+		r.False(ok)
+		_, ok = row.GetActive()
+		r.True(ok)
+		r.Equal([]byte{0x04}, row.GetField(5))
+
+		row.Set(1, bytes16Val, uintVal, "name", true)
+		row = testdata.NewOptionalTable(ds).Get(1)
+		nickname, ok = row.GetNickname()
+		r.True(ok)
+		r.Equal(bytes16Val, nickname)
+		score, ok = row.GetScore()
+		r.True(ok)
+		r.Equal(uintVal, score)
+
+		row.Delete()
+		_, ok = row.GetNickname()
+		r.False(ok)
+		r.False(testdata.NewOptionalTable(ds).Has(1))
+	})
+
+	t.Run("PinnedTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewPinnedTable(ds).Get(1)
+		row.Set(1, 2, addrVal, "label", uint256.NewInt(3))
+
+		row = testdata.NewPinnedTable(ds).Get(1)
+		first, second, owner, label, amount := row.Get()
+		r.Equal(uint64(1), first)
+		r.Equal(uint64(2), second)
+		r.Equal(addrVal, owner)
+		r.Equal("label", label)
+		r.Equal(uint256.NewInt(3), amount)
+
+		// Pinned values are stored at the start of their slot
+		slots := row.GetField_slot(3).SlotArray([]int{4})
+		r.Equal("label", string(slots.Get(0).Bytes()))
+		r.Equal(codec.EncodeUint64(8, 1), slots.Get(2).Bytes32().Bytes()[:8])
+		r.Equal(codec.EncodeUint64(8, 2), slots.Get(1).Bytes32().Bytes()[:8])
+		r.Equal(codec.EncodeUint128(16, uint256.NewInt(3)), slots.Get(3).Bytes32().Bytes()[:16])
+	})
+
+	t.Run("LittleEndianTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewLittleEndianTable(ds)
+		wideSigned := new(uint256.Int).Neg(uint256.NewInt(4))
+		table.Get(0x01020304).Set(0x0102, -2, uint256.NewInt(3), wideSigned, 5, 6)
+
+		row := table.Get(0x01020304)
+		small, signed, wide, gotWideSigned, flag, plain := row.Get()
+		r.Equal(uint16(0x0102), small)
+		r.Equal(int64(-2), signed)
+		r.Equal(uint256.NewInt(3), wide)
+		r.Equal(wideSigned, gotWideSigned)
+		r.Equal(uint8(5), flag)
+		r.Equal(uint64(6), plain)
+
+		// Keys and values are stored least significant byte first
+		slot := ds.Get(testdata.LittleEndianTableDefaultKey()).Mapping().GetNested([]byte{0x04, 0x03, 0x02, 0x01})
+		r.Equal(slot.Slot(), row.GetField_slot(0).Slot())
+		data := row.GetField_slot(0).Bytes32().Bytes()
+		r.Equal([]byte{0x02, 0x01}, data[:2])
+		r.Equal(codec.EncodeInt64LE(8, -2), data[2:10])
+		r.Equal(byte(0x03), data[10])
+		data = row.GetField_slot(3).Bytes32().Bytes()
+		r.Equal(byte(0xfc), data[0])
+		data = row.GetField_slot(4).Bytes32().Bytes()
+		r.Equal(codec.EncodeUint64(8, 6), data[1:9])
+	})
+
+	t.Run("BigIntTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewBigIntTable(ds)
+		id := new(big.Int).Lsh(big.NewInt(1), 100)
+		row := table.Get(id)
+		r.Equal(big.NewInt(-1), row.GetFloor())
+
+		balance := new(big.Int).Lsh(big.NewInt(1), 200)
+		row.Set(balance, big.NewInt(-7), big.NewInt(9), big.NewInt(-2))
+		gotBalance, debt, limit, floor := table.Get(id).Get()
+		r.Equal(balance, gotBalance)
+		r.Equal(big.NewInt(-7), debt)
+		r.Equal(big.NewInt(9), limit)
+		r.Equal(big.NewInt(-2), floor)
+
+		// Values are stored as the uint256 representation of the same width
+		r.Equal(codec.EncodeInt128(16, new(uint256.Int).Neg(uint256.NewInt(7))), row.GetField(1))
+		key := codec.EncodeUint128(16, new(uint256.Int).Lsh(uint256.NewInt(1), 100))
+		slot := ds.Get(testdata.BigIntTableDefaultKey()).Mapping().GetNested(key)
+		r.Equal(slot.Slot(), row.GetField_slot(0).Slot())
+
+		// Values out of the range of the field are rejected when set
+		r.Panics(func() { row.SetDebt(new(big.Int).Lsh(big.NewInt(1), 127)) })
+		r.Panics(func() { row.SetBalance(big.NewInt(-1)) })
+		r.Equal(big.NewInt(-7), row.GetDebt())
+
+		maxInt128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+		row.SetDebt(maxInt128)
+		r.ErrorIs(row.AddDebt(big.NewInt(1)), codec.ErrOverflow)
+		r.NoError(row.SubDebt(maxInt128))
+		r.Zero(row.GetDebt().Sign())
+
+		data, err := json.Marshal(row.GetValues())
+		r.NoError(err)
+		r.JSONEq(`{"balance":"1606938044258990275541962092341162602522202993782792835301376","debt":"0","limit":"9","floor":"-2"}`, string(data))
+		var decoded testdata.BigIntTableValues
+		r.NoError(json.Unmarshal(data, &decoded))
+		r.True(decoded.Equal(row.GetValues()))
+		r.ErrorIs(json.Unmarshal([]byte(`{"balance":"-1","debt":"0","limit":null,"floor":"0"}`), &decoded), codec.ErrIntOutOfRange)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		r := require.New(t)
+		values := testdata.LittleEndianTableValues{
+			Small:      0x0102,
+			Signed:     -2,
+			Wide:       uint256.NewInt(3),
+			WideSigned: new(uint256.Int).Neg(uint256.NewInt(4)),
+			Flag:       5,
+			Plain:      6,
+		}
+		data, err := json.Marshal(values)
+		r.NoError(err)
+		r.JSONEq(`{"small":258,"signed":-2,"wide":"3","wideSigned":"-4","flag":5,"plain":6}`, string(data))
+		var decoded testdata.LittleEndianTableValues
+		r.NoError(json.Unmarshal(data, &decoded))
+		r.Equal(values, decoded)
+
+		// Bytes are hex strings and absent optional values are null
+		optional := testdata.OptionalTableValues{Required: 1, Nickname: []byte{0xab}, HasNickname: true, Name: "name"}
+		data, err = json.Marshal(optional)
+		r.NoError(err)
+		r.JSONEq(`{"required":1,"nickname":"0xab","score":null,"name":"name","active":null}`, string(data))
+		var decodedOptional testdata.OptionalTableValues
+		r.NoError(json.Unmarshal(data, &decodedOptional))
+		r.Equal(optional, decodedOptional)
+
+		// Decimals are strings
+		price, err := testdata.ParseUfixed128x18("1.5")
+		r.NoError(err)
+		data, err = json.Marshal(testdata.FixedTableValues{Price: price})
+		r.NoError(err)
+		r.Contains(string(data), `"price":"1.5"`)
+		var decodedFixed testdata.FixedTableValues
+		r.NoError(json.Unmarshal(data, &decodedFixed))
+		r.Equal("1.5", decodedFixed.Price.String())
+
+		segment := testdata.Segment{Start: testdata.Point{X: 1, Y: 2}, Label: []byte{0x01}}
+		data, err = json.Marshal(segment)
+		r.NoError(err)
+		r.JSONEq(`{"start":{"x":1,"y":2},"end":{"x":0,"y":0},"label":"0x01"}`, string(data))
+		var decodedSegment testdata.Segment
+		r.NoError(json.Unmarshal(data, &decodedSegment))
+		r.Equal(segment, decodedSegment)
+
+		r.Error(json.Unmarshal([]byte(`{"small":1,"wideSigned":"-1e100"}`), &decoded))
+		r.ErrorContains(json.Unmarshal([]byte(`{"label":"0x000102030405060708"}`), &decodedSegment), "label")
+	})
+
+	t.Run("Packed", func(t *testing.T) {
+		r := require.New(t)
+		word := func(hex string) []byte {
+			return common.HexToHash(hex).Bytes()
+		}
+		// Integers are big-endian and dynamic values are not padded
+		keyed := testdata.KeyedTableValues{
+			ValueUint:    uintVal,
+			ValueString:  stringVal,
+			ValueBytes:   bytesVal,
+			ValueBool:    boolVal,
+			ValueAddress: addrVal,
+			ValueBytes16: bytes16Val,
+		}
+		r.Equal(bytes.Join([][]byte{word("0x01"), []byte(stringVal), bytesVal, {0x01}, addrVal.Bytes(), bytes16Val}, nil), keyed.Packed())
+		littleEndian := testdata.LittleEndianTableValues{
+			Small:      0x0102,
+			Signed:     -2,
+			Wide:       uint256.NewInt(3),
+			WideSigned: new(uint256.Int).Neg(uint256.NewInt(4)),
+			Flag:       5,
+			Plain:      6,
+		}
+		r.Equal(bytes.Join([][]byte{
+			{0x01, 0x02},
+			{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe},
+			common.LeftPadBytes([]byte{0x03}, 16),
+			word("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc"),
+			{0x05},
+			codec.EncodeUint64(8, 6),
+		}, nil), littleEndian.Packed())
+		// Array elements are padded to words
+		array := testdata.DynamicArrayTableValues{Holders: []common.Address{addrVal}, Amounts: []uint64{7, 8}}
+		r.Equal(bytes.Join([][]byte{common.LeftPadBytes(addrVal.Bytes(), 32), word("0x07"), word("0x08")}, nil), array.Packed())
+		r.Empty(testdata.DynamicArrayTableValues{}.Packed())
+	})
+
+	t.Run("AlignedBytesTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewAlignedBytesTable(ds)
+		left := []byte{0x01, 0x02, 0x03, 0x00}
+		right := []byte{0x00, 0x01, 0x02, 0x03}
+		table.Get([]byte{0x01, 0x02}).Set(left, right, left, common.BytesToHash(bytes16Val))
+
+		row := table.Get([]byte{0x01, 0x02})
+		leftOut, rightOut, plainOut, wordOut := row.Get()
+		r.Equal(left, leftOut)
+		r.Equal(right, rightOut)
+		r.Equal(left, plainOut)
+		r.Equal(common.BytesToHash(bytes16Val), wordOut)
+
+		// Short right aligned values are left padded, keys included
+		row.SetRight([]byte{0x05})
+		r.Equal([]byte{0x00, 0x00, 0x00, 0x05}, row.GetRight())
+		slot := ds.Get(testdata.AlignedBytesTableDefaultKey()).Mapping().GetNested(common.LeftPadBytes([]byte{0x01, 0x02}, 8))
+		r.Equal(slot.Slot(), row.GetField_slot(0).Slot())
+		data := row.GetField_slot(0).Bytes32().Bytes()
+		r.Equal(left, data[:4])
+		r.Equal([]byte{0x00, 0x00, 0x00, 0x05}, data[4:8])
+	})
+
+	t.Run("CheckedArithmetic", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewLittleEndianTable(ds).Get(9)
+		r.NoError(row.AddSmall(math.MaxUint16))
+		r.ErrorIs(row.AddSmall(1), codec.ErrOverflow)
+		r.Equal(uint16(math.MaxUint16), row.GetSmall())
+		r.NoError(row.SubSmall(math.MaxUint16))
+		r.ErrorIs(row.SubSmall(1), codec.ErrOverflow)
+
+		r.NoError(row.SubSigned(math.MaxInt64))
+		r.NoError(row.SubSigned(1))
+		r.ErrorIs(row.SubSigned(1), codec.ErrOverflow)
+		r.Equal(int64(math.MinInt64), row.GetSigned())
+
+		maxUint128 := new(uint256.Int).Sub(new(uint256.Int).Lsh(uint256.NewInt(1), 128), uint256.NewInt(1))
+		r.NoError(row.AddWide(maxUint128))
+		r.ErrorIs(row.AddWide(uint256.NewInt(1)), codec.ErrOverflow)
+		r.Equal(maxUint128, row.GetWide())
+
+		// Arithmetic starts from the default of the value
+		defaults := testdata.NewDefaultsTable(ds).Get(9)
+		r.NoError(defaults.AddOffset(uint256.NewInt(2)))
+		r.Equal(new(uint256.Int).Neg(uint256.NewInt(3)), defaults.GetOffset())
+		minInt128 := new(uint256.Int).Neg(new(uint256.Int).Lsh(uint256.NewInt(1), 127))
+		r.ErrorIs(defaults.AddOffset(minInt128), codec.ErrOverflow)
+		r.Equal(new(uint256.Int).Neg(uint256.NewInt(3)), defaults.GetOffset())
+	})
+
+	t.Run("CounterTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewCounterTable(ds).Get(1)
+		for expected := uint64(0); expected < 3; expected++ {
+			nonce, err := row.NextNonce()
+			r.NoError(err)
+			r.Equal(expected, nonce)
+		}
+		r.Equal(uint64(3), row.CurrentNonce())
+		r.Equal(uint64(3), testdata.NewCounterTable(ds).Get(1).GetNonce())
+
+		r.NoError(row.IncrementNonce(math.MaxUint64 - 4))
+		nonce, err := row.NextNonce()
+		r.NoError(err)
+		r.Equal(uint64(math.MaxUint64-1), nonce)
+		_, err = row.NextNonce()
+		r.ErrorIs(err, codec.ErrOverflow)
+		r.ErrorIs(row.IncrementNonce(1), codec.ErrOverflow)
+		r.Equal(uint64(math.MaxUint64), row.CurrentNonce())
+		r.NoError(row.DecrementNonce(math.MaxUint64))
+		r.ErrorIs(row.DecrementNonce(1), codec.ErrOverflow)
+
+		serial, err := row.NextSerial()
+		r.NoError(err)
+		r.True(serial.IsZero())
+		r.Equal(uint256.NewInt(1), row.CurrentSerial())
+		row.SetSerial(new(uint256.Int).SetAllOne())
+		serial, err = row.NextSerial()
+		r.ErrorIs(err, codec.ErrOverflow)
+		r.Nil(serial)
+
+		// Counters start from the default of the value
+		row = testdata.NewCounterTable(ds).Get(2)
+		for expected := uint8(250); expected < math.MaxUint8; expected++ {
+			small, err := row.NextSmall()
+			r.NoError(err)
+			r.Equal(expected, small)
+		}
+		_, err = row.NextSmall()
+		r.ErrorIs(err, codec.ErrOverflow)
+		r.Equal(uint8(math.MaxUint8), row.GetSmall())
+	})
+
+	t.Run("InitializedTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewInitializedTable(ds)
+		r.False(table.Exists(1))
+		r.Equal(uint8(1), table.Get(1).GetLevel())
+		r.PanicsWithValue(codec.ErrRowNotCreated, func() { table.Get(1).SetOwner(common.Address{0x01}) })
+		r.PanicsWithValue(codec.ErrRowNotCreated, func() { table.Get(1).GetTagsArray().Push(1) })
+		r.PanicsWithValue(codec.ErrRowNotCreated, func() { table.Get(1).ClearScore() })
+		r.Zero(table.Len())
+
+		// Created rows keep their defaults and are indexed
+		row := table.Create(1)
+		r.True(row.Exists())
+		r.True(table.Exists(1))
+		r.Equal(uint8(1), row.GetLevel())
+		r.Equal([]uint64{1}, table.Keys())
+		row.SetOwner(common.Address{0x01})
+		row.GetTagsArray().Push(7)
+		row.SetScore(3)
+		table.Create(1)
+		r.Equal(common.Address{0x01}, table.Get(1).GetOwner())
+		r.Equal([]uint32{7}, table.Get(1).GetTags())
+
+		// Created rows exist even when all their values are zero
+		row.Set(common.Address{}, 0, 0, []uint32{})
+		r.True(table.Exists(1))
+		r.Equal(uint8(0), row.GetLevel())
+
+		table.Delete(1)
+		r.False(table.Exists(1))
+		r.Zero(table.Len())
+		r.PanicsWithValue(codec.ErrRowNotCreated, func() { row.SetLevel(2) })
+	})
+
+	t.Run("DefaultsTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewDefaultsTable(ds)
+		limit := uint256.MustFromDecimal("1000000000000000000000")
+		offset := new(uint256.Int).Neg(uint256.NewInt(5))
+		owner := common.HexToAddress("0xc0de")
+
+		// Empty rows read as the defaults
+		row := table.Get(1)
+		r.Equal(uint16(30), row.GetFee())
+		r.True(row.GetEnabled())
+		r.Equal(owner, row.GetOwner())
+		r.Equal(limit, row.GetLimit())
+		r.Equal(offset, row.GetOffset())
+		r.Equal(uint64(0), row.GetPlain())
+		fee, enabled, gotOwner, gotLimit, gotOffset, note, plain := row.Get()
+		r.Equal(uint16(30), fee)
+		r.True(enabled)
+		r.Equal(owner, gotOwner)
+		r.Equal(limit, gotLimit)
+		r.Equal(offset, gotOffset)
+		r.Equal("", note)
+		r.Equal(uint64(0), plain)
+		values := row.GetValues()
+		r.Equal(uint16(30), values.Fee)
+		r.True(values.Enabled)
+		r.Equal(owner, values.Owner)
+		r.Equal(limit, values.Limit)
+		r.Equal(offset, values.Offset)
+
+		// Deliberately zeroed values are not masked once the row is written
+		row.SetPlain(1)
+		r.Equal(uint16(0), row.GetFee())
+		r.False(row.GetEnabled())
+		values = row.GetValues()
+		r.Equal(uint16(0), values.Fee)
+		r.Equal(common.Address{}, values.Owner)
+		r.True(values.Limit.IsZero())
+		row = table.Get(2)
+		row.SetNote("note")
+		r.Equal(uint16(0), row.GetFee())
+		r.Equal(uint16(0), row.GetValues().Fee)
+		row.SetFee(0)
+		r.Equal(uint16(0), row.GetFee())
+
+		// Stored values are read as is
+		row = table.Get(3)
+		row.SetFee(50)
+		r.Equal(uint16(50), row.GetFee())
+		r.False(row.GetEnabled())
+		row.Delete()
+		r.Equal(uint16(30), row.GetFee())
+	})
+
+	t.Run("AccountView", func(t *testing.T) {
+		r := require.New(t)
+		account := common.HexToAddress("0xacc0")
+		testdata.NewIterableTable(ds).Get(account).Set(100, "alice", []uint8{1})
+		testdata.NewReservesTable(ds).Get(account).SetFee(30)
+
+		values := testdata.NewAccountView(ds).Get(account)
+		r.Equal(uint64(100), values.Balance)
+		r.Equal("alice", values.Name)
+		r.Equal(uint16(30), values.Fee)
+
+		// Values of rows missing in one of the tables are zero or the default
+		item := testdata.NewItemView(ds).Get(1234)
+		r.Equal(testdata.Point{}, item.Position)
+		r.Equal(uint16(30), item.Fee)
+	})
+
+	t.Run("AuditTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewAuditTable(ds)
+		row := table.Get(1)
+		// Rows that were never written are valid
+		r.NoError(row.Verify())
+
+		entry := bytes.Repeat([]byte{0xab}, 100)
+		r.NoError(row.Set(entry, 3, "created"))
+		r.NoError(row.Verify())
+
+		// Writing the content without its hash is caught
+		row.GetField_slot(0).SetBytes(entry[:40])
+		err := row.Verify()
+		r.ErrorIs(err, codec.ErrHashMismatch)
+		r.ErrorContains(err, "entry")
+		row.SetEntry(entry[:40])
+		r.NoError(row.Verify())
+
+		row.GetField_slot(2).SetBytes([]byte("edited"))
+		r.ErrorIs(row.Verify(), codec.ErrHashMismatch)
+		r.NoError(row.SetNote("edited"))
+		r.NoError(row.Verify())
+
+		values := row.GetValues()
+		values.Entry = []byte{0x01}
+		r.NoError(table.SetRow(2, values))
+		r.NoError(table.Get(2).Verify())
+		r.Equal(values, table.GetRow(2))
+
+		row.Delete()
+		r.NoError(row.Verify())
+	})
+
+	t.Run("CappedTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewCappedTable(ds)
+		row := table.Get(1)
+		r.NoError(row.Set("12345678", []byte{0x01, 0x02, 0x03, 0x04}, strings.Repeat("x", 100), 5))
+		name, data, free, count := row.Get()
+		r.Equal("12345678", name)
+		r.Equal([]byte{0x01, 0x02, 0x03, 0x04}, data)
+		r.Equal(strings.Repeat("x", 100), free)
+		r.Equal(uint64(5), count)
+
+		// Values over the cap are rejected without writing anything
+		err := row.SetName("123456789")
+		r.ErrorIs(err, codec.ErrValueTooLong)
+		r.ErrorContains(err, "name is 9 bytes, at most 8 allowed")
+		r.Equal("12345678", row.GetName())
+		r.ErrorIs(row.SetData(make([]byte, 5)), codec.ErrValueTooLong)
+		r.ErrorIs(row.Set("", make([]byte, 5), "", 6), codec.ErrValueTooLong)
+		r.Equal(uint64(5), row.GetCount())
+		r.Equal("12345678", row.GetName())
+
+		values := row.GetValues()
+		values.Data = make([]byte, 5)
+		values.Count = 7
+		r.ErrorIs(row.SetValues(values), codec.ErrValueTooLong)
+		r.ErrorIs(table.SetRow(2, values), codec.ErrValueTooLong)
+		r.False(table.Has(2))
+		r.Equal(uint64(5), row.GetCount())
+
+		values.Data = nil
+		r.NoError(table.SetRow(2, values))
+		r.Equal(uint64(7), table.Get(2).GetCount())
+		r.NoError(row.SetName(""))
+		r.Equal("", row.GetName())
+	})
+
+	t.Run("FunctionTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewFunctionTable(ds).Get(1)
+		r.True(row.GetCallback().IsZero())
+
+		callback := codec.Function{Addr: addrVal, Selector: [4]byte{0xde, 0xad, 0xbe, 0xef}}
+		row.Set(callback, 5)
+		callbackOut, fee := testdata.NewFunctionTable(ds).Get(1).Get()
+		r.Equal(callback, callbackOut)
+		r.Equal(uint64(5), fee)
+		data := row.GetField_slot(0).Bytes32().Bytes()
+		r.Equal(codec.EncodeFunction(24, callback), data[:24])
+	})
+
+	t.Run("FlagsTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewFlagsTable(ds).Get(1)
+		permissions := testdata.Permissions(0).SetFlag(testdata.PermissionsRead).SetFlag(testdata.PermissionsAdmin)
+		r.True(permissions.HasFlag(testdata.PermissionsAdmin))
+		r.False(permissions.HasFlag(testdata.PermissionsWrite))
+		r.Equal(uint8(0b101), permissions.Raw())
+		r.Equal("read|admin", permissions.String())
+		r.True(permissions.IsValid())
+		r.False(testdata.Permissions(0b1000).IsValid())
+		r.Equal(permissions, permissions.SetFlag(testdata.PermissionsWrite).ClearFlag(testdata.PermissionsWrite))
+
+		var many testdata.ManyFlags
+		many = many.SetFlag(testdata.ManyFlagsF0).SetFlag(testdata.ManyFlagsF69)
+		r.True(many.HasFlag(testdata.ManyFlagsF69))
+		r.False(many.HasFlag(testdata.ManyFlagsF68))
+		r.Equal([]testdata.ManyFlagsFlag{testdata.ManyFlagsF0, testdata.ManyFlagsF69}, many.Flags())
+		r.Equal(new(uint256.Int).Or(uint256.NewInt(1), new(uint256.Int).Lsh(uint256.NewInt(1), 69)), many.Raw())
+
+		row.Set(permissions, many)
+		gotPermissions, gotMany := testdata.NewFlagsTable(ds).Get(1).Get()
+		r.Equal(permissions, gotPermissions)
+		r.Equal(many, gotMany)
+		data := row.GetField_slot(0).Bytes32().Bytes()
+		r.Equal(byte(0b101), data[0])
+		r.Equal(many.Raw().Bytes(), common.TrimLeftZeroes(data[1:10]))
+	})
+
+	t.Run("EnumTable", func(t *testing.T) {
+		r := require.New(t)
+		row := testdata.NewEnumTable(ds).Get(1)
+		r.Equal(testdata.Status(10), testdata.StatusActive)
+		r.Equal(testdata.Status(0xff), testdata.StatusClosed)
+		r.True(testdata.StatusActive.IsValid())
+		r.False(testdata.Status(1).IsValid())
+		r.Equal("Active", testdata.StatusActive.String())
+		r.Equal("Status(1)", testdata.Status(1).String())
+
+		row.Set(testdata.StatusActive, testdata.KindB)
+		status, kind := row.Get()
+		r.Equal(testdata.StatusActive, status)
+		r.Equal(testdata.KindB, kind)
+		r.Equal(byte(10), row.GetField(0)[0])
+
+		// Unknown backing values are clamped to the last value
+		row.SetField(0, []byte{1})
+		r.Equal(testdata.StatusClosed, row.GetStatus())
+	})
+
+	t.Run("FloatTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewFloatTable(ds)
+		table.Get(1).Set(-2.5, 100)
+		price, volume := table.Get(1).Get()
+		r.Equal(-2.5, price)
+		r.Equal(uint64(100), volume)
+
+		table.Get(2).SetPrice(math.Inf(-1))
+		r.True(math.IsInf(table.Get(2).GetPrice(), -1))
+		table.Get(2).SetPrice(math.NaN())
+		r.True(math.IsNaN(table.Get(2).GetPrice()))
+
+		data := table.Get(1).GetField_slot(0).Bytes32()
+		r.Equal(math.Float64bits(-2.5), binary.BigEndian.Uint64(data[:8]))
+	})
+
+	t.Run("OrderedTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewOrderedTable(ds)
+		rows := []testdata.OrderedTableValues{
+			{Name: "b", Score: 1},
+			{Name: "a", Score: 2, Balance: new(uint256.Int).Neg(uint256.NewInt(1)), HasBalance: true},
+			{Name: "a", Score: 2, Balance: uint256.NewInt(1), HasBalance: true},
+			{Name: "a", Score: 2},
+			{Name: "a", Score: 1, Tags: []uint8{1, 2}},
+		}
+		for ii, row := range rows {
+			table.SetRow(uint64(ii), row)
+		}
+		for ii, row := range rows {
+			r.True(row.Equal(table.GetRow(uint64(ii))))
+		}
+
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Less(rows[j]) })
+		r.Equal([]string{"a", "a", "a", "a", "b"}, []string{rows[0].Name, rows[1].Name, rows[2].Name, rows[3].Name, rows[4].Name})
+		r.Equal(uint64(1), rows[0].Score)
+		r.False(rows[1].HasBalance)
+		r.Equal(uint64(1), rows[3].Balance.Uint64())
+
+		// Values that are not ordered still make rows unequal
+		a, b := rows[0], rows[0]
+		b.Tags = []uint8{1, 3}
+		r.Equal(0, a.Compare(b))
+		r.False(a.Equal(b))
+		b.Tags = a.Tags
+		b.Rates[1] = 1
+		r.False(a.Equal(b))
+
+		// Absent optional values are equal whatever their value
+		a, b = rows[1], rows[1]
+		b.Balance = uint256.NewInt(5)
+		r.True(a.Equal(b))
+		b.HasBalance = true
+		r.False(a.Equal(b))
+		r.True(a.Less(b))
+	})
+
+	t.Run("ReservesTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewReservesTable(ds)
+		pool := common.HexToAddress("0x01")
+		row := table.Get(pool)
+
+		// Unset elements are zero, not nil
+		for _, reserve := range row.GetReserves() {
+			r.NotNil(reserve)
+			r.True(reserve.IsZero())
+		}
+
+		var reserves [8]*uint256.Int
+		reserves[0] = uint256.NewInt(1)
+		reserves[7] = new(uint256.Int).Not(uint256.NewInt(0))
+		roots := [2]common.Hash{{1}, {2}}
+		row.Set(30, reserves, roots)
+		fee, gotReserves, gotRoots := row.Get()
+		r.Equal(uint16(30), fee)
+		r.Equal(roots, gotRoots)
+		r.Equal(uint64(1), gotReserves[0].Uint64())
+		r.True(gotReserves[3].IsZero())
+		r.Equal(reserves[7], gotReserves[7])
+		r.Equal(gotReserves, table.GetRow(pool).Reserves)
+
+		// Every element takes its own slot after the slot of the fee
+		slots := row.GetBase_slot().SlotArray([]int{11})
+		r.Equal(codec.EncodeUint16(2, 30), slots.Get(0).Bytes32().Bytes()[:2])
+		for ii, reserve := range reserves {
+			r.Equal(codec.EncodeUint256(32, reserve), slots.Get(1+ii).Bytes32().Bytes())
+		}
+		r.Equal(roots[1], slots.Get(10).Bytes32())
+	})
+
+	t.Run("LabelTable", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewLabelTable(ds)
+		row := table.Get("usdc")
+		row.Set("USD Coin", "USDC", 6)
+		label, symbol, decimals := table.Get("usdc").Get()
+		r.Equal("USD Coin", label)
+		r.Equal("USDC", symbol)
+		r.Equal(uint8(6), decimals)
+		r.False(table.Has("usd"))
+
+		// Short strings are stored inline, right padded in a single slot
+		data := row.GetField_slot(0).Bytes32()
+		r.Equal(common.RightPadBytes([]byte("USD Coin"), 32), data[:])
+		r.Panics(func() { row.SetSymbol("USDC.e") })
+		r.Equal("USDC", row.GetSymbol())
+	})
+
+	t.Run("StorageLayout", func(t *testing.T) {
+		r := require.New(t)
+		keys := [][]byte{
+			codec.EncodeUint256(32, uintVal),
+			codec.EncodeString(32, stringVal),
+			codec.EncodeBytes(32, bytesVal),
+			codec.EncodeBool(1, boolVal),
+			codec.EncodeAddress(20, addrVal),
+			codec.EncodeFixedBytes(16, bytes16Val),
+		}
+		keyedBase := storage.TableSlot("KeyedTable")
+		r.Equal(common.BytesToHash(testdata.KeyedTableDefaultKey()), keyedBase)
+		keyedRow := testdata.NewKeyedTable(ds).Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val)
+		r.Equal(storage.TableRowSlot(keyedBase, keys...), keyedRow.GetBase_slot().Slot())
+		slot, _ := storage.FieldSlot(keyedRow.GetBase_slot().Slot(), storage.FieldOffsets([]int{32, 32, 32, 1, 20, 16})[4])
+		r.Equal(slot, keyedRow.GetField_slot(4).Slot())
+
+		owner, spender := common.Address{0x01}, common.Address{0x02}
+		compositeRow := testdata.NewCompositeKeyTable(ds).Get(owner, spender)
+		r.Equal(storage.CompositeRowSlot(storage.TableSlot("CompositeKeyTable"), owner.Bytes(), spender.Bytes()), compositeRow.GetBase_slot().Slot())
+
+		pool := testdata.NewPoolTable(ds).Get(7)
+		poolRow := storage.TableRowSlot(storage.TableSlot("PoolTable"), codec.EncodeUint64(8, 7))
+		r.Equal(poolRow, pool.GetBase_slot().Slot())
+		r.Equal(storage.NestedTableSlot(poolRow, 2), pool.GetSettings().Get().GetBase_slot().Slot())
+		r.Equal(storage.TableRowSlot(storage.NestedTableSlot(poolRow, 1), keys...), pool.GetHolders().Get(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val).GetBase_slot().Slot())
+
+		// Tables with a base slot offset are stored that many slots further
+		offsetBase := storage.OffsetSlot(storage.TableSlot("OffsetTable"), 16)
+		r.Equal(common.BytesToHash(testdata.OffsetTableDefaultKey()), offsetBase)
+		r.Equal(storage.TableRowSlot(offsetBase, codec.EncodeUint64(8, 7)), testdata.NewOffsetTable(ds).Get(7).GetBase_slot().Slot())
+
+		// Small values of keyed rows share slots, read as in solidity by
+		// shifting the slot right
+		label := testdata.NewLabelTable(ds).Get("USDC")
+		label.SetSymbol("USD")
+		label.SetDecimals(6)
+		offsets := storage.FieldOffsets([]int{32, 4, 1})
+		symbolSlot, symbolOffset := storage.FieldSlot(storage.TableRowSlot(storage.TableSlot("LabelTable"), codec.EncodeFixedString(8, "USDC")), offsets[1])
+		decimalsSlot, decimalsOffset := storage.FieldSlot(label.GetBase_slot().Slot(), offsets[2])
+		r.Equal(symbolSlot, decimalsSlot)
+		word := new(uint256.Int).SetBytes(ds.Get(symbolSlot.Bytes()).Bytes32().Bytes())
+		r.Equal(uint64(6), new(uint256.Int).Rsh(word, uint(storage.FieldShift(decimalsOffset, 1))).Uint64()&0xff)
+		r.Equal(uint64(0x55534400), new(uint256.Int).Rsh(word, uint(storage.FieldShift(symbolOffset, 4))).Uint64()&0xffffffff)
+	})
+
 	t.Run("keylessWithKeylessTable", func(t *testing.T) {
-		row := testdata.NewKeylessWithKeylessTableValue(ds)
+		row := testdata.NewKeylessWithKeylessTableValue(ds).Get()
 		testRow(t, func() testRowInterface {
-			return row.GetValueTable()
+			return row.ValueTable().Get()
 		})
 	})
 }
+
+// abiBytes returns the ABI encoding of a single bytes value as log data.
+func abiBytes(data []byte) []byte {
+	enc := common.LeftPadBytes([]byte{0x20}, 32)
+	enc = append(enc, common.LeftPadBytes(big.NewInt(int64(len(data))).Bytes(), 32)...)
+	return append(enc, common.RightPadBytes(data, (len(data)+31)/32*32)...)
+}
+
+func TestEmitTables(t *testing.T) {
+	var (
+		addr     = common.HexToAddress("0x1234567890123456789012345678901234567890")
+		contract = api.NewContract(common.Address{}, common.Address{}, addr, new(uint256.Int))
+		statedb  = mock.NewMockStateDB().(*state.StateDB)
+		env      = api.NewEnvironment(api.EnvConfig{}, false, statedb, api.NewMockBlockContext(), api.NewMockCaller(), contract)
+		owner    = common.HexToAddress("0xc0de")
+	)
+	rowUpdated := crypto.Keccak256Hash([]byte(DefaultEmitSignature))
+	key := crypto.Keccak256Hash(owner.Bytes(), []byte{0x00, 0x00, 0x00, 0x07})
+	logCount := 0
+	newLogs := func() []*types.Log {
+		logs := statedb.Logs()[logCount:]
+		logCount += len(logs)
+		return logs
+	}
+
+	t.Run("WithoutEvents", func(t *testing.T) {
+		r := require.New(t)
+		testdata.NewEmitTable(lib.NewDatastore(env)).Get(owner, 7).SetBalance(1)
+		r.Empty(newLogs())
+	})
+
+	t.Run("SetValue", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewEmitTableWithEvents(env)
+		table.Get(owner, 7).SetBalance(2)
+		r.Equal(uint64(2), table.Get(owner, 7).GetBalance())
+		logs := newLogs()
+		r.Len(logs, 1)
+		r.Equal(addr, logs[0].Address)
+		r.Equal([]common.Hash{rowUpdated, key, common.BigToHash(big.NewInt(0))}, logs[0].Topics)
+		r.Equal(abiBytes(codec.EncodeUint[uint64](8, 2)), logs[0].Data)
+	})
+
+	t.Run("SetRow", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewEmitTableWithEvents(env)
+		table.SetRow(owner, 7, testdata.EmitTableValues{Balance: 3, Note: "note", Limit: 4, HasLimit: true, Pair: [2]uint16{5, 6}})
+		logs := newLogs()
+		r.Len(logs, 4)
+		for ii, log := range logs {
+			r.Equal(common.BigToHash(big.NewInt(int64(ii))), log.Topics[2])
+		}
+		r.Equal(abiBytes([]byte("note")), logs[1].Data)
+		r.Equal(abiBytes(codec.EncodeUint[uint64](8, 4)), logs[2].Data)
+		r.Equal(abiBytes([]byte{0x00, 0x05, 0x00, 0x06}), logs[3].Data)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := require.New(t)
+		testdata.NewEmitTableWithEvents(env).Delete(owner, 7)
+		logs := newLogs()
+		r.Len(logs, 4)
+		r.Equal(abiBytes(make([]byte, 8)), logs[0].Data)
+		r.Equal(abiBytes(nil), logs[1].Data)
+	})
+
+	t.Run("CustomSignature", func(t *testing.T) {
+		r := require.New(t)
+		testdata.NewEmitKeylessTableWithEvents(env).Get().SetAdmin(owner)
+		logs := newLogs()
+		r.Len(logs, 1)
+		r.Equal("ConfigChanged(bytes32,uint256,bytes)", testdata.EmitKeylessTableEvent.Signature())
+		r.Equal([]common.Hash{
+			crypto.Keccak256Hash([]byte("ConfigChanged(bytes32,uint256,bytes)")),
+			{},
+			common.BigToHash(big.NewInt(1)),
+		}, logs[0].Topics)
+		r.Equal(abiBytes(owner.Bytes()), logs[0].Data)
+	})
+}
+
+func TestMemoryTables(t *testing.T) {
+	contract := api.NewContract(common.Address{}, common.Address{}, common.Address{}, new(uint256.Int))
+	ds := lib.NewDatastore(mock.NewMockEnvironment(api.EnvConfig{}, false, contract))
+
+	// Storage and memory tables behave the same through their store
+	for name, store := range map[string]testdata.DefaultsTableRowStore{
+		"storage": testdata.NewDefaultsTable(ds),
+		"memory":  testdata.NewMemoryDefaultsTable(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			r.False(store.Has(1))
+			r.Equal(uint16(30), store.GetRow(1).Fee)
+			r.Equal(uint256.MustFromDecimal("1000000000000000000000"), store.GetRow(1).Limit)
+
+			row := testdata.DefaultsTableValues{Fee: 5, Enabled: true, Limit: uint256.NewInt(1), Offset: uint256.NewInt(2), Note: "note", Plain: 3}
+			store.SetRow(1, row)
+			r.True(store.Has(1))
+			r.False(store.Has(2))
+			r.True(row.Equal(store.GetRow(1)))
+
+			store.Delete(1)
+			r.False(store.Has(1))
+			r.Equal(uint16(30), store.GetRow(1).Fee)
+		})
+	}
+
+	t.Run("keys", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewMemoryKeyedTable()
+		row := testdata.KeyedTableValues{ValueUint: uintVal, ValueString: stringVal}
+		table.SetRow(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val, row)
+		r.True(table.Has(uint256.NewInt(1), stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+		r.False(table.Has(uintVal, stringVal, []byte("other"), boolVal, addrVal, bytes16Val))
+		r.False(table.Has(uintVal, stringVal+string(bytesVal), nil, boolVal, addrVal, bytes16Val))
+		r.Equal(row, table.GetRow(uintVal, stringVal, bytesVal, boolVal, addrVal, bytes16Val))
+	})
+
+	t.Run("keyless", func(t *testing.T) {
+		r := require.New(t)
+		table := testdata.NewMemoryKeylessTable()
+		r.False(table.Has())
+		r.Equal(testdata.KeylessTableValues{}, table.GetRow())
+		table.SetRow(testdata.KeylessTableValues{ValueBool: true})
+		r.True(table.Has())
+		r.True(table.GetRow().ValueBool)
+		table.Delete()
+		r.False(table.Has())
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var defaultAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+default:"([^"]*)"$`)
+
+// splitDefaultAnnotation splits a value type into its type and the literal of
+// its default value, if any, e.g. `uint16 default:"30"`.
+func splitDefaultAnnotation(typeStr string) (string, string, bool) {
+	matches := defaultAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, "", false
+	}
+	return matches[1], matches[2], true
+}
+
+// defaultExpr parses the literal of a default value according to the go type
+// of the field and returns the go expression of the value. Integers must fit
+// in the width of the field.
+func defaultExpr(fieldType FieldType, literal string) (string, error) {
+	if fieldType.Type != ValueType || fieldType.Elem != nil || fieldType.Enum != nil || fieldType.Struct != nil || fieldType.Fixed != nil || fieldType.Flags != nil || fieldType.GoTypeOverride != nil {
+		return "", fmt.Errorf("default values are only supported for integers, booleans and addresses")
+	}
+	switch {
+	case fieldType.Name == "bool":
+		if literal != "true" && literal != "false" {
+			return "", fmt.Errorf("invalid bool default %q", literal)
+		}
+		return literal, nil
+	case fieldType.Name == "address":
+		if !common.IsHexAddress(literal) {
+			return "", fmt.Errorf("invalid address default %q", literal)
+		}
+		return fmt.Sprintf("common.HexToAddress(\"%s\")", common.HexToAddress(literal).Hex()), nil
+	case integerTypeRegexp.MatchString(fieldType.Name):
+		return integerDefaultExpr(fieldType, literal)
+	default:
+		return "", fmt.Errorf("default values are only supported for integers, booleans and addresses")
+	}
+}
+
+func integerDefaultExpr(fieldType FieldType, literal string) (string, error) {
+	value, ok := new(big.Int).SetString(literal, 0)
+	if !ok {
+		return "", fmt.Errorf("invalid integer default %q", literal)
+	}
+	bits := uint(fieldType.Size * 8)
+	signed := strings.HasPrefix(fieldType.Name, "int")
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), bits)
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	max.Sub(max, big.NewInt(1))
+	if value.Cmp(min) < 0 || value.Cmp(max) > 0 {
+		return "", fmt.Errorf("default %s out of range for %s, expected %s to %s", literal, fieldType.Name, min, max)
+	}
+	if fieldType.BigInt {
+		if value.IsInt64() {
+			return fmt.Sprintf("big.NewInt(%s)", value), nil
+		}
+		expr := fmt.Sprintf("uint256.MustFromDecimal(\"%s\").ToBig()", new(big.Int).Abs(value))
+		if value.Sign() < 0 {
+			expr = fmt.Sprintf("new(big.Int).Neg(%s)", expr)
+		}
+		return expr, nil
+	}
+	if fieldType.GoType != "*uint256.Int" {
+		return value.String(), nil
+	}
+	abs := new(big.Int).Abs(value)
+	expr := fmt.Sprintf("uint256.MustFromDecimal(\"%s\")", abs)
+	if abs.IsUint64() {
+		expr = fmt.Sprintf("uint256.NewInt(%s)", abs)
+	}
+	if value.Sign() < 0 {
+		// Wide signed integers are decoded sign extended to 256 bits
+		expr = fmt.Sprintf("new(uint256.Int).Neg(%s)", expr)
+	}
+	return expr, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDefaultAnnotation(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, literal, ok := splitDefaultAnnotation(`uint16 default:"30"`)
+	r.True(ok)
+	r.Equal("uint16", typeStr)
+	r.Equal("30", literal)
+
+	typeStr, literal, ok = splitDefaultAnnotation(`uint64 gotype:"example.com/x.ID" default:""`)
+	r.True(ok)
+	r.Equal(`uint64 gotype:"example.com/x.ID"`, typeStr)
+	r.Equal("", literal)
+
+	typeStr, _, ok = splitDefaultAnnotation("uint16")
+	r.False(ok)
+	r.Equal("uint16", typeStr)
+}
+
+func TestDefaultExpr(t *testing.T) {
+	r := require.New(t)
+
+	for _, test := range []struct {
+		typeStr string
+		literal string
+		expr    string
+	}{
+		{"uint16", "30", "30"},
+		{"uint8", "0xff", "255"},
+		{"int8", "-128", "-128"},
+		{"int64", "-1", "-1"},
+		{"uint16 endian:\"little\"", "30", "30"},
+		{"uint128", "340282366920938463463374607431768211455", "uint256.MustFromDecimal(\"340282366920938463463374607431768211455\")"},
+		{"uint256", "7", "uint256.NewInt(7)"},
+		{"int128", "-5", "new(uint256.Int).Neg(uint256.NewInt(5))"},
+		{"int256 @bigint", "-5", "big.NewInt(-5)"},
+		{"int128 @bigint", "-170141183460469231731687303715884105728", "new(big.Int).Neg(uint256.MustFromDecimal(\"170141183460469231731687303715884105728\").ToBig())"},
+		{"bool", "false", "false"},
+		{"address", "0x000000000000000000000000000000000000c0de", "common.HexToAddress(\"0x000000000000000000000000000000000000c0DE\")"},
+	} {
+		field, err := newFieldSchema("a", 0, test.typeStr)
+		r.NoError(err)
+		expr, err := defaultExpr(field.Type, test.literal)
+		r.NoError(err, test.typeStr)
+		r.Equal(test.expr, expr, test.typeStr)
+	}
+
+	for _, test := range []struct {
+		typeStr string
+		literal string
+	}{
+		{"uint8", "256"},
+		{"uint16", "-1"},
+		{"int8", "128"},
+		{"int8", "-129"},
+		{"uint24", "16777216"},
+		{"int128", "170141183460469231731687303715884105728"},
+		{"uint128 @bigint", "-1"},
+		{"uint64", "thirty"},
+		{"uint64", ""},
+		{"bool", "1"},
+		{"address", "0xc0de"},
+		{"string", "x"},
+		{"bytes4", "0x01"},
+		{"uint64[2]", "0"},
+		{"enum kind {a, b}", "a"},
+		{"int64 gotype:\"time.Duration\"", "0"},
+	} {
+		field, err := newFieldSchema("a", 0, test.typeStr)
+		r.NoError(err, test.typeStr)
+		_, err = defaultExpr(field.Type, test.literal)
+		r.Error(err, "%s %s", test.typeStr, test.literal)
+	}
+}
@@ -16,28 +16,696 @@
 package datamod
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+// Errors returned when parsing field types, wrapped with the name or size
+// that is invalid, e.g. "invalid integer size 7", so they can be matched with
+// errors.Is.
+var (
+	ErrUnknownFieldType          = errors.New("unknown field type")
+	ErrInvalidIntSize            = errors.New("invalid integer size")
+	ErrInvalidBytesSize          = errors.New("invalid bytes size")
+	ErrInvalidStringSize         = errors.New("invalid string size")
+	ErrInvalidArrayLength        = errors.New("invalid array length")
+	ErrNestedArray               = errors.New("nested arrays are not supported")
+	ErrInvalidFixedPointSize     = errors.New("invalid fixed-point size")
+	ErrInvalidFixedPointDecimals = errors.New("invalid fixed-point decimals")
+	ErrInvalidTableName          = errors.New("invalid table name")
+)
+
 const (
 	ValueType = iota
 	BytesType
 	TableType
+	DynamicArrayType
 )
 
 type FieldType struct {
-	Name       string
-	Type       int
-	Size       int
-	GoType     string
-	SolType    string
+	Name    string
+	Type    int
+	Size    int
+	GoType  string
+	SolType string
+	// Package qualified codec functions, e.g. codec.EncodeAddress
 	EncodeFunc string
 	DecodeFunc string
+	// Fixed-size arrays
+	ArrayLength int
+	Elem        *FieldType
+	// Enums
+	Enum *EnumSchema
+	// Custom go types
+	GoTypeOverride *GoTypeOverride
+	// Structs
+	Struct *StructSchema
+	// Fixed-point decimals
+	Fixed *FixedSchema
+	// Bit flags
+	Flags *FlagsSchema
+	// Integers stored least significant byte first
+	LittleEndian bool
+	// Integers wider than 64 bits represented as *big.Int instead of
+	// *uint256.Int
+	BigInt bool
+	// Unsigned integers declared as counters, with generated accessors
+	// incrementing them
+	Counter bool
+	// Fixed bytes padded on the left instead of the right
+	RightAligned bool
+}
+
+type EnumSchema struct {
+	Name   string
+	Values []string
+	// Numbers are the backing values of the values, declared as
+	// `enum Name {A = 1, B = 0x10}`, or nil if the values are numbered in
+	// declaration order from zero.
+	Numbers []int
+}
+
+func (e *EnumSchema) ConstName(value string) string {
+	return e.Name + upperFirstLetter(value)
+}
+
+// Explicit reports whether the values of the enum have declared backing
+// values.
+func (e *EnumSchema) Explicit() bool {
+	return e.Numbers != nil
+}
+
+// Number returns the backing value of the value at index.
+func (e *EnumSchema) Number(index int) int {
+	if e.Numbers == nil {
+		return index
+	}
+	return e.Numbers[index]
+}
+
+func (e *EnumSchema) Last() string {
+	return e.ConstName(e.Values[len(e.Values)-1])
+}
+
+func (e *EnumSchema) Equal(other *EnumSchema) bool {
+	if e.Name != other.Name || len(e.Values) != len(other.Values) {
+		return false
+	}
+	for ii := range e.Values {
+		if e.Values[ii] != other.Values[ii] || e.Number(ii) != other.Number(ii) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseEnumNumber parses the backing value of an enum value, a decimal or
+// 0x prefixed hexadecimal literal fitting in a uint8.
+func parseEnumNumber(literal string) (int, bool) {
+	base := 10
+	if strings.HasPrefix(literal, "0x") || strings.HasPrefix(literal, "0X") {
+		literal, base = literal[2:], 16
+	}
+	number, err := strconv.ParseUint(literal, base, 8)
+	if err != nil {
+		return 0, false
+	}
+	return int(number), true
+}
+
+var enumTypeRegexp = regexp.MustCompile(`^enum\s+([^\s{]+)\s*\{(.*)\}$`)
+
+func enumFieldType(name string) (FieldType, error) {
+	matches := enumTypeRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return FieldType{}, fmt.Errorf("invalid enum type %s, expected 'enum Name {A, B, ...}'", name)
+	}
+	enumName, valuesStr := matches[1], matches[2]
+	if !isValidName(enumName) {
+		return FieldType{}, fmt.Errorf("invalid enum name %s", enumName)
+	}
+	enum := &EnumSchema{Name: formatTableName(enumName)}
+	seen := make(map[string]bool)
+	// Values without a backing value follow the previous one, as in go and c
+	var numbers []int
+	explicit := false
+	numberSeen := make(map[int]string)
+	next := 0
+	for _, value := range strings.Split(valuesStr, ",") {
+		value, literal, hasNumber := strings.Cut(value, "=")
+		value = strings.TrimSpace(value)
+		if !isValidName(value) {
+			return FieldType{}, fmt.Errorf("invalid value '%s' for enum %s", value, enumName)
+		}
+		if seen[upperFirstLetter(value)] {
+			return FieldType{}, fmt.Errorf("duplicate value '%s' for enum %s", value, enumName)
+		}
+		seen[upperFirstLetter(value)] = true
+		number := next
+		if hasNumber {
+			literal = strings.TrimSpace(literal)
+			var ok bool
+			if number, ok = parseEnumNumber(literal); !ok {
+				return FieldType{}, fmt.Errorf("invalid backing value '%s' of '%s' for enum %s, expected a decimal or hex literal between 0 and 255", literal, value, enumName)
+			}
+			explicit = true
+		}
+		if other, ok := numberSeen[number]; ok {
+			return FieldType{}, fmt.Errorf("duplicate backing value %d of '%s' and '%s' for enum %s", number, other, value, enumName)
+		}
+		numberSeen[number] = value
+		numbers = append(numbers, number)
+		next = number + 1
+		enum.Values = append(enum.Values, value)
+	}
+	if len(enum.Values) > 256 {
+		return FieldType{}, fmt.Errorf("too many values for enum %s, at most 256 are allowed", enumName)
+	}
+	if explicit {
+		for ii, number := range numbers {
+			if number > 255 {
+				return FieldType{}, fmt.Errorf("backing value of '%s' for enum %s is out of range, enums are stored as uint8", enum.Values[ii], enumName)
+			}
+		}
+		enum.Numbers = numbers
+	}
+	return FieldType{
+		Name:       enum.Name,
+		Type:       ValueType,
+		Size:       1,
+		GoType:     enum.Name,
+		SolType:    "uint8",
+		EncodeFunc: "encode" + enum.Name,
+		DecodeFunc: "decode" + enum.Name,
+		Enum:       enum,
+	}, nil
+}
+
+// FlagsSchema is a set of named boolean flags packed into a single integer,
+// with one bit per flag in declaration order starting from the least
+// significant bit.
+type FlagsSchema struct {
+	Name   string
+	Values []string
+}
+
+func (f *FlagsSchema) FlagName() string {
+	return f.Name + "Flag"
+}
+
+func (f *FlagsSchema) ConstName(value string) string {
+	return f.Name + upperFirstLetter(value)
+}
+
+// Size returns the number of bytes the flags are stored in, i.e. the size of
+// the smallest go integer type able to hold them, or the smallest number of
+// bytes for more than 64 flags.
+func (f *FlagsSchema) Size() int {
+	for _, size := range []int{1, 2, 4, 8} {
+		if len(f.Values) <= size*8 {
+			return size
+		}
+	}
+	return (len(f.Values) + 7) / 8
+}
+
+// IsArray reports whether the flags are too many for a go integer type and
+// are represented as a byte array instead.
+func (f *FlagsSchema) IsArray() bool {
+	return f.Size() > 8
+}
+
+func (f *FlagsSchema) Bits() int {
+	return f.Size() * 8
+}
+
+// GoBaseType returns the underlying go type of the flags.
+func (f *FlagsSchema) GoBaseType() string {
+	if f.IsArray() {
+		return fmt.Sprintf("[%d]byte", f.Size())
+	}
+	return fmt.Sprintf("uint%d", f.Bits())
+}
+
+func (f *FlagsSchema) Equal(other *FlagsSchema) bool {
+	if f.Name != other.Name || len(f.Values) != len(other.Values) {
+		return false
+	}
+	for ii := range f.Values {
+		if f.Values[ii] != other.Values[ii] {
+			return false
+		}
+	}
+	return true
+}
+
+var flagsTypeRegexp = regexp.MustCompile(`^flags\s+([^\s{]+)\s*\{(.*)\}$`)
+
+func flagsFieldType(name string) (FieldType, error) {
+	matches := flagsTypeRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return FieldType{}, fmt.Errorf("invalid flags type %s, expected 'flags Name {A, B, ...}'", name)
+	}
+	flagsName, valuesStr := matches[1], matches[2]
+	if !isValidName(flagsName) {
+		return FieldType{}, fmt.Errorf("invalid flags name %s", flagsName)
+	}
+	flags := &FlagsSchema{Name: formatTableName(flagsName)}
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(valuesStr, ",") {
+		value = strings.TrimSpace(value)
+		if !isValidName(value) {
+			return FieldType{}, fmt.Errorf("invalid flag '%s' for flags %s", value, flagsName)
+		}
+		if seen[upperFirstLetter(value)] {
+			return FieldType{}, fmt.Errorf("duplicate flag '%s' for flags %s", value, flagsName)
+		}
+		seen[upperFirstLetter(value)] = true
+		flags.Values = append(flags.Values, value)
+	}
+	if len(flags.Values) > 256 {
+		return FieldType{}, fmt.Errorf("too many flags for flags %s, at most 256 are allowed", flagsName)
+	}
+	return FieldType{
+		Name:       flags.Name,
+		Type:       ValueType,
+		Size:       flags.Size(),
+		GoType:     flags.Name,
+		SolType:    fmt.Sprintf("uint%d", flags.Bits()),
+		EncodeFunc: "encode" + flags.Name,
+		DecodeFunc: "decode" + flags.Name,
+		Flags:      flags,
+	}, nil
+}
+
+type StructMember struct {
+	Name   string
+	Title  string
+	Offset int
+	Type   FieldType
+}
+
+type StructSchema struct {
+	Name    string
+	Members []StructMember
+	Size    int
+}
+
+func (s *StructSchema) Equal(other *StructSchema) bool {
+	if s.Name != other.Name || len(s.Members) != len(other.Members) {
+		return false
+	}
+	for ii := range s.Members {
+		a, b := s.Members[ii], other.Members[ii]
+		if a.Name != b.Name || a.Type.Name != b.Type.Name || a.Type.GoType != b.Type.GoType {
+			return false
+		}
+		if (a.Type.Struct == nil) != (b.Type.Struct == nil) {
+			return false
+		}
+		if a.Type.Struct != nil && !a.Type.Struct.Equal(b.Type.Struct) {
+			return false
+		}
+	}
+	return true
+}
+
+var structTypeRegexp = regexp.MustCompile(`^struct\s+([^\s{]+)\s*\{(.*)\}$`)
+
+// splitStructMembers splits struct members on semicolons outside of nested
+// braces.
+func splitStructMembers(membersStr string) ([]string, error) {
+	var (
+		members []string
+		depth   int
+		start   int
+	)
+	for ii, c := range membersStr {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced braces")
+			}
+		case ';':
+			if depth == 0 {
+				members = append(members, membersStr[start:ii])
+				start = ii + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced braces")
+	}
+	members = append(members, membersStr[start:])
+	return members, nil
+}
+
+func structFieldType(name string) (FieldType, error) {
+	matches := structTypeRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return FieldType{}, fmt.Errorf("invalid struct type %s, expected 'struct Name { a type; b type; ... }'", name)
+	}
+	structName, membersStr := matches[1], matches[2]
+	if !isValidName(structName) {
+		return FieldType{}, fmt.Errorf("invalid struct name %s", structName)
+	}
+	memberStrs, err := splitStructMembers(membersStr)
+	if err != nil {
+		return FieldType{}, fmt.Errorf("invalid struct %s: %w", structName, err)
+	}
+	schema := &StructSchema{Name: formatTableName(structName)}
+	seen := make(map[string]bool)
+	for _, memberStr := range memberStrs {
+		memberStr = strings.TrimSpace(memberStr)
+		if memberStr == "" {
+			continue
+		}
+		parts := strings.SplitN(memberStr, " ", 2)
+		if len(parts) != 2 {
+			return FieldType{}, fmt.Errorf("invalid member '%s' for struct %s, expected 'name type'", memberStr, structName)
+		}
+		memberName, memberTypeStr := parts[0], strings.TrimSpace(parts[1])
+		if !isValidName(memberName) {
+			return FieldType{}, fmt.Errorf("invalid member name '%s' for struct %s", memberName, structName)
+		}
+		if seen[upperFirstLetter(memberName)] {
+			return FieldType{}, fmt.Errorf("duplicate member '%s' for struct %s", memberName, structName)
+		}
+		seen[upperFirstLetter(memberName)] = true
+		memberType, err := nameToFieldType(memberTypeStr)
+		if err != nil {
+			return FieldType{}, fmt.Errorf("invalid type for member '%s' of struct %s: %w", memberName, structName, err)
+		}
+		if memberType.Type != ValueType || memberType.Elem != nil {
+			return FieldType{}, fmt.Errorf("invalid type for member '%s' of struct %s, only value types are supported", memberName, structName)
+		}
+		if memberType.Struct != nil {
+			for _, nested := range memberType.Struct.Members {
+				if nested.Type.Struct != nil {
+					return FieldType{}, fmt.Errorf("invalid type for member '%s' of struct %s, structs can only be nested one level deep", memberName, structName)
+				}
+			}
+		}
+		schema.Members = append(schema.Members, StructMember{
+			Name:   lowerFirstLetter(memberName),
+			Title:  upperFirstLetter(memberName),
+			Offset: schema.Size,
+			Type:   memberType,
+		})
+		schema.Size += memberType.Size
+	}
+	if len(schema.Members) == 0 {
+		return FieldType{}, fmt.Errorf("struct %s has no members", structName)
+	}
+	return FieldType{
+		Name:       schema.Name,
+		Type:       ValueType,
+		Size:       schema.Size,
+		GoType:     schema.Name,
+		SolType:    schema.Name,
+		EncodeFunc: "encode" + schema.Name,
+		DecodeFunc: "decode" + schema.Name,
+		Struct:     schema,
+	}, nil
+}
+
+// FixedSchema describes a fixed-point decimal type such as ufixed128x18, stored
+// as an integer of Bits bits scaled by 10^Decimals.
+type FixedSchema struct {
+	Name     string
+	Bits     int
+	Decimals int
+	Signed   bool
+}
+
+// IntType returns the solidity integer type the decimal is stored as.
+func (f *FixedSchema) IntType() string {
+	if f.Signed {
+		return fmt.Sprintf("int%d", f.Bits)
+	}
+	return fmt.Sprintf("uint%d", f.Bits)
+}
+
+var fixedTypeRegexp = regexp.MustCompile(`^(u?)fixed(?:([0-9]+)x([0-9]+))?$`)
+
+func fixedFieldType(name string) (FieldType, error) {
+	matches := fixedTypeRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return FieldType{}, fmt.Errorf("invalid fixed-point type %s, expected 'fixedMxN' or 'ufixedMxN'", name)
+	}
+	schema := &FixedSchema{Signed: matches[1] == "", Bits: 128, Decimals: 18}
+	if matches[2] != "" {
+		var err error
+		if schema.Bits, err = strconv.Atoi(matches[2]); err != nil {
+			return FieldType{}, err
+		}
+		if schema.Decimals, err = strconv.Atoi(matches[3]); err != nil {
+			return FieldType{}, err
+		}
+	}
+	// Same rules as solidity: M must be a multiple of 8 between 8 and 256 and
+	// N must be between 0 and 80
+	if schema.Bits < 8 || schema.Bits > 256 || schema.Bits%8 != 0 {
+		return FieldType{}, fmt.Errorf("%w %d, must be a multiple of 8 between 8 and 256", ErrInvalidFixedPointSize, schema.Bits)
+	}
+	if schema.Decimals > 80 {
+		return FieldType{}, fmt.Errorf("%w %d, must be between 0 and 80", ErrInvalidFixedPointDecimals, schema.Decimals)
+	}
+	schema.Name = fmt.Sprintf("%sfixed%dx%d", matches[1], schema.Bits, schema.Decimals)
+	goType := upperFirstLetter(schema.Name)
+	return FieldType{
+		Name:       schema.Name,
+		Type:       ValueType,
+		Size:       schema.Bits / 8,
+		GoType:     goType,
+		SolType:    schema.IntType(),
+		EncodeFunc: "encode" + goType,
+		DecodeFunc: "decode" + goType,
+		Fixed:      schema,
+	}, nil
+}
+
+var counterTypeRegexp = regexp.MustCompile(`^counter([0-9]*)$`)
+
+// counterFieldType returns the field type of a counter such as counter64,
+// stored as an unsigned integer of the same width, 256 bits by default.
+func counterFieldType(name string) (FieldType, error) {
+	matches := counterTypeRegexp.FindStringSubmatch(name)
+	fieldType, err := nameToFieldType("uint" + matches[1])
+	if err != nil {
+		return FieldType{}, err
+	}
+	fieldType.Counter = true
+	return fieldType, nil
+}
+
+// SolArgType returns the solidity type of the field when used as a function
+// argument or return value, including the data location for reference types.
+func (t FieldType) SolArgType() string {
+	if t.Type != ValueType || t.Elem != nil || t.Struct != nil {
+		return t.SolType + " memory"
+	}
+	return t.SolDeclType()
+}
+
+// SolDeclType returns the solidity type of the field in a declaration. It is
+// the same as SolType except for function pointers, which are declared as
+// external functions without arguments.
+func (t FieldType) SolDeclType() string {
+	if t.Name == "function" {
+		return "function() external"
+	}
+	return t.SolType
+}
+
+// PackedEncodeExpr returns a go expression encoding value as solidity does
+// with abi.encodePacked, i.e. without padding and big-endian whatever the
+// byte order the field is stored with.
+func (t FieldType) PackedEncodeExpr(value string) string {
+	if !t.LittleEndian || t.Size == 1 {
+		return fmt.Sprintf("%s(%d, %s)", t.EncodeFunc, t.Size, value)
+	}
+	base := t
+	if t.GoTypeOverride != nil {
+		base = t.GoTypeOverride.Base
+		value = fmt.Sprintf("%s(%s)", base.GoType, value)
+	}
+	bigEndian, err := nameToFieldType(base.Name)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%s(%d, %s)", bigEndian.EncodeFunc, t.Size, value)
+}
+
+// PaddedEncodeExpr returns a go expression encoding value as the 32-byte word
+// of its ABI encoding, as solidity does for the keys of mappings: integers are
+// left padded, with ones for negative signed integers, fixed bytes and
+// function pointers are right padded, and dynamic values are not padded.
+func (t FieldType) PaddedEncodeExpr(value string) string {
+	expr := t.PackedEncodeExpr(value)
+	switch {
+	case t.Type == BytesType:
+		return expr
+	case strings.HasPrefix(t.SolType, "bytes") || t.SolType == "function":
+		return fmt.Sprintf("common.RightPadBytes(%s, 32)", expr)
+	case strings.HasPrefix(t.SolType, "int"):
+		return fmt.Sprintf("codec.SignExtendWord(%s)", expr)
+	default:
+		return fmt.Sprintf("common.LeftPadBytes(%s, 32)", expr)
+	}
+}
+
+// CheckedFunc returns the package qualified codec function computing op, Add
+// or Sub, with overflow checks on values of the field, or an empty string if
+// the field is not a plain integer.
+func (t FieldType) CheckedFunc(op string) string {
+	if t.Type != ValueType || t.Elem != nil || t.GoTypeOverride != nil || !integerTypeRegexp.MatchString(t.Name) {
+		return ""
+	}
+	sign := "Uint"
+	if strings.HasPrefix(t.Name, "int") {
+		sign = "Int"
+	}
+	if t.BigInt {
+		return fmt.Sprintf("codec.%s%sBig", op, sign)
+	}
+	if t.Size > 8 {
+		return fmt.Sprintf("codec.%sBig%s", op, sign)
+	}
+	return fmt.Sprintf("codec.%s%s[%s]", op, sign, t.GoType)
+}
+
+// OneExpr returns the go expression of the integer one in the go type of the
+// field, e.g. to increment counters.
+func (t FieldType) OneExpr() string {
+	switch t.GoType {
+	case "*uint256.Int":
+		return "uint256.NewInt(1)"
+	case "*big.Int":
+		return "big.NewInt(1)"
+	default:
+		return "1"
+	}
+}
+
+// IsCBOR reports whether the field holds a go value marshalled as cbor.
+func (t FieldType) IsCBOR() bool {
+	return t.Name == "cbor"
+}
+
+// usesBig reports whether the go type of the field refers to the math/big
+// package.
+func (t FieldType) usesBig() bool {
+	return t.BigInt
+}
+
+// usesTime reports whether the go type of the field refers to the time package.
+func (t FieldType) usesTime() bool {
+	if t.Name == "timestamp" || t.Name == "duration" {
+		return true
+	}
+	if t.Elem != nil {
+		return t.Elem.usesTime()
+	}
+	if t.Struct != nil {
+		for _, member := range t.Struct.Members {
+			if member.Type.usesTime() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var arrayTypeRegexp = regexp.MustCompile(`^(.+)\[([0-9]+)\]$`)
+
+func arrayFieldType(name string) (FieldType, error) {
+	matches := arrayTypeRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return FieldType{}, fmt.Errorf("invalid array type %s", name)
+	}
+	elemName, lengthStr := matches[1], matches[2]
+	length, err := strconv.Atoi(lengthStr)
+	if err != nil {
+		return FieldType{}, err
+	}
+	if length < 1 {
+		return FieldType{}, fmt.Errorf("%w %d", ErrInvalidArrayLength, length)
+	}
+	if strings.HasSuffix(elemName, "]") {
+		return FieldType{}, ErrNestedArray
+	}
+	elem, err := nameToFieldType(elemName)
+	if err != nil {
+		return FieldType{}, err
+	}
+	if elem.Type != ValueType || elem.Struct != nil {
+		return FieldType{}, fmt.Errorf("invalid array element type %s, only value types are supported", elemName)
+	}
+	// Elements are stored back to back, so full width elements such as
+	// uint256 take one slot each
+	return FieldType{
+		Name:        name,
+		Type:        ValueType,
+		Size:        length * elem.Size,
+		GoType:      fmt.Sprintf("[%d]%s", length, elem.GoType),
+		SolType:     fmt.Sprintf("%s[%d]", elem.SolType, length),
+		ArrayLength: length,
+		Elem:        &elem,
+	}, nil
+}
+
+func dynamicArrayFieldType(name string) (FieldType, error) {
+	elemName := strings.TrimSuffix(name, "[]")
+	if strings.HasSuffix(elemName, "]") {
+		return FieldType{}, ErrNestedArray
+	}
+	elem, err := nameToFieldType(elemName)
+	if err != nil {
+		return FieldType{}, err
+	}
+	if elem.Type != ValueType || elem.Elem != nil || elem.Struct != nil {
+		return FieldType{}, fmt.Errorf("unsupported element type %s for dynamic array, only value types are supported", elemName)
+	}
+	return FieldType{
+		Name:    name,
+		Type:    DynamicArrayType,
+		Size:    32,
+		GoType:  "[]" + elem.GoType,
+		SolType: elem.SolType + "[]",
+		Elem:    &elem,
+	}, nil
 }
 
 func nameToFieldType(name string) (FieldType, error) {
+	if strings.HasPrefix(name, "enum ") {
+		return enumFieldType(name)
+	}
+	if strings.HasPrefix(name, "flags ") {
+		return flagsFieldType(name)
+	}
+	if strings.HasPrefix(name, "struct ") {
+		return structFieldType(name)
+	}
+	if strings.HasSuffix(name, "[]") {
+		return dynamicArrayFieldType(name)
+	}
+	if strings.HasSuffix(name, "]") {
+		return arrayFieldType(name)
+	}
+	if strings.HasPrefix(name, "fixed") || strings.HasPrefix(name, "ufixed") {
+		return fixedFieldType(name)
+	}
+	if counterTypeRegexp.MatchString(name) {
+		return counterFieldType(name)
+	}
+
 	switch name {
 	case "address":
 		return FieldType{
@@ -45,8 +713,17 @@ func nameToFieldType(name string) (FieldType, error) {
 			Size:       20,
 			GoType:     "common.Address",
 			SolType:    "address",
-			EncodeFunc: "EncodeAddress",
-			DecodeFunc: "DecodeAddress",
+			EncodeFunc: "codec.EncodeAddress",
+			DecodeFunc: "codec.DecodeAddress",
+		}, nil
+	case "function":
+		return FieldType{
+			Name:       "function",
+			Size:       24,
+			GoType:     "codec.Function",
+			SolType:    "function",
+			EncodeFunc: "codec.EncodeFunction",
+			DecodeFunc: "codec.DecodeFunction",
 		}, nil
 	case "bool":
 		return FieldType{
@@ -54,8 +731,37 @@ func nameToFieldType(name string) (FieldType, error) {
 			Size:       1,
 			GoType:     "bool",
 			SolType:    "bool",
-			EncodeFunc: "EncodeBool",
-			DecodeFunc: "DecodeBool",
+			EncodeFunc: "codec.EncodeBool",
+			DecodeFunc: "codec.DecodeBool",
+		}, nil
+	case "float64":
+		// Floats are stored as raw IEEE-754 bits, solidity sees them as opaque
+		// bytes
+		return FieldType{
+			Name:       "float64",
+			Size:       8,
+			GoType:     "float64",
+			SolType:    "bytes8",
+			EncodeFunc: "codec.EncodeFloat64",
+			DecodeFunc: "codec.DecodeFloat64",
+		}, nil
+	case "timestamp":
+		return FieldType{
+			Name:       "timestamp",
+			Size:       8,
+			GoType:     "time.Time",
+			SolType:    "uint64",
+			EncodeFunc: "codec.EncodeTimestamp",
+			DecodeFunc: "codec.DecodeTimestamp",
+		}, nil
+	case "duration":
+		return FieldType{
+			Name:       "duration",
+			Size:       8,
+			GoType:     "time.Duration",
+			SolType:    "uint64",
+			EncodeFunc: "codec.EncodeDuration",
+			DecodeFunc: "codec.DecodeDuration",
 		}, nil
 	case "uint":
 		break
@@ -66,9 +772,21 @@ func nameToFieldType(name string) (FieldType, error) {
 			Name:       "bytes",
 			Size:       32,
 			GoType:     "[]byte",
-			SolType:    "memory bytes",
-			EncodeFunc: "EncodeBytes",
-			DecodeFunc: "DecodeBytes",
+			SolType:    "bytes",
+			EncodeFunc: "codec.EncodeBytes",
+			DecodeFunc: "codec.DecodeBytes",
+			Type:       BytesType,
+		}, nil
+	case "cbor":
+		// The codec functions are replaced by the go type override, which
+		// cbor values require
+		return FieldType{
+			Name:       "cbor",
+			Size:       32,
+			GoType:     "[]byte",
+			SolType:    "bytes",
+			EncodeFunc: "codec.EncodeBytes",
+			DecodeFunc: "codec.DecodeBytes",
 			Type:       BytesType,
 		}, nil
 	case "string":
@@ -76,9 +794,9 @@ func nameToFieldType(name string) (FieldType, error) {
 			Name:       "string",
 			Size:       32,
 			GoType:     "string",
-			SolType:    "memory string",
-			EncodeFunc: "EncodeString",
-			DecodeFunc: "DecodeString",
+			SolType:    "string",
+			EncodeFunc: "codec.EncodeString",
+			DecodeFunc: "codec.DecodeString",
 			Type:       BytesType,
 		}, nil
 	default:
@@ -92,27 +810,48 @@ func nameToFieldType(name string) (FieldType, error) {
 		sizeStr = strings.TrimPrefix(name, "bytes")
 		size, err = strconv.Atoi(sizeStr)
 		if err != nil {
-			return FieldType{}, err
+			return FieldType{}, fmt.Errorf("%w %s", ErrUnknownFieldType, name)
 		}
 		if size < 1 || size > 32 {
-			return FieldType{}, fmt.Errorf("invalid bytes size %d", size)
+			return FieldType{}, fmt.Errorf("%w %d", ErrInvalidBytesSize, size)
 		}
 		fieldType := FieldType{
 			Name:       name,
 			Size:       size,
 			GoType:     "[]byte",
 			SolType:    fmt.Sprintf("bytes%d", size),
-			EncodeFunc: "EncodeFixedBytes",
-			DecodeFunc: "DecodeFixedBytes",
+			EncodeFunc: "codec.EncodeFixedBytes",
+			DecodeFunc: "codec.DecodeFixedBytes",
 		}
 		if size == 32 {
 			fieldType.GoType = "common.Hash"
-			fieldType.EncodeFunc = "EncodeHash"
-			fieldType.DecodeFunc = "DecodeHash"
+			fieldType.EncodeFunc = "codec.EncodeHash"
+			fieldType.DecodeFunc = "codec.DecodeHash"
 		}
 		return fieldType, nil
 	}
 
+	if strings.HasPrefix(name, "string") {
+		// Short strings stored inline in a single slot, seen as bytesN by
+		// solidity
+		sizeStr = strings.TrimPrefix(name, "string")
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil {
+			return FieldType{}, fmt.Errorf("%w %s", ErrUnknownFieldType, name)
+		}
+		if size < 1 || size > 32 {
+			return FieldType{}, fmt.Errorf("%w %d", ErrInvalidStringSize, size)
+		}
+		return FieldType{
+			Name:       name,
+			Size:       size,
+			GoType:     "string",
+			SolType:    fmt.Sprintf("bytes%d", size),
+			EncodeFunc: "codec.EncodeFixedString",
+			DecodeFunc: "codec.DecodeFixedString",
+		}, nil
+	}
+
 	matchesUint := strings.HasPrefix(name, "uint")
 	matchesInt := strings.HasPrefix(name, "int")
 
@@ -130,52 +869,197 @@ func nameToFieldType(name string) (FieldType, error) {
 		} else {
 			size, err = strconv.Atoi(sizeStr)
 			if err != nil {
-				return FieldType{}, err
+				return FieldType{}, fmt.Errorf("%w %s", ErrUnknownFieldType, name)
 			}
 		}
-		if size < 8 || (size > 64 && size != 256) || size%8 != 0 {
-			return FieldType{}, fmt.Errorf("invalid integer size %d", size)
+		if size < 8 || size%8 != 0 {
+			return FieldType{}, fmt.Errorf("%w %d", ErrInvalidIntSize, size)
 		}
-
-		if noSizeTypeStr == "int" && size == 256 {
-			return FieldType{}, fmt.Errorf("int256 is not valid, use uint256 with Sign() and Neg() to represent negative big numbers")
+		if size > 256 {
+			return FieldType{}, fmt.Errorf("%w %d", ErrInvalidIntSize, size)
+		}
+		if noSizeTypeStr == "int" && size > 64 && size != 128 && size != 256 {
+			return FieldType{}, fmt.Errorf("%w %d, big signed integers must be int128 or int256", ErrInvalidIntSize, size)
 		}
 
 		fieldType := FieldType{
 			Name: name,
 			Size: size / 8,
 		}
-		var (
-			goType      string
-			codecSuffix string
-		)
+		fieldType.SolType = fmt.Sprintf("%s%d", noSizeTypeStr, size)
 		if size <= 64 {
-			goType = noSizeTypeStr + fmt.Sprint(size)
-			codecSuffix = fmt.Sprintf("%s%d", upperFirstLetter(noSizeTypeStr), size)
+			// Native widths share the generic codec, instantiated with the go
+			// type and called with the field size
+			fieldType.GoType = noSizeTypeStr + fmt.Sprint(size)
+			codecName := upperFirstLetter(noSizeTypeStr)
+			fieldType.EncodeFunc = fmt.Sprintf("codec.Encode%s[%s]", codecName, fieldType.GoType)
+			fieldType.DecodeFunc = fmt.Sprintf("codec.Decode%s[%s]", codecName, fieldType.GoType)
 		} else {
-			goType = "*uint256.Int"
-			codecSuffix = fmt.Sprintf("%s256", upperFirstLetter(noSizeTypeStr))
+			// Signed big integers are stored in two's complement over the
+			// field size and represented as *uint256.Int
+			codecSuffix := fmt.Sprintf("%s%d", upperFirstLetter(noSizeTypeStr), size)
+			fieldType.GoType = "*uint256.Int"
+			fieldType.EncodeFunc = "codec.Encode" + codecSuffix
+			fieldType.DecodeFunc = "codec.Decode" + codecSuffix
 		}
-		fieldType.GoType = goType
-		fieldType.SolType = fmt.Sprintf("%s%d", noSizeTypeStr, size)
-		fieldType.EncodeFunc = "Encode" + codecSuffix
-		fieldType.DecodeFunc = "Decode" + codecSuffix
 		return fieldType, nil
 	}
 
 	if strings.HasPrefix(name, "table ") {
 		tableName := strings.TrimPrefix(name, "table ")
 		if !isValidName(tableName) {
-			return FieldType{}, fmt.Errorf("invalid table name %s", tableName)
+			return FieldType{}, fmt.Errorf("%w %s", ErrInvalidTableName, tableName)
 		}
 		return FieldType{
 			Name:    tableName,
 			Size:    32,
 			GoType:  formatTableName(tableName),
-			SolType: fmt.Sprintf("memory %s", formatTableName(tableName)),
+			SolType: formatTableName(tableName),
 			Type:    TableType,
 		}, nil
 	}
 
-	return FieldType{}, fmt.Errorf("unknown field type %s", name)
+	return FieldType{}, fmt.Errorf("%w %s", ErrUnknownFieldType, name)
+}
+
+var (
+	endianAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+endian:"([^"]*)"$`)
+	alignAnnotationRegexp  = regexp.MustCompile(`^(.*\S)\s+align:"([^"]*)"$`)
+	maxLenAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+maxlen:"([^"]*)"$`)
+	hashedAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+@hashed$`)
+	bigIntAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+@bigint$`)
+	fixedBytesTypeRegexp   = regexp.MustCompile(`^bytes[0-9]+$`)
+	integerTypeRegexp      = regexp.MustCompile(`^u?int[0-9]*$`)
+)
+
+// splitEndianAnnotation splits a field type into its type and its byte order
+// annotation, if any, e.g. `uint64 endian:"little"`.
+func splitEndianAnnotation(typeStr string) (string, string) {
+	matches := endianAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, ""
+	}
+	return matches[1], matches[2]
+}
+
+// withEndian returns the integer field type with the codec functions of the
+// given byte order. Big-endian is the default, matching EVM words.
+func withEndian(fieldType FieldType, endian string) (FieldType, error) {
+	switch endian {
+	case "big":
+		return fieldType, nil
+	case "little":
+	default:
+		return FieldType{}, fmt.Errorf("invalid byte order %s, expected big or little", endian)
+	}
+	if fieldType.Type != ValueType || fieldType.Elem != nil || !integerTypeRegexp.MatchString(fieldType.Name) {
+		return FieldType{}, fmt.Errorf("little-endian encoding is only supported for integers")
+	}
+	fieldType.LittleEndian = true
+	if fieldType.Size == 1 {
+		// Single bytes have no byte order
+		return fieldType, nil
+	}
+	signed := strings.HasPrefix(fieldType.Name, "int")
+	suffix := "Uint"
+	if signed {
+		suffix = "Int"
+	}
+	if fieldType.Size <= 8 {
+		suffix += fmt.Sprint(fieldType.Size*8) + "LE"
+	} else {
+		suffix += "LE"
+	}
+	fieldType.EncodeFunc = "codec.Encode" + suffix
+	fieldType.DecodeFunc = "codec.Decode" + suffix
+	return fieldType, nil
+}
+
+// splitMaxLenAnnotation splits a value type into its type and the maximum
+// length in bytes of its values, e.g. `string maxlen:"1024"`. The length is 0
+// if the values are not capped.
+func splitMaxLenAnnotation(typeStr string) (string, int, error) {
+	matches := maxLenAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, 0, nil
+	}
+	maxLen, err := strconv.Atoi(matches[2])
+	if err != nil || maxLen < 1 {
+		return "", 0, fmt.Errorf("invalid maximum length %q, expected a positive integer", matches[2])
+	}
+	return matches[1], maxLen, nil
+}
+
+// splitHashedAnnotation splits a value type into its type and whether it is
+// annotated as hashed, e.g. `bytes @hashed`.
+func splitHashedAnnotation(typeStr string) (string, bool) {
+	matches := hashedAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, false
+	}
+	return matches[1], true
+}
+
+// splitBigIntAnnotation splits a field type into its type and whether it is
+// annotated as represented by *big.Int, e.g. `uint256 @bigint`.
+func splitBigIntAnnotation(typeStr string) (string, bool) {
+	matches := bigIntAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, false
+	}
+	return matches[1], true
+}
+
+// withBigInt returns the integer field type with its go type replaced by
+// *big.Int and the codec functions converting it, which panic with
+// codec.ErrIntOutOfRange for values that do not fit in the field.
+func withBigInt(fieldType FieldType) (FieldType, error) {
+	if fieldType.Type != ValueType || fieldType.Elem != nil || fieldType.GoType != "*uint256.Int" || fieldType.Fixed != nil || !integerTypeRegexp.MatchString(fieldType.Name) {
+		return FieldType{}, fmt.Errorf("bigint is only supported for integers wider than 64 bits")
+	}
+	if fieldType.LittleEndian {
+		return FieldType{}, fmt.Errorf("bigint is not supported for little-endian integers")
+	}
+	sign := "Uint"
+	if strings.HasPrefix(fieldType.Name, "int") {
+		sign = "Int"
+	}
+	fieldType.BigInt = true
+	fieldType.GoType = "*big.Int"
+	fieldType.EncodeFunc = fmt.Sprintf("codec.Encode%sBig", sign)
+	fieldType.DecodeFunc = fmt.Sprintf("codec.Decode%sBig", sign)
+	return fieldType, nil
+}
+
+// splitAlignAnnotation splits a field type into its type and its alignment
+// annotation, if any, e.g. `bytes8 align:"right"`.
+func splitAlignAnnotation(typeStr string) (string, string) {
+	matches := alignAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, ""
+	}
+	return matches[1], matches[2]
+}
+
+// withAlign returns the fixed bytes field type with the codec functions of the
+// given alignment. Left alignment is the default, matching solidity bytesN.
+func withAlign(fieldType FieldType, align string) (FieldType, error) {
+	switch align {
+	case "left":
+		return fieldType, nil
+	case "right":
+	default:
+		return FieldType{}, fmt.Errorf("invalid alignment %s, expected left or right", align)
+	}
+	if fieldType.Type != ValueType || fieldType.Elem != nil || !fixedBytesTypeRegexp.MatchString(fieldType.Name) {
+		return FieldType{}, fmt.Errorf("right alignment is only supported for fixed bytes")
+	}
+	if fieldType.Size == 32 {
+		// Full words have no padding
+		return fieldType, nil
+	}
+	fieldType.RightAligned = true
+	fieldType.EncodeFunc = "codec.EncodeFixedBytesRight"
+	fieldType.DecodeFunc = "codec.DecodeFixedBytesRight"
+	return fieldType, nil
 }
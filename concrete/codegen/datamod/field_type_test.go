@@ -0,0 +1,519 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegerFieldType(t *testing.T) {
+	r := require.New(t)
+
+	for _, size := range []int{128, 160, 192, 224} {
+		fieldType, err := nameToFieldType(fmt.Sprintf("uint%d", size))
+		r.NoError(err)
+		r.Equal(size/8, fieldType.Size)
+		r.Equal("*uint256.Int", fieldType.GoType)
+		r.Equal(fmt.Sprintf("codec.EncodeUint%d", size), fieldType.EncodeFunc)
+	}
+
+	for _, name := range []string{"uint0", "uint7", "uint264", "int72", "int160"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestArrayFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("uint64[4]")
+	r.NoError(err)
+	r.Equal(32, fieldType.Size)
+	r.Equal("[4]uint64", fieldType.GoType)
+	r.Equal("uint64[4]", fieldType.SolType)
+	r.Equal(4, fieldType.ArrayLength)
+	r.Equal("codec.DecodeUint[uint64]", fieldType.Elem.DecodeFunc)
+
+	fieldType, err = nameToFieldType("address[8]")
+	r.NoError(err)
+	r.Equal(160, fieldType.Size)
+	r.Equal("[8]common.Address", fieldType.GoType)
+
+	// Full width elements take a slot each
+	fieldType, err = nameToFieldType("uint256[8]")
+	r.NoError(err)
+	r.Equal(256, fieldType.Size)
+	r.Equal("[8]*uint256.Int", fieldType.GoType)
+	r.Equal("uint256[8]", fieldType.SolType)
+	r.Equal("codec.DecodeUint256", fieldType.Elem.DecodeFunc)
+
+	for _, name := range []string{"uint8[0]", "uint8[2][2]", "string[2]", "uint8[-1]"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestFixedStringFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("string32")
+	r.NoError(err)
+	r.Equal(ValueType, fieldType.Type)
+	r.Equal(32, fieldType.Size)
+	r.Equal("string", fieldType.GoType)
+	r.Equal("bytes32", fieldType.SolType)
+	r.Equal("codec.EncodeFixedString", fieldType.EncodeFunc)
+
+	fieldType, err = nameToFieldType("string4")
+	r.NoError(err)
+	r.Equal(4, fieldType.Size)
+	r.Equal("bytes4", fieldType.SolType)
+
+	for _, name := range []string{"string0", "string33", "stringx"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestEnumFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("enum status {Pending, Active, Closed}")
+	r.NoError(err)
+	r.Equal(1, fieldType.Size)
+	r.Equal("Status", fieldType.GoType)
+	r.Equal("uint8", fieldType.SolType)
+	r.Equal([]string{"Pending", "Active", "Closed"}, fieldType.Enum.Values)
+	r.Equal("StatusClosed", fieldType.Enum.Last())
+
+	r.False(fieldType.Enum.Explicit())
+	r.Equal(2, fieldType.Enum.Number(2))
+
+	// Values without a backing value follow the previous one
+	fieldType, err = nameToFieldType("enum status {Pending = 0, Active = 10, Paused, Closed = 0xFF}")
+	r.NoError(err)
+	r.Equal([]string{"Pending", "Active", "Paused", "Closed"}, fieldType.Enum.Values)
+	r.True(fieldType.Enum.Explicit())
+	r.Equal([]int{0, 10, 11, 255}, fieldType.Enum.Numbers)
+
+	for _, name := range []string{
+		"enum {A}",
+		"enum Status {}",
+		"enum Status {A, A}",
+		"enum Status {A, 1}",
+		"enum Status A, B",
+		"enum Status {A = 256}",
+		"enum Status {A = -1}",
+		"enum Status {A = 0o7}",
+		"enum Status {A = }",
+		"enum Status {A = 1, B = 0x01}",
+		"enum Status {A = 0, B = 1, C = 0}",
+		"enum Status {A = 0xff, B}",
+	} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestSolArgType(t *testing.T) {
+	r := require.New(t)
+	for name, expected := range map[string]string{
+		"uint256":                             "uint256",
+		"address":                             "address",
+		"bytes16":                             "bytes16",
+		"bytes":                               "bytes memory",
+		"string":                              "string memory",
+		"uint8[4]":                            "uint8[4] memory",
+		"enum Foo {A}":                        "uint8",
+		"struct Point { x uint64; y uint64 }": "Point memory",
+	} {
+		fieldType, err := nameToFieldType(name)
+		r.NoError(err)
+		r.Equal(expected, fieldType.SolArgType(), name)
+	}
+}
+
+func TestDynamicArrayFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("address[]")
+	r.NoError(err)
+	r.Equal(DynamicArrayType, fieldType.Type)
+	r.Equal(32, fieldType.Size)
+	r.Equal("[]common.Address", fieldType.GoType)
+	r.Equal("address[]", fieldType.SolType)
+
+	for _, name := range []string{"bytes[]", "string[]", "uint8[2][]", "uint8[][]", "table foo[]"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestStructFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("struct point { x uint64; y uint64 }")
+	r.NoError(err)
+	r.Equal(16, fieldType.Size)
+	r.Equal("Point", fieldType.GoType)
+	r.Equal("Point", fieldType.SolType)
+	r.Equal("encodePoint", fieldType.EncodeFunc)
+	r.Len(fieldType.Struct.Members, 2)
+	r.Equal("Y", fieldType.Struct.Members[1].Title)
+	r.Equal(8, fieldType.Struct.Members[1].Offset)
+
+	fieldType, err = nameToFieldType("struct Segment { start struct Point { x uint64; y uint64 }; end struct Point { x uint64; y uint64 }; label bytes8; }")
+	r.NoError(err)
+	r.Equal(40, fieldType.Size)
+	r.Equal("Point", fieldType.Struct.Members[0].Type.GoType)
+	r.Equal(32, fieldType.Struct.Members[2].Offset)
+
+	for _, name := range []string{
+		"struct Point {}",
+		"struct Point { x uint64; x uint64 }",
+		"struct Point { x }",
+		"struct Point { x string }",
+		"struct Point { x uint8[2] }",
+		"struct Point { x uint64 ",
+		"struct A { b struct B { c struct C { x uint8 } } }",
+		"struct Point { x uint64 }[]",
+		"struct Point { x uint64 }[2]",
+	} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestTimeFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("timestamp")
+	r.NoError(err)
+	r.Equal(8, fieldType.Size)
+	r.Equal("time.Time", fieldType.GoType)
+	r.Equal("uint64", fieldType.SolType)
+	r.True(fieldType.usesTime())
+
+	fieldType, err = nameToFieldType("duration")
+	r.NoError(err)
+	r.Equal("time.Duration", fieldType.GoType)
+	r.Equal("codec.DecodeDuration", fieldType.DecodeFunc)
+
+	fieldType, err = nameToFieldType("struct Window { start timestamp; length duration }")
+	r.NoError(err)
+	r.Equal(16, fieldType.Size)
+	r.True(fieldType.usesTime())
+
+	fieldType, err = nameToFieldType("uint64[]")
+	r.NoError(err)
+	r.False(fieldType.usesTime())
+}
+
+func TestFixedFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("ufixed128x18")
+	r.NoError(err)
+	r.Equal(16, fieldType.Size)
+	r.Equal("Ufixed128x18", fieldType.GoType)
+	r.Equal("uint128", fieldType.SolType)
+	r.Equal("encodeUfixed128x18", fieldType.EncodeFunc)
+	r.Equal(&FixedSchema{Name: "ufixed128x18", Bits: 128, Decimals: 18}, fieldType.Fixed)
+
+	fieldType, err = nameToFieldType("fixed")
+	r.NoError(err)
+	r.Equal("Fixed128x18", fieldType.GoType)
+	r.Equal("int128", fieldType.SolType)
+	r.True(fieldType.Fixed.Signed)
+
+	fieldType, err = nameToFieldType("fixed64x0")
+	r.NoError(err)
+	r.Equal(8, fieldType.Size)
+	r.Equal(0, fieldType.Fixed.Decimals)
+
+	for _, name := range []string{"fixed0x18", "ufixed7x2", "fixed264x18", "ufixed128x81", "fixed128x", "ufixedx18", "fixed128y18"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestPackedEncodeExpr(t *testing.T) {
+	r := require.New(t)
+
+	field, err := newFieldSchema("id", 0, `uint32 endian:"little"`)
+	r.NoError(err)
+	r.Equal("codec.EncodeUint[uint32](4, id)", field.Type.PackedEncodeExpr("id"))
+
+	field, err = newFieldSchema("id", 0, `int64 endian:"little" gotype:"time.Duration"`)
+	r.NoError(err)
+	r.Equal("codec.EncodeInt[int64](8, int64(id))", field.Type.PackedEncodeExpr("id"))
+
+	field, err = newFieldSchema("owner", 0, "address")
+	r.NoError(err)
+	r.Equal("codec.EncodeAddress(20, owner)", field.Type.PackedEncodeExpr("owner"))
+}
+
+func TestCheckedFunc(t *testing.T) {
+	r := require.New(t)
+
+	for typeStr, expected := range map[string]string{
+		"uint8":                        "codec.AddUint[uint8]",
+		"int32":                        "codec.AddInt[int32]",
+		`int64 endian:"little"`:        "codec.AddInt[int64]",
+		"uint96":                       "codec.AddBigUint",
+		"int":                          "codec.AddBigInt",
+		"uint256 @bigint":              "codec.AddUintBig",
+		"counter32":                    "codec.AddUint[uint32]",
+		"int128 @bigint":               "codec.AddIntBig",
+		"bool":                         "",
+		"uint16[2]":                    "",
+		`int64 gotype:"time.Duration"`: "",
+	} {
+		field, err := newFieldSchema("value", 0, typeStr)
+		r.NoError(err, typeStr)
+		r.Equal(expected, field.Type.CheckedFunc("Add"), typeStr)
+	}
+}
+
+func TestBigIntFieldType(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, bigInt := splitBigIntAnnotation("uint256 @bigint")
+	r.Equal("uint256", typeStr)
+	r.True(bigInt)
+	typeStr, bigInt = splitBigIntAnnotation("uint256")
+	r.Equal("uint256", typeStr)
+	r.False(bigInt)
+
+	field, err := newFieldSchema("balance", 0, "uint96 @bigint")
+	r.NoError(err)
+	r.Equal("*big.Int", field.Type.GoType)
+	r.Equal("codec.EncodeUintBig", field.Type.EncodeFunc)
+	r.Equal("codec.DecodeUintBig", field.Type.DecodeFunc)
+	r.Equal("uint96", field.Type.SolType)
+	r.Equal(12, field.Type.Size)
+
+	field, err = newFieldSchema("debt", 0, "int @bigint")
+	r.NoError(err)
+	r.Equal("codec.EncodeIntBig", field.Type.EncodeFunc)
+	r.Equal("codec.DecodeIntBig", field.Type.DecodeFunc)
+
+	for _, typeStr := range []string{"uint64 @bigint", "bytes32 @bigint", "uint256[2] @bigint", "ufixed128x18 @bigint", `uint128 endian:"little" @bigint`} {
+		_, err := newFieldSchema("value", 0, typeStr)
+		r.Error(err, typeStr)
+	}
+}
+
+func TestLittleEndianFieldType(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, endian := splitEndianAnnotation(`uint64 endian:"little"`)
+	r.Equal("uint64", typeStr)
+	r.Equal("little", endian)
+	typeStr, endian = splitEndianAnnotation("uint64")
+	r.Equal("uint64", typeStr)
+	r.Equal("", endian)
+
+	for name, funcs := range map[string][2]string{
+		"uint16": {"codec.EncodeUint16LE", "codec.DecodeUint16LE"},
+		"int64":  {"codec.EncodeInt64LE", "codec.DecodeInt64LE"},
+		"uint72": {"codec.EncodeUintLE", "codec.DecodeUintLE"},
+		"int":    {"codec.EncodeIntLE", "codec.DecodeIntLE"},
+		"uint8":  {"codec.EncodeUint[uint8]", "codec.DecodeUint[uint8]"},
+	} {
+		fieldType, err := nameToFieldType(name)
+		r.NoError(err)
+		fieldType, err = withEndian(fieldType, "little")
+		r.NoError(err, name)
+		r.True(fieldType.LittleEndian)
+		r.Equal(funcs[0], fieldType.EncodeFunc, name)
+		r.Equal(funcs[1], fieldType.DecodeFunc, name)
+	}
+
+	fieldType, err := nameToFieldType("uint32")
+	r.NoError(err)
+	fieldType, err = withEndian(fieldType, "big")
+	r.NoError(err)
+	r.False(fieldType.LittleEndian)
+	r.Equal("codec.EncodeUint[uint32]", fieldType.EncodeFunc)
+
+	_, err = withEndian(fieldType, "middle")
+	r.Error(err)
+	for _, name := range []string{"address", "bytes32", "string", "uint64[2]", "ufixed128x18"} {
+		fieldType, err := nameToFieldType(name)
+		r.NoError(err)
+		_, err = withEndian(fieldType, "little")
+		r.Error(err, name)
+	}
+}
+
+func TestRightAlignedFieldType(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, align := splitAlignAnnotation(`bytes8 align:"right"`)
+	r.Equal("bytes8", typeStr)
+	r.Equal("right", align)
+	typeStr, align = splitAlignAnnotation("bytes8")
+	r.Equal("bytes8", typeStr)
+	r.Equal("", align)
+
+	fieldType, err := nameToFieldType("bytes8")
+	r.NoError(err)
+	left, err := withAlign(fieldType, "left")
+	r.NoError(err)
+	r.Equal(fieldType, left)
+	right, err := withAlign(fieldType, "right")
+	r.NoError(err)
+	r.True(right.RightAligned)
+	r.Equal("[]byte", right.GoType)
+	r.Equal("bytes8", right.SolType)
+	r.Equal("codec.EncodeFixedBytesRight", right.EncodeFunc)
+	r.Equal("codec.DecodeFixedBytesRight", right.DecodeFunc)
+
+	// Full words have no padding to align
+	fieldType, err = nameToFieldType("bytes32")
+	r.NoError(err)
+	right, err = withAlign(fieldType, "right")
+	r.NoError(err)
+	r.Equal(fieldType, right)
+
+	_, err = withAlign(fieldType, "center")
+	r.Error(err)
+	for _, name := range []string{"uint64", "address", "bytes", "string", "bytes4[2]", "string8"} {
+		fieldType, err := nameToFieldType(name)
+		r.NoError(err)
+		_, err = withAlign(fieldType, "right")
+		r.Error(err, name)
+	}
+}
+
+func TestSplitMaxLenAnnotation(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, maxLen, err := splitMaxLenAnnotation(`string maxlen:"1024"`)
+	r.NoError(err)
+	r.Equal("string", typeStr)
+	r.Equal(1024, maxLen)
+
+	typeStr, maxLen, err = splitMaxLenAnnotation("bytes")
+	r.NoError(err)
+	r.Equal("bytes", typeStr)
+	r.Equal(0, maxLen)
+
+	for _, typeStr := range []string{`bytes maxlen:"0"`, `bytes maxlen:"-1"`, `bytes maxlen:"1k"`, `bytes maxlen:""`} {
+		_, _, err = splitMaxLenAnnotation(typeStr)
+		r.Error(err, typeStr)
+	}
+}
+
+func TestFunctionFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("function")
+	r.NoError(err)
+	r.Equal(24, fieldType.Size)
+	r.Equal(ValueType, fieldType.Type)
+	r.Equal("codec.Function", fieldType.GoType)
+	r.Equal("function", fieldType.SolType)
+	r.Equal("function() external", fieldType.SolArgType())
+	r.Equal("codec.EncodeFunction", fieldType.EncodeFunc)
+	r.Equal("codec.DecodeFunction", fieldType.DecodeFunc)
+}
+
+func TestFloat64FieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("float64")
+	r.NoError(err)
+	r.Equal(8, fieldType.Size)
+	r.Equal(ValueType, fieldType.Type)
+	r.Equal("float64", fieldType.GoType)
+	r.Equal("bytes8", fieldType.SolType)
+	r.Equal("codec.EncodeFloat64", fieldType.EncodeFunc)
+	r.Equal("codec.DecodeFloat64", fieldType.DecodeFunc)
+
+	_, err = withEndian(fieldType, "little")
+	r.Error(err)
+}
+
+func TestFlagsFieldType(t *testing.T) {
+	r := require.New(t)
+
+	fieldType, err := nameToFieldType("flags permissions {Read, Write, Admin}")
+	r.NoError(err)
+	r.Equal(1, fieldType.Size)
+	r.Equal("Permissions", fieldType.GoType)
+	r.Equal("uint8", fieldType.SolType)
+	r.Equal("PermissionsFlag", fieldType.Flags.FlagName())
+	r.Equal("uint8", fieldType.Flags.GoBaseType())
+	r.Equal([]string{"Read", "Write", "Admin"}, fieldType.Flags.Values)
+
+	for count, size := range map[int]int{8: 1, 9: 2, 16: 2, 17: 4, 33: 8, 64: 8, 65: 9, 256: 32} {
+		values := make([]string, count)
+		for ii := range values {
+			values[ii] = fmt.Sprintf("F%d", ii)
+		}
+		fieldType, err := nameToFieldType("flags Many {" + strings.Join(values, ", ") + "}")
+		r.NoError(err, count)
+		r.Equal(size, fieldType.Size, count)
+		r.Equal(fmt.Sprintf("uint%d", size*8), fieldType.SolType, count)
+		r.Equal(size > 8, fieldType.Flags.IsArray(), count)
+	}
+
+	values := make([]string, 257)
+	for ii := range values {
+		values[ii] = fmt.Sprintf("F%d", ii)
+	}
+	for _, name := range []string{"flags {A}", "flags Perms {}", "flags Perms {A, A}", "flags Perms {A, 1}", "flags Perms A, B", "flags Many {" + strings.Join(values, ", ") + "}"} {
+		_, err := nameToFieldType(name)
+		r.Error(err, name)
+	}
+}
+
+func TestFieldTypeErrors(t *testing.T) {
+	r := require.New(t)
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		msg  string
+	}{
+		{"adress", ErrUnknownFieldType, "unknown field type adress"},
+		{"uintx", ErrUnknownFieldType, "unknown field type uintx"},
+		{"uint7", ErrInvalidIntSize, "invalid integer size 7"},
+		{"int72", ErrInvalidIntSize, "invalid integer size 72, big signed integers must be int128 or int256"},
+		{"bytes33", ErrInvalidBytesSize, "invalid bytes size 33"},
+		{"string0", ErrInvalidStringSize, "invalid string size 0"},
+		{"uint8[0]", ErrInvalidArrayLength, "invalid array length 0"},
+		{"uint8[2][]", ErrNestedArray, "nested arrays are not supported"},
+		{"fixed7x2", ErrInvalidFixedPointSize, "invalid fixed-point size 7, must be a multiple of 8 between 8 and 256"},
+		{"fixed8x81", ErrInvalidFixedPointDecimals, "invalid fixed-point decimals 81, must be between 0 and 80"},
+		{"table 1x", ErrInvalidTableName, "invalid table name 1x"},
+	} {
+		_, err := nameToFieldType(tc.name)
+		r.ErrorIs(err, tc.err, tc.name)
+		r.EqualError(err, tc.msg, tc.name)
+	}
+
+	// Errors are still matched once wrapped with the field
+	_, err := newFieldSchema("a", 0, "uint7")
+	r.ErrorIs(err, ErrInvalidIntSize)
+	r.EqualError(err, "invalid type 'uint7' for field 'a': invalid integer size 7")
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// GoTypeOverride replaces the go type of a value field with a named type
+// defined in another package, e.g. `address gotype:"github.com/acme/x.AccountID"`.
+// Values are converted to and from the original go type of the field, which
+// determines how they are stored.
+type GoTypeOverride struct {
+	ImportPath string
+	PkgName    string
+	TypeName   string
+	Base       FieldType
+}
+
+func (o *GoTypeOverride) GoType() string {
+	return o.PkgName + "." + o.TypeName
+}
+
+func (o *GoTypeOverride) FuncSuffix() string {
+	return upperFirstLetter(o.PkgName) + o.TypeName
+}
+
+// generatedImports are the packages imported by all generated files with the
+// default import paths.
+var generatedImports = map[string]string{
+	"common":  "github.com/ethereum/go-ethereum/common",
+	"codec":   "github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec",
+	"crypto":  "github.com/ethereum/go-ethereum/concrete/crypto",
+	"lib":     "github.com/ethereum/go-ethereum/concrete/lib",
+	"uint256": "github.com/holiman/uint256",
+}
+
+var (
+	goTypeAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+gotype:"([^"]*)"$`)
+
+	goTypeImporterOnce sync.Once
+	goTypeImporter     types.Importer
+)
+
+// splitGoTypeAnnotation splits a field type into its storage type and its
+// go type override, if any.
+func splitGoTypeAnnotation(typeStr string) (string, string) {
+	matches := goTypeAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, ""
+	}
+	return matches[1], matches[2]
+}
+
+func importGoTypePackage(path string) (*types.Package, error) {
+	goTypeImporterOnce.Do(func() {
+		goTypeImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+	})
+	return goTypeImporter.Import(path)
+}
+
+// underlyingGoType returns the underlying type of the go types used by the
+// codec, or nil if they cannot be overridden.
+func underlyingGoType(goType string) types.Type {
+	switch goType {
+	case "common.Address":
+		return types.NewArray(types.Typ[types.Byte], 20)
+	case "common.Hash":
+		return types.NewArray(types.Typ[types.Byte], 32)
+	case "[]byte":
+		return types.NewSlice(types.Typ[types.Byte])
+	case "string", "bool", "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64":
+		return types.Universe.Lookup(goType).Type()
+	}
+	return nil
+}
+
+func newGoTypeOverride(base FieldType, override string) (*GoTypeOverride, error) {
+	if base.Type == TableType || base.Elem != nil || base.Enum != nil || base.Flags != nil {
+		return nil, fmt.Errorf("go type overrides are only supported for value, bytes and string types")
+	}
+	if base.Counter {
+		return nil, fmt.Errorf("go type overrides are not supported for counters")
+	}
+	expected := underlyingGoType(base.GoType)
+	if base.IsCBOR() {
+		// Any go type can be marshalled as cbor
+		expected = nil
+	} else if expected == nil {
+		return nil, fmt.Errorf("go type overrides are not supported for %s", base.GoType)
+	}
+
+	dotIdx := strings.LastIndex(override, ".")
+	if dotIdx <= 0 {
+		return nil, fmt.Errorf("invalid go type %s, expected <import path>.<type name>", override)
+	}
+	importPath, typeName := override[:dotIdx], override[dotIdx+1:]
+	if !isValidName(typeName) {
+		return nil, fmt.Errorf("invalid go type name %s", typeName)
+	}
+
+	pkg, err := importGoTypePackage(importPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load package %s: %w", importPath, err)
+	}
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok || !obj.Exported() {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, importPath)
+	}
+	if expected != nil && !types.Identical(obj.Type().Underlying(), expected) {
+		return nil, fmt.Errorf("go type %s with underlying type %s is not compatible with %s", override, obj.Type().Underlying(), base.GoType)
+	}
+
+	pkgName := pkg.Name()
+	if path, ok := generatedImports[pkgName]; ok && path != importPath {
+		pkgName += "pkg"
+	}
+
+	return &GoTypeOverride{
+		ImportPath: importPath,
+		PkgName:    pkgName,
+		TypeName:   typeName,
+		Base:       base,
+	}, nil
+}
+
+// withGoTypeOverride returns the field type with its go type replaced by the
+// override and its codec functions replaced by generated conversion wrappers,
+// or by the cbor codec for cbor values.
+func withGoTypeOverride(base FieldType, override *GoTypeOverride) FieldType {
+	fieldType := base
+	fieldType.GoType = override.GoType()
+	fieldType.EncodeFunc = "encode" + override.FuncSuffix()
+	fieldType.DecodeFunc = "decode" + override.FuncSuffix()
+	if base.IsCBOR() {
+		fieldType.EncodeFunc = "codec.EncodeCBOR[" + override.GoType() + "]"
+		fieldType.DecodeFunc = "codec.DecodeCBOR[" + override.GoType() + "]"
+	}
+	fieldType.GoTypeOverride = override
+	return fieldType
+}
+
+type goImport struct {
+	Alias string
+	Path  string
+}
+
+// collectGoTypeOverrides returns the go type overrides used in the given
+// fields and the imports they require, both in declaration order. Packages
+// already imported by the generated files, given by name, are not imported
+// again.
+func collectGoTypeOverrides(fields []FieldSchema, generated map[string]string) ([]*GoTypeOverride, []goImport, error) {
+	var (
+		overrides   []*GoTypeOverride
+		imports     []goImport
+		seenTypes   = make(map[string]*GoTypeOverride)
+		seenImports = make(map[string]string)
+	)
+	for _, field := range fields {
+		override := field.Type.GoTypeOverride
+		if override == nil {
+			continue
+		}
+		if other, ok := seenTypes[override.FuncSuffix()]; ok {
+			if other.ImportPath != override.ImportPath || other.TypeName != override.TypeName {
+				return nil, nil, fmt.Errorf("go types %s and %s have the same name", other.GoType(), override.GoType())
+			}
+			if other.Base.GoType != override.Base.GoType || other.Base.EncodeFunc != override.Base.EncodeFunc {
+				return nil, nil, fmt.Errorf("go type %s is used with different storage types", override.GoType())
+			}
+			continue
+		}
+		if importPath, ok := seenImports[override.PkgName]; ok && importPath != override.ImportPath {
+			return nil, nil, fmt.Errorf("packages %s and %s have the same name", importPath, override.ImportPath)
+		}
+		_, seen := seenImports[override.PkgName]
+		if !seen && generated[override.PkgName] != override.ImportPath {
+			imp := goImport{Path: override.ImportPath}
+			if override.PkgName != path.Base(override.ImportPath) {
+				imp.Alias = override.PkgName
+			}
+			imports = append(imports, imp)
+		}
+		seenImports[override.PkgName] = override.ImportPath
+		seenTypes[override.FuncSuffix()] = override
+		overrides = append(overrides, override)
+	}
+	return overrides, imports, nil
+}
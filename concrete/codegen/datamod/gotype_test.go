@@ -0,0 +1,111 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const goTypesPkg = "github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata/gotypes"
+
+func TestGoTypeOverride(t *testing.T) {
+	r := require.New(t)
+
+	field, err := newFieldSchema("timeout", 0, `int64 gotype:"time.Duration"`)
+	r.NoError(err)
+	r.Equal("time.Duration", field.Type.GoType)
+	r.Equal(8, field.Type.Size)
+	r.Equal("encodeTimeDuration", field.Type.EncodeFunc)
+	r.Equal("codec.EncodeInt[int64]", field.Type.GoTypeOverride.Base.EncodeFunc)
+
+	field, err = newFieldSchema("owner", 0, `address gotype:"`+goTypesPkg+`.AccountID"`)
+	r.NoError(err)
+	r.Equal("gotypes.AccountID", field.Type.GoType)
+	r.Equal(20, field.Type.Size)
+
+	field, err = newFieldSchema("label", 0, `string gotype:"`+goTypesPkg+`.Label"`)
+	r.NoError(err)
+	r.Equal("gotypes.Label", field.Type.GoType)
+
+	for _, typeStr := range []string{
+		`int32 gotype:"time.Duration"`,
+		`int64 gotype:"time.Time"`,
+		`int64 gotype:"time.NotAType"`,
+		`int64 gotype:"Duration"`,
+		`uint256 gotype:"` + goTypesPkg + `.Balance"`,
+		`uint32 gotype:"` + goTypesPkg + `.Balance"`,
+		`bytes32 gotype:"` + goTypesPkg + `.AccountID"`,
+		`address gotype:"` + goTypesPkg + `.Account"`,
+		`address[2] gotype:"` + goTypesPkg + `.AccountID"`,
+		`address gotype:"` + goTypesPkg + `/missing.AccountID"`,
+	} {
+		_, err := newFieldSchema("field", 0, typeStr)
+		r.Error(err, typeStr)
+	}
+}
+
+func TestCollectGoTypeOverrides(t *testing.T) {
+	r := require.New(t)
+
+	fields := make([]FieldSchema, 0)
+	for _, typeStr := range []string{
+		`int64 gotype:"time.Duration"`,
+		`int64 gotype:"time.Duration"`,
+		`address gotype:"` + goTypesPkg + `.AccountID"`,
+		`uint64`,
+	} {
+		field, err := newFieldSchema("field", 0, typeStr)
+		r.NoError(err)
+		fields = append(fields, field)
+	}
+	overrides, imports, err := collectGoTypeOverrides(fields, generatedImports)
+	r.NoError(err)
+	r.Len(overrides, 2)
+	r.Equal([]goImport{{Path: "time"}, {Path: goTypesPkg}}, imports)
+
+	field, err := newFieldSchema("field", 0, `int64 gotype:"time.Duration"`)
+	r.NoError(err)
+	field.Type.GoTypeOverride.Base.GoType = "uint64"
+	_, _, err = collectGoTypeOverrides(append(fields, field), generatedImports)
+	r.Error(err)
+}
+
+func TestCBORFieldType(t *testing.T) {
+	r := require.New(t)
+
+	field, err := newFieldSchema("profile", 0, `cbor gotype:"`+goTypesPkg+`.Profile"`)
+	r.NoError(err)
+	r.Equal("gotypes.Profile", field.Type.GoType)
+	r.Equal("bytes", field.Type.SolType)
+	r.Equal(BytesType, field.Type.Type)
+	r.Equal("codec.EncodeCBOR[gotypes.Profile]", field.Type.EncodeFunc)
+	r.Equal("codec.DecodeCBOR[gotypes.Profile]", field.Type.DecodeFunc)
+
+	// Any named type can be marshalled
+	_, err = newFieldSchema("balance", 0, `cbor gotype:"`+goTypesPkg+`.Balance"`)
+	r.NoError(err)
+
+	for _, typeStr := range []string{
+		`cbor`,
+		`cbor[]`,
+		`cbor gotype:"` + goTypesPkg + `.Missing"`,
+	} {
+		_, err := newFieldSchema("field", 0, typeStr)
+		r.Error(err, typeStr)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Values are marshaled to JSON through a struct of the same fields where bytes
+// are replaced by hexutil.Bytes and signed integers wider than 64 bits, as well
+// as integers represented as *big.Int, by decimal strings. Other types marshal as they are, e.g. *uint256.Int as a
+// decimal string and common.Address as a hex string, and so do go type
+// overrides.
+
+const (
+	jsonBytes = "bytes"
+	jsonInt   = "int"
+	jsonBig   = "big"
+)
+
+// jsonKind returns how values of the type are converted to and from JSON, or
+// an empty string if they marshal as they are.
+func (t FieldType) jsonKind() string {
+	switch {
+	case t.GoTypeOverride != nil || t.Elem != nil:
+		return ""
+	case t.GoType == "[]byte":
+		return jsonBytes
+	case t.GoType == "*uint256.Int" && strings.HasPrefix(t.Name, "int"):
+		return jsonInt
+	case t.BigInt:
+		return jsonBig
+	default:
+		return ""
+	}
+}
+
+// jsonConverted reports whether values of the type, or their elements, are
+// converted to and from JSON.
+func (t FieldType) jsonConverted() bool {
+	if t.Elem != nil && t.GoTypeOverride == nil {
+		return t.Elem.jsonKind() != ""
+	}
+	return t.jsonKind() != ""
+}
+
+// JSONType returns the go type of the field in the JSON representation of the
+// struct holding it.
+func (t FieldType) JSONType() string {
+	if t.Elem != nil && t.jsonConverted() {
+		if t.Type == DynamicArrayType {
+			return "[]" + t.Elem.JSONType()
+		}
+		return fmt.Sprintf("[%d]%s", t.ArrayLength, t.Elem.JSONType())
+	}
+	switch t.jsonKind() {
+	case jsonBytes:
+		return "hexutil.Bytes"
+	case jsonInt, jsonBig:
+		return "string"
+	default:
+		return t.GoType
+	}
+}
+
+func (t FieldType) toJSONExpr(value string) string {
+	switch t.jsonKind() {
+	case jsonBytes:
+		return fmt.Sprintf("hexutil.Bytes(%s)", value)
+	case jsonInt:
+		return fmt.Sprintf("codec.FormatInt(%s)", value)
+	case jsonBig:
+		return fmt.Sprintf("codec.FormatBig(%s)", value)
+	default:
+		return value
+	}
+}
+
+// fromJSONStmt returns the go statement setting dst from its JSON
+// representation src, returning an error if src is not a valid value.
+func (t FieldType) fromJSONStmt(name, dst, src string) string {
+	switch t.jsonKind() {
+	case jsonBytes:
+		if t.Type == BytesType {
+			return fmt.Sprintf("%s = []byte(%s)", dst, src)
+		}
+		return fmt.Sprintf("if err := codec.CheckMaxLen(\"%s\", %d, %s); err != nil {\n\treturn err\n}\n%s = []byte(%s)", name, t.Size, src, dst, src)
+	case jsonInt:
+		return fmt.Sprintf("if %s, err = codec.ParseInt(%d, %s); err != nil {\n\treturn err\n}", dst, t.Size, src)
+	case jsonBig:
+		sign := "Uint"
+		if strings.HasPrefix(t.Name, "int") {
+			sign = "Int"
+		}
+		return fmt.Sprintf("if %s, err = codec.Parse%sBig(%d, %s); err != nil {\n\treturn err\n}", dst, sign, t.Size, src)
+	default:
+		return fmt.Sprintf("%s = %s", dst, src)
+	}
+}
+
+// ToJSONStmt returns the go statement setting dst to the JSON representation
+// of src.
+func (t FieldType) ToJSONStmt(dst, src string) string {
+	if t.Elem == nil || !t.jsonConverted() {
+		return fmt.Sprintf("%s = %s", dst, t.toJSONExpr(src))
+	}
+	loop := fmt.Sprintf("for ii, elem := range %s {\n\t%s[ii] = %s\n}", src, dst, t.Elem.toJSONExpr("elem"))
+	if t.Type == DynamicArrayType {
+		return fmt.Sprintf("%s = make(%s, len(%s))\n%s", dst, t.JSONType(), src, loop)
+	}
+	return loop
+}
+
+// FromJSONStmt returns the go statement setting dst from its JSON
+// representation src. Statements of signed integers assign an err variable
+// declared beforehand, see JSONNeedsErr.
+func (t FieldType) FromJSONStmt(name, dst, src string) string {
+	if t.Elem == nil || !t.jsonConverted() {
+		return t.fromJSONStmt(name, dst, src)
+	}
+	elem := strings.ReplaceAll(t.Elem.fromJSONStmt(name, dst+"[ii]", "elem"), "\n", "\n\t")
+	loop := fmt.Sprintf("for ii, elem := range %s {\n\t%s\n}", src, elem)
+	if t.Type == DynamicArrayType {
+		return fmt.Sprintf("%s = make(%s, len(%s))\n%s", dst, t.GoType, src, loop)
+	}
+	return loop
+}
+
+// jsonNeedsErr reports whether the statements of FromJSONStmt for the types
+// assign an err variable.
+func jsonNeedsErr(types []FieldType) bool {
+	for _, t := range types {
+		kind := t.jsonKind()
+		if t.Elem != nil && t.jsonConverted() {
+			kind = t.Elem.jsonKind()
+		}
+		if kind == jsonInt || kind == jsonBig {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONNeedsErr reports whether the UnmarshalJSON method of the values of the
+// table must declare an err variable.
+func (s TableSchema) JSONNeedsErr() bool {
+	var types []FieldType
+	for _, value := range s.RowValues() {
+		types = append(types, value.Type)
+	}
+	return jsonNeedsErr(types)
+}
+
+// JSONNeedsErr reports whether the UnmarshalJSON method of the struct must
+// declare an err variable.
+func (s *StructSchema) JSONNeedsErr() bool {
+	var types []FieldType
+	for _, member := range s.Members {
+		types = append(types, member.Type)
+	}
+	return jsonNeedsErr(types)
+}
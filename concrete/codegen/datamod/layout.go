@@ -0,0 +1,194 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/concrete/storage"
+)
+
+var slotAnnotationRegexp = regexp.MustCompile(`^(.*\S)\s+@slot\s+([0-9]+)$`)
+
+// splitSlotAnnotation splits a value type into its type and the slot it is
+// pinned to, e.g. `uint64 @slot 3`. The slot is -1 if the value is not pinned.
+func splitSlotAnnotation(typeStr string) (string, int, error) {
+	matches := slotAnnotationRegexp.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return typeStr, -1, nil
+	}
+	slot, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, err
+	}
+	return matches[1], slot, nil
+}
+
+// checkTableSlots returns an error if the slots of two tables overlap given
+// their base slot offsets. Keyless tables take the slots of their row from
+// their base slot and tables with keys only their base slot, as a solidity
+// mapping does. Slots wrap around at 2^256 as storage.OffsetSlot does.
+func checkTableSlots(schemas []TableSchema, pack bool) error {
+	type tableSlots struct {
+		name       string
+		start, end *big.Int
+	}
+	tables := make([]tableSlots, len(schemas))
+	for ii, schema := range schemas {
+		span := 1
+		if len(schema.Keys) == 0 {
+			sizes, pins := rowSizes(schema)
+			if slots := layoutSlots(sizes, rowLayout(sizes, pins, pack)); slots > span {
+				span = slots
+			}
+		}
+		start := storage.OffsetSlot(storage.TableSlot(formatTableName(schema.Name)), schema.BaseSlot).Big()
+		tables[ii] = tableSlots{lowerFirstLetter(schema.Name), start, new(big.Int).Add(start, big.NewInt(int64(span)))}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].start.Cmp(tables[j].start) < 0 })
+	for ii := 0; ii+1 < len(tables); ii++ {
+		if tables[ii].end.Cmp(tables[ii+1].start) > 0 {
+			return fmt.Errorf("invalid base slot schema for table '%s': its slots overlap those of table '%s'", tables[ii+1].name, tables[ii].name)
+		}
+	}
+	if len(tables) > 1 {
+		last, first := tables[len(tables)-1], tables[0]
+		wrapped := new(big.Int).Sub(last.end, new(big.Int).Lsh(big.NewInt(1), 256))
+		if wrapped.Cmp(first.start) > 0 {
+			return fmt.Errorf("invalid base slot schema for table '%s': its slots overlap those of table '%s'", first.name, last.name)
+		}
+	}
+	return nil
+}
+
+// slotSpan returns the number of slots taken by a field of the given size.
+func slotSpan(size int) int {
+	if size <= 32 {
+		return 1
+	}
+	return (size + 31) / 32
+}
+
+// checkPinnedSlots returns an error if two pinned values share a slot.
+func checkPinnedSlots(tableName string, values []FieldSchema) error {
+	owners := make(map[int]FieldSchema)
+	for _, value := range values {
+		if !value.Pinned {
+			continue
+		}
+		for slot := value.Slot; slot < value.Slot+slotSpan(value.Type.Size); slot++ {
+			if other, ok := owners[slot]; ok {
+				return fmt.Errorf("invalid slot schema for table '%s': values '%s' and '%s' both use slot %d", tableName, other.Name, value.Name, slot)
+			}
+			owners[slot] = value
+		}
+	}
+	return nil
+}
+
+// rowSizes returns the size of every field of a row and the slot it is pinned
+// to, or -1 if it is not pinned. Tables with optional values have one more
+// field after the values holding the presence bitmap, followed by one slot
+// for the hash of every hashed value and by the flag of initialized tables.
+func rowSizes(schema TableSchema) (sizes []int, pins []int) {
+	for _, field := range schema.Values {
+		sizes = append(sizes, field.Type.Size)
+		if field.Pinned {
+			pins = append(pins, field.Slot)
+		} else {
+			pins = append(pins, -1)
+		}
+	}
+	if schema.HasOptional() {
+		sizes = append(sizes, schema.PresenceSize())
+		pins = append(pins, -1)
+	}
+	for range schema.HashedValues() {
+		sizes = append(sizes, 32)
+		pins = append(pins, -1)
+	}
+	if schema.Initialized {
+		sizes = append(sizes, 1)
+		pins = append(pins, -1)
+	}
+	return sizes, pins
+}
+
+// rowLayout returns the byte offset of every field of a row. Fields with a
+// non-negative pin start at that slot and take all the slots they span. The
+// others are laid out in order into the remaining slots, following the same
+// rules as lib.NewDatastoreStruct. If pack is false every field starts at a
+// new slot instead of sharing the remaining space of the previous one.
+func rowLayout(sizes []int, pins []int, pack bool) []int {
+	pinned := make(map[int]bool)
+	for ii, pin := range pins {
+		if pin < 0 {
+			continue
+		}
+		for slot := pin; slot < pin+slotSpan(sizes[ii]); slot++ {
+			pinned[slot] = true
+		}
+	}
+
+	offsets := make([]int, len(sizes))
+	offset := 0
+	for ii, size := range sizes {
+		if pins[ii] >= 0 {
+			offsets[ii] = pins[ii] * 32
+			continue
+		}
+		for {
+			if size > 32 {
+				if offset%32 != 0 {
+					offset = (offset/32 + 1) * 32
+				}
+			} else if offset/32 != (offset+size-1)/32 {
+				offset = (offset/32 + 1) * 32
+			}
+			free := true
+			for slot := offset / 32; slot < offset/32+slotSpan(size); slot++ {
+				if pinned[slot] {
+					// Skip past the pinned slot and try again
+					offset = (slot + 1) * 32
+					free = false
+					break
+				}
+			}
+			if free {
+				break
+			}
+		}
+		offsets[ii] = offset
+		offset += size
+		if !pack {
+			offset = (offset + 31) / 32 * 32
+		}
+	}
+	return offsets
+}
+
+func intSliceLiteral(values []int) string {
+	strs := make([]string, len(values))
+	for ii, value := range values {
+		strs[ii] = fmt.Sprint(value)
+	}
+	return fmt.Sprintf("[]int{%s}", strings.Join(strs, ", "))
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSlotAnnotation(t *testing.T) {
+	r := require.New(t)
+
+	typeStr, slot, err := splitSlotAnnotation("uint64 @slot 3")
+	r.NoError(err)
+	r.Equal("uint64", typeStr)
+	r.Equal(3, slot)
+
+	typeStr, slot, err = splitSlotAnnotation(`address gotype:"example.com/x.ID"  @slot  12`)
+	r.NoError(err)
+	r.Equal(`address gotype:"example.com/x.ID"`, typeStr)
+	r.Equal(12, slot)
+
+	typeStr, slot, err = splitSlotAnnotation("uint64")
+	r.NoError(err)
+	r.Equal("uint64", typeStr)
+	r.Equal(-1, slot)
+}
+
+func TestRowLayout(t *testing.T) {
+	r := require.New(t)
+
+	// Without pins fields are packed in order
+	r.Equal([]int{0, 8, 32, 64, 84}, rowLayout([]int{8, 20, 32, 20, 1}, []int{-1, -1, -1, -1, -1}, true))
+	r.Equal([]int{0, 32, 96}, rowLayout([]int{1, 64, 1}, []int{-1, -1, -1}, true))
+
+	// Unpinned fields skip pinned slots, including all the slots spanned by
+	// multi-slot fields
+	r.Equal([]int{32, 0, 40}, rowLayout([]int{8, 8, 8}, []int{-1, 0, -1}, true))
+	r.Equal([]int{64, 0, 72}, rowLayout([]int{8, 64, 8}, []int{-1, 0, -1}, true))
+	r.Equal([]int{0, 32, 8}, rowLayout([]int{8, 64, 8}, []int{-1, 1, -1}, true))
+	r.Equal([]int{0, 128, 8}, rowLayout([]int{8, 8, 8}, []int{-1, 4, -1}, true))
+
+	// Without packing every field starts at a new slot
+	r.Equal([]int{0, 32, 64, 96, 128}, rowLayout([]int{8, 20, 32, 20, 1}, []int{-1, -1, -1, -1, -1}, false))
+	r.Equal([]int{0, 32, 96}, rowLayout([]int{1, 64, 1}, []int{-1, -1, -1}, false))
+	r.Equal([]int{32, 0, 64}, rowLayout([]int{8, 8, 8}, []int{-1, 0, -1}, false))
+}
+
+func TestCheckPinnedSlots(t *testing.T) {
+	r := require.New(t)
+	field := func(name string, typeStr string, slot int) FieldSchema {
+		f, err := newFieldSchema(name, 0, typeStr)
+		r.NoError(err)
+		f.Pinned, f.Slot = slot >= 0, slot
+		return f
+	}
+
+	r.NoError(checkPinnedSlots("t", []FieldSchema{field("a", "uint8", 0), field("b", "uint8", 1), field("c", "uint8", -1)}))
+	r.NoError(checkPinnedSlots("t", []FieldSchema{field("a", "uint64[8]", 0), field("b", "uint8", 2)}))
+	r.ErrorContains(checkPinnedSlots("t", []FieldSchema{field("a", "uint64[8]", 0), field("b", "uint8", 1)}), "values 'a' and 'b' both use slot 1")
+}
+
+func TestCheckTableSlots(t *testing.T) {
+	r := require.New(t)
+	tables := func(schema string) []TableSchema {
+		schemas, err := UnmarshalTableSchemas([]byte(schema), false)
+		r.NoError(err)
+		return schemas
+	}
+
+	// Offsets are what tell the tables apart when all names hash to the same
+	// base slot
+	defer func(hasher storage.Hasher) { storage.DefaultHasher = hasher }(storage.DefaultHasher)
+	storage.DefaultHasher = storage.HasherFunc(func(data ...[]byte) common.Hash { return common.Hash{} })
+	r.NoError(checkTableSlots(tables(`{"a": {"schema": {"x": "uint256", "y": "uint256"}}, "b": {"baseSlot": 2, "keySchema": {"k": "uint64"}, "schema": {"v": "uint8"}}}`), true))
+	r.ErrorContains(checkTableSlots(tables(`{"a": {"schema": {"x": "uint256", "y": "uint256"}}, "b": {"baseSlot": 1, "keySchema": {"k": "uint64"}, "schema": {"v": "uint8"}}}`), true), "invalid base slot schema for table 'b': its slots overlap those of table 'a'")
+	r.ErrorContains(checkTableSlots(tables(`{"a": {"schema": {"x": "uint8", "y": "uint8"}}, "b": {"baseSlot": 1, "schema": {"v": "uint8"}}}`), false), "its slots overlap those of table 'a'")
+	r.NoError(checkTableSlots(tables(`{"a": {"schema": {"x": "uint8", "y": "uint8"}}, "b": {"baseSlot": 1, "schema": {"v": "uint8"}}}`), true))
+
+	// The row of a keyless table at the last slot wraps around to the first
+	storage.DefaultHasher = storage.HasherFunc(func(data ...[]byte) common.Hash { return common.MaxHash })
+	r.ErrorContains(checkTableSlots(tables(`{"a": {"schema": {"x": "uint256", "y": "uint256"}}, "b": {"baseSlot": 1, "keySchema": {"k": "uint64"}, "schema": {"v": "uint8"}}}`), true), "invalid base slot schema for table 'b': its slots overlap those of table 'a'")
+	r.NoError(checkTableSlots(tables(`{"a": {"schema": {"x": "uint256", "y": "uint256"}}, "b": {"baseSlot": 2, "keySchema": {"k": "uint64"}, "schema": {"v": "uint8"}}}`), true))
+	r.NoError(checkTableSlots(tables(`{"a": {"baseSlot": "0xffffffffffffffff", "schema": {"x": "uint256"}}}`), true))
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LintWarning is a suggestion to make the storage of a table cheaper.
+type LintWarning struct {
+	Table string
+	// Value is the name of the value the warning is about, empty if it is
+	// about the whole row.
+	Value   string
+	Message string
+}
+
+func (w LintWarning) String() string {
+	if w.Value == "" {
+		return fmt.Sprintf("table '%s': %s", w.Table, w.Message)
+	}
+	return fmt.Sprintf("table '%s', value '%s': %s", w.Table, w.Value, w.Message)
+}
+
+// lintNameHints are the parts of value names suggesting the value is a
+// timestamp, a block number or a counter, for which 64 bits are enough.
+var lintNameHints = []string{"time", "block", "count", "nonce", "index", "deadline", "expir"}
+
+// LintSchemas returns suggestions to make the storage of the tables cheaper:
+// values that would use fewer slots if declared in another order, capped bytes
+// that fit a fixed size bytes type, and integers wider than 64 bits for values
+// named like timestamps or counters. Layouts are computed with or without
+// packing as the generated tables. Following a suggestion changes the layout
+// of the table, which moves the rows of deployed tables, see PlanMigration.
+func LintSchemas(schemas []TableSchema, pack bool) []LintWarning {
+	var warnings []LintWarning
+	for _, schema := range schemas {
+		table := lowerFirstLetter(schema.Name)
+		if pack {
+			if order, slots, better := betterValueOrder(schema); better < slots {
+				warnings = append(warnings, LintWarning{
+					Table:   table,
+					Message: fmt.Sprintf("values use %d slots, declaring them in the order %s would use %d", slots, strings.Join(order, ", "), better),
+				})
+			}
+		}
+		for _, value := range schema.Values {
+			fieldType := value.Type
+			switch {
+			case fieldType.Name == "bytes" && value.MaxLen > 0 && value.MaxLen <= 32:
+				warnings = append(warnings, LintWarning{
+					Table:   table,
+					Value:   value.Name,
+					Message: fmt.Sprintf("bytes with a maximum length of %d could be bytes%d, which is packed with the other values instead of taking a slot and a length", value.MaxLen, value.MaxLen),
+				})
+			case fieldType.Type == ValueType && fieldType.Elem == nil && fieldType.GoTypeOverride == nil &&
+				integerTypeRegexp.MatchString(fieldType.Name) && fieldType.Size > 8 && hasLintNameHint(value.Name):
+				sign := "uint"
+				if strings.HasPrefix(fieldType.Name, "int") {
+					sign = "int"
+				}
+				warnings = append(warnings, LintWarning{
+					Table:   table,
+					Value:   value.Name,
+					Message: fmt.Sprintf("%s%d is wider than needed for timestamps, block numbers and counters, %s64 would pack with other values", sign, fieldType.Size*8, sign),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+func hasLintNameHint(name string) bool {
+	lower := strings.ToLower(name)
+	for _, hint := range lintNameHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	// Timestamps such as createdAt
+	return strings.HasSuffix(name, "At")
+}
+
+// layoutSlots returns the number of slots used by fields of the given sizes at
+// the given offsets.
+func layoutSlots(sizes, offsets []int) int {
+	slots := 0
+	for ii, size := range sizes {
+		if end := (offsets[ii] + size + 31) / 32; end > slots {
+			slots = end
+		}
+	}
+	return slots
+}
+
+// betterValueOrder returns the value names of a table in the order filling
+// slots first fit by decreasing size, along with the number of slots used by
+// the row as declared and in that order. Tables with pinned values are left as
+// they are.
+func betterValueOrder(schema TableSchema) ([]string, int, int) {
+	sizes, pins := rowSizes(schema)
+	slots := layoutSlots(sizes, rowLayout(sizes, pins, true))
+	if schema.HasPinnedSlots() || len(schema.Values) < 2 {
+		return nil, slots, slots
+	}
+
+	indices := make([]int, len(schema.Values))
+	for ii := range indices {
+		indices[ii] = ii
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return sizes[indices[a]] > sizes[indices[b]]
+	})
+	// Values of at least a slot fill their own bins
+	type bin struct {
+		free    int
+		members []int
+	}
+	var bins []*bin
+	for _, index := range indices {
+		size := sizes[index]
+		var target *bin
+		for _, b := range bins {
+			if b.free >= size {
+				target = b
+				break
+			}
+		}
+		if target == nil {
+			target = &bin{free: 32 * slotSpan(size)}
+			bins = append(bins, target)
+		}
+		target.free -= size
+		target.members = append(target.members, index)
+	}
+
+	var order []string
+	var reordered []int
+	for _, b := range bins {
+		for _, index := range b.members {
+			order = append(order, schema.Values[index].Name)
+			reordered = append(reordered, sizes[index])
+		}
+	}
+	// The presence bitmap, hashes and initialized flag stay after the values
+	reordered = append(reordered, sizes[len(schema.Values):]...)
+	return order, slots, layoutSlots(reordered, rowLayout(reordered, pins, true))
+}
+
+// Lint loads the schema file of config and returns the suggestions of
+// LintSchemas for its tables, without generating anything.
+func Lint(config Config, allowTableTypes bool) ([]LintWarning, error) {
+	schemas, _, err := loadSchemaFile(config.SchemaFilePath, config.Format, allowTableTypes)
+	if err != nil {
+		return nil, err
+	}
+	return LintSchemas(schemas, !config.DisablePacking), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const lintSchema = `{
+    "scattered": {
+        "schema": {
+            "a": "uint128",
+            "b": "uint256",
+            "c": "uint128"
+        }
+    },
+    "packed": {
+        "schema": {
+            "a": "uint128",
+            "c": "uint64",
+            "e": "uint64",
+            "b": "uint256"
+        }
+    },
+    "pinned": {
+        "schema": {
+            "a": "uint128",
+            "b": "uint256 @slot 1",
+            "c": "uint128"
+        }
+    },
+    "wide": {
+        "schema": {
+            "data": "bytes maxlen:\"20\"",
+            "blob": "bytes maxlen:\"64\"",
+            "createdAt": "uint256",
+            "nonce": "int128",
+            "amount": "uint256"
+        }
+    }
+}`
+
+func TestLintSchemas(t *testing.T) {
+	r := require.New(t)
+	schemas, err := UnmarshalTableSchemas([]byte(lintSchema), false)
+	r.NoError(err)
+
+	warnings := make(map[string][]string)
+	for _, warning := range LintSchemas(schemas, true) {
+		warnings[warning.Table] = append(warnings[warning.Table], warning.String())
+	}
+	r.Equal([]string{"table 'scattered': values use 3 slots, declaring them in the order b, a, c would use 2"}, warnings["scattered"])
+	r.Empty(warnings["packed"])
+	r.Empty(warnings["pinned"])
+	r.Equal([]string{
+		"table 'wide', value 'data': bytes with a maximum length of 20 could be bytes20, which is packed with the other values instead of taking a slot and a length",
+		"table 'wide', value 'createdAt': uint256 is wider than needed for timestamps, block numbers and counters, uint64 would pack with other values",
+		"table 'wide', value 'nonce': int128 is wider than needed for timestamps, block numbers and counters, int64 would pack with other values",
+	}, warnings["wide"])
+
+	// Without packing values use a slot each in any order
+	for _, warning := range LintSchemas(schemas, false) {
+		r.NotEqual("scattered", warning.Table)
+	}
+}
+
+func TestLint(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-lint"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	r.NoError(os.WriteFile(schemaPath, []byte(lintSchema), 0644))
+
+	warnings, err := Lint(Config{SchemaFilePath: schemaPath}, false)
+	r.NoError(err)
+	r.Len(warnings, 4)
+	// Nothing is generated
+	entries, err := os.ReadDir(tmpDir)
+	r.NoError(err)
+	r.Len(entries, 1)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+// accessorArg is an argument or return value of an accessor method.
+type accessorArg struct {
+	Name string
+	Type FieldType
+}
+
+// accessorMethod describes a getter or setter of the generated go code as a
+// contract method taking the table keys as leading arguments.
+type accessorMethod struct {
+	Name    string
+	Inputs  []accessorArg
+	Outputs []accessorArg
+	IsView  bool
+}
+
+func accessorArgs(fields []FieldSchema) []accessorArg {
+	args := make([]accessorArg, 0, len(fields))
+	for _, field := range fields {
+		args = append(args, accessorArg{Name: field.Name, Type: field.Type})
+	}
+	return args
+}
+
+func withArgs(args []accessorArg, extra ...accessorArg) []accessorArg {
+	return append(append([]accessorArg{}, args...), extra...)
+}
+
+// accessorMethods returns the accessor methods of a table in declaration
+// order. Table values cannot be represented in contract methods and are
+// skipped.
+func accessorMethods(schema TableSchema) []accessorMethod {
+	var values []FieldSchema
+	for _, value := range schema.Values {
+		if value.Type.Type != TableType {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	keyArgs := accessorArgs(schema.Keys)
+	valueArgs := accessorArgs(values)
+
+	methods := []accessorMethod{
+		{Name: "get", Inputs: keyArgs, Outputs: valueArgs, IsView: true},
+		{Name: "set", Inputs: withArgs(keyArgs, valueArgs...)},
+	}
+	for _, value := range values {
+		getOutputs := []accessorArg{{Type: value.Type}}
+		if value.Optional {
+			// Optional getters also return whether the value is set
+			boolType, _ := nameToFieldType("bool")
+			getOutputs = append(getOutputs, accessorArg{Type: boolType})
+		}
+		methods = append(methods,
+			accessorMethod{Name: "get" + value.Title, Inputs: keyArgs, Outputs: getOutputs, IsView: true},
+			accessorMethod{Name: "set" + value.Title, Inputs: withArgs(keyArgs, accessorArg{Name: "value", Type: value.Type})},
+		)
+		if value.Optional {
+			methods = append(methods, accessorMethod{Name: "clear" + value.Title, Inputs: keyArgs})
+		}
+	}
+	return methods
+}
@@ -0,0 +1,662 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// FieldChange is the way a value of a table changes between two schemas.
+type FieldChange string
+
+const (
+	FieldUnchanged FieldChange = "unchanged"
+	FieldMoved     FieldChange = "moved"
+	FieldWidened   FieldChange = "widened"
+	FieldShrunk    FieldChange = "shrunk"
+	FieldRetyped   FieldChange = "retyped"
+	FieldAdded     FieldChange = "added"
+	FieldRemoved   FieldChange = "removed"
+)
+
+// TableChange is the way a table changes between two schemas.
+type TableChange string
+
+const (
+	TableUnchanged TableChange = "unchanged"
+	TableChanged   TableChange = "changed"
+	TableAdded     TableChange = "added"
+	TableRemoved   TableChange = "removed"
+)
+
+// FieldMigration describes how a value moves between the row layouts of two
+// schemas. Offsets are in bytes from the start of the row and are -1 if the
+// value is absent from one of the schemas.
+type FieldMigration struct {
+	Name      string
+	Change    FieldChange
+	OldType   string
+	NewType   string
+	OldOffset int
+	NewOffset int
+	// Destructive changes lose the stored value, which the generated
+	// migration does not carry over. Reason tells why it must be reviewed.
+	Destructive bool
+	Reason      string
+
+	oldField *FieldSchema
+	newField *FieldSchema
+}
+
+// TableMigration describes how the rows of a table change between two
+// schemas.
+type TableMigration struct {
+	Name   string
+	Change TableChange
+	Fields []FieldMigration
+	// Reasons lists the changes to the table itself that must be reviewed,
+	// e.g. changed keys.
+	Reasons []string
+	// RowsMoved is set when rows are stored at new slots, e.g. because the
+	// keys changed, so they cannot be migrated in place.
+	RowsMoved bool
+
+	oldSchema  TableSchema
+	newSchema  TableSchema
+	oldOffsets []int
+	newOffsets []int
+}
+
+// Destructive reports whether the table, or any of its values, changes in a
+// way that needs manual review.
+func (m TableMigration) Destructive() bool {
+	if len(m.Reasons) > 0 {
+		return true
+	}
+	for _, field := range m.Fields {
+		if field.Destructive {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrationPlan describes the changes between two schemas, table by table.
+type MigrationPlan struct {
+	Tables []TableMigration
+}
+
+// Destructive reports whether any table in the plan needs manual review.
+func (p MigrationPlan) Destructive() bool {
+	for _, table := range p.Tables {
+		if table.Destructive() {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanMigration compares the row layouts of two schemas, matching tables and
+// values by name. Renamed tables and values show up as removed and added.
+// pack must be false if the tables were generated with packing disabled.
+func PlanMigration(oldSchemas, newSchemas []TableSchema, pack bool) MigrationPlan {
+	var plan MigrationPlan
+	oldByName := make(map[string]TableSchema)
+	for _, schema := range oldSchemas {
+		oldByName[schema.Name] = schema
+	}
+	newByName := make(map[string]bool)
+	for _, schema := range newSchemas {
+		newByName[schema.Name] = true
+		oldSchema, ok := oldByName[schema.Name]
+		if !ok {
+			plan.Tables = append(plan.Tables, TableMigration{Name: schema.Name, Change: TableAdded, newSchema: schema})
+			continue
+		}
+		plan.Tables = append(plan.Tables, planTableMigration(oldSchema, schema, pack))
+	}
+	for _, schema := range oldSchemas {
+		if newByName[schema.Name] {
+			continue
+		}
+		plan.Tables = append(plan.Tables, TableMigration{
+			Name:      schema.Name,
+			Change:    TableRemoved,
+			Reasons:   []string{"the table is removed, its rows are left in storage"},
+			oldSchema: schema,
+		})
+	}
+	return plan
+}
+
+func planTableMigration(oldSchema, newSchema TableSchema, pack bool) TableMigration {
+	migration := TableMigration{
+		Name:       newSchema.Name,
+		Change:     TableUnchanged,
+		oldSchema:  oldSchema,
+		newSchema:  newSchema,
+		oldOffsets: schemaLayout(oldSchema, pack),
+		newOffsets: schemaLayout(newSchema, pack),
+	}
+
+	if !sameKeys(oldSchema, newSchema) {
+		migration.RowsMoved = true
+		migration.Reasons = append(migration.Reasons, fmt.Sprintf("the keys change from %s to %s, rows are stored at new slots and must be copied manually", describeKeys(oldSchema), describeKeys(newSchema)))
+	}
+	if !oldSchema.Iterable && newSchema.Iterable {
+		migration.Reasons = append(migration.Reasons, "the table becomes iterable, the index of existing rows must be built manually")
+	} else if oldSchema.Iterable && !newSchema.Iterable {
+		migration.Reasons = append(migration.Reasons, "the table is no longer iterable, its index is left in storage")
+	} else if oldSchema.Iterable && oldSchema.KeyHashOrder != newSchema.KeyHashOrder {
+		migration.Reasons = append(migration.Reasons, "the iteration order of the table changes, the index of existing rows must be rebuilt manually")
+	}
+
+	if oldSchema.BaseSlot != newSchema.BaseSlot {
+		migration.RowsMoved = true
+		migration.Reasons = append(migration.Reasons, fmt.Sprintf("the base slot offset changes from %d to %d, rows are stored at new slots and must be copied manually", oldSchema.BaseSlot, newSchema.BaseSlot))
+	}
+	if !oldSchema.Initialized && newSchema.Initialized {
+		migration.Reasons = append(migration.Reasons, "the table becomes initialized, existing rows must be created manually")
+	} else if oldSchema.Initialized && !newSchema.Initialized {
+		migration.Reasons = append(migration.Reasons, "the table is no longer initialized, the flags of created rows are left in storage")
+	} else if oldSchema.Initialized && migration.oldOffsets[oldSchema.ExistsIndex()] != migration.newOffsets[newSchema.ExistsIndex()] {
+		migration.Reasons = append(migration.Reasons, "the flag of created rows moves, it must be copied manually")
+	}
+
+	newValues := make(map[string]bool)
+	for ii := range newSchema.Values {
+		newField := &newSchema.Values[ii]
+		newValues[newField.Name] = true
+		oldField := findValue(oldSchema, newField.Name)
+		if oldField == nil {
+			migration.Fields = append(migration.Fields, FieldMigration{
+				Name:      newField.Name,
+				Change:    FieldAdded,
+				NewType:   newField.Type.Name,
+				OldOffset: -1,
+				NewOffset: migration.newOffsets[newField.Index],
+				newField:  newField,
+			})
+			continue
+		}
+		migration.Fields = append(migration.Fields, planFieldMigration(oldField, newField, migration.oldOffsets[oldField.Index], migration.newOffsets[newField.Index]))
+	}
+	for ii := range oldSchema.Values {
+		oldField := &oldSchema.Values[ii]
+		if newValues[oldField.Name] {
+			continue
+		}
+		migration.Fields = append(migration.Fields, FieldMigration{
+			Name:        oldField.Name,
+			Change:      FieldRemoved,
+			OldType:     oldField.Type.Name,
+			OldOffset:   migration.oldOffsets[oldField.Index],
+			NewOffset:   -1,
+			Destructive: true,
+			Reason:      "the value is removed, if it was renamed it must be copied manually",
+			oldField:    oldField,
+		})
+	}
+
+	if len(migration.Reasons) > 0 || presenceChanged(oldSchema, newSchema, migration.oldOffsets, migration.newOffsets) || hashesChanged(oldSchema, newSchema, migration.oldOffsets, migration.newOffsets) {
+		migration.Change = TableChanged
+	}
+	for _, field := range migration.Fields {
+		if field.Change != FieldUnchanged {
+			migration.Change = TableChanged
+		}
+	}
+	return migration
+}
+
+func planFieldMigration(oldField, newField *FieldSchema, oldOffset, newOffset int) FieldMigration {
+	migration := FieldMigration{
+		Name:      newField.Name,
+		Change:    FieldUnchanged,
+		OldType:   oldField.Type.Name,
+		NewType:   newField.Type.Name,
+		OldOffset: oldOffset,
+		NewOffset: newOffset,
+		oldField:  oldField,
+		newField:  newField,
+	}
+	oldType, newType := oldField.Type, newField.Type
+	retyped := func() FieldMigration {
+		migration.Change = FieldRetyped
+		migration.Destructive = true
+		migration.Reason = fmt.Sprintf("the type changes from %s to %s, the value must be converted manually", oldType.Name, newType.Name)
+		return migration
+	}
+
+	if oldType.Type != newType.Type {
+		return retyped()
+	}
+	switch newType.Type {
+	case TableType:
+		if oldType.Name != newType.Name {
+			return retyped()
+		}
+		// Nested tables are rooted at the index of the value, not its offset
+		if oldField.Index != newField.Index {
+			migration.Change = FieldMoved
+			migration.Destructive = true
+			migration.Reason = fmt.Sprintf("the index of the table changes from %d to %d, its rows must be moved manually", oldField.Index, newField.Index)
+		}
+		return migration
+	case DynamicArrayType:
+		if !sameEncoding(oldType, newType) {
+			return retyped()
+		}
+		if oldOffset != newOffset {
+			migration.Change = FieldMoved
+			migration.Destructive = true
+			migration.Reason = "the elements of the array are rooted at the slot of the value, they must be moved manually"
+		}
+		return migration
+	}
+
+	if !sameEncoding(oldType, newType) {
+		oldKind, newKind := resizableKind(oldType), resizableKind(newType)
+		if oldKind == "" || oldKind != newKind {
+			return retyped()
+		}
+		if newType.Size < oldType.Size {
+			migration.Change = FieldShrunk
+			migration.Destructive = true
+			migration.Reason = fmt.Sprintf("the width shrinks from %d to %d bytes, the value must be converted manually", oldType.Size, newType.Size)
+			return migration
+		}
+		if newType.Size > oldType.Size {
+			migration.Change = FieldWidened
+			return migration
+		}
+		// Aliases of the same type, e.g. uint and uint256
+	}
+	if oldOffset != newOffset {
+		migration.Change = FieldMoved
+	}
+	return migration
+}
+
+// schemaLayout returns the byte offset of every field of a row of the table,
+// including its presence bitmap.
+func schemaLayout(schema TableSchema, pack bool) []int {
+	sizes, pins := rowSizes(schema)
+	return rowLayout(sizes, pins, pack)
+}
+
+func findValue(schema TableSchema, name string) *FieldSchema {
+	for ii := range schema.Values {
+		if schema.Values[ii].Name == name {
+			return &schema.Values[ii]
+		}
+	}
+	return nil
+}
+
+func sameKeys(a, b TableSchema) bool {
+	if len(a.Keys) != len(b.Keys) || a.CompositeKey != b.CompositeKey || a.PaddedKeys != b.PaddedKeys {
+		return false
+	}
+	for ii := range a.Keys {
+		if !sameEncoding(a.Keys[ii].Type, b.Keys[ii].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeKeys(schema TableSchema) string {
+	keys := make([]string, len(schema.Keys))
+	for ii, key := range schema.Keys {
+		keys[ii] = key.Name + " " + key.Type.Name
+	}
+	desc := "(" + strings.Join(keys, ", ") + ")"
+	if schema.CompositeKey {
+		desc += " composite"
+	}
+	if schema.PaddedKeys {
+		desc += " padded"
+	}
+	return desc
+}
+
+// presenceChanged reports whether the presence bitmap of the rows of a table
+// moves, or whether any value kept by both schemas changes presence bit.
+func presenceChanged(oldSchema, newSchema TableSchema, oldOffsets, newOffsets []int) bool {
+	if oldSchema.HasOptional() != newSchema.HasOptional() {
+		return true
+	}
+	if !newSchema.HasOptional() {
+		return false
+	}
+	if oldSchema.PresenceSize() != newSchema.PresenceSize() || oldOffsets[oldSchema.PresenceIndex()] != newOffsets[newSchema.PresenceIndex()] {
+		return true
+	}
+	for _, newField := range newSchema.Values {
+		oldField := findValue(oldSchema, newField.Name)
+		if oldField == nil {
+			continue
+		}
+		if oldField.Optional != newField.Optional || oldField.Optional && oldField.PresenceBit != newField.PresenceBit {
+			return true
+		}
+	}
+	return false
+}
+
+// hashesChanged reports whether the hashes of the hashed values of a table are
+// added, removed or moved, so they must be written again.
+func hashesChanged(oldSchema, newSchema TableSchema, oldOffsets, newOffsets []int) bool {
+	oldHashed, newHashed := oldSchema.HashedValues(), newSchema.HashedValues()
+	if len(oldHashed) != len(newHashed) {
+		return true
+	}
+	for ii := range newHashed {
+		if oldHashed[ii].Name != newHashed[ii].Name || oldOffsets[oldSchema.HashIndex(oldHashed[ii])] != newOffsets[newSchema.HashIndex(newHashed[ii])] {
+			return true
+		}
+	}
+	return false
+}
+
+// sameEncoding reports whether values of both types are stored the same way.
+func sameEncoding(a, b FieldType) bool {
+	if a.Name != b.Name || a.Type != b.Type || a.Size != b.Size || a.LittleEndian != b.LittleEndian || a.RightAligned != b.RightAligned || a.ArrayLength != b.ArrayLength {
+		return false
+	}
+	if (a.Elem == nil) != (b.Elem == nil) || a.Elem != nil && !sameEncoding(*a.Elem, *b.Elem) {
+		return false
+	}
+	if (a.Enum == nil) != (b.Enum == nil) || a.Enum != nil && !a.Enum.Equal(b.Enum) {
+		return false
+	}
+	if (a.Struct == nil) != (b.Struct == nil) || a.Struct != nil && !a.Struct.Equal(b.Struct) {
+		return false
+	}
+	if (a.Flags == nil) != (b.Flags == nil) || a.Flags != nil && !a.Flags.Equal(b.Flags) {
+		return false
+	}
+	return true
+}
+
+var resizableTypeRegexp = regexp.MustCompile(`^(uint|int|bytes|string)[0-9]*$`)
+
+// resizableKind returns uint, int, bytes or string for big-endian integers
+// and left aligned inline byte strings, whose width can change without changing the
+// meaning of the value, and an empty string for other types.
+func resizableKind(t FieldType) string {
+	if t.Type != ValueType || t.LittleEndian || t.RightAligned || t.Elem != nil || t.Enum != nil || t.Struct != nil || t.Fixed != nil || t.Flags != nil {
+		return ""
+	}
+	matches := resizableTypeRegexp.FindStringSubmatch(t.Name)
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
+func formatOffset(typeName string, offset int) string {
+	if offset < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s @ %d:%d", typeName, offset/32, offset%32)
+}
+
+// Report describes the plan in a human readable form. Offsets are written as
+// slot:byte relative to the base slot of the row.
+func (p MigrationPlan) Report() string {
+	var buf bytes.Buffer
+	destructive := 0
+	for _, table := range p.Tables {
+		fmt.Fprintf(&buf, "table %s: %s\n", table.Name, table.Change)
+		if len(table.Fields) > 0 && table.Change != TableUnchanged {
+			w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+			for _, field := range table.Fields {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t-> %s\n", field.Name, field.Change, formatOffset(field.OldType, field.OldOffset), formatOffset(field.NewType, field.NewOffset))
+			}
+			w.Flush()
+		}
+		for _, reason := range table.Reasons {
+			fmt.Fprintf(&buf, "  REVIEW: %s\n", reason)
+			destructive++
+		}
+		for _, field := range table.Fields {
+			if field.Destructive {
+				fmt.Fprintf(&buf, "  REVIEW: %s: %s\n", field.Name, field.Reason)
+				destructive++
+			}
+		}
+	}
+	if destructive > 0 {
+		fmt.Fprintf(&buf, "\n%d destructive changes require manual review.\n", destructive)
+	} else {
+		fmt.Fprintf(&buf, "\nNo destructive changes.\n")
+	}
+	return buf.String()
+}
+
+//go:embed migration.tpl
+var migrationTpl string
+
+// migrationFunc holds the statements of the generated function migrating a
+// row of a table in place.
+type migrationFunc struct {
+	Name       string
+	Review     []string
+	OldSizes   string
+	OldOffsets string
+	NewSizes   string
+	NewOffsets string
+	Reads      []string
+	Clears     []string
+	Writes     []string
+}
+
+func newMigrationFunc(table TableMigration) (migrationFunc, bool) {
+	oldSizes, _ := rowSizes(table.oldSchema)
+	newSizes, _ := rowSizes(table.newSchema)
+	fn := migrationFunc{
+		Name:       formatTableName(table.Name),
+		OldSizes:   intSliceLiteral(oldSizes),
+		OldOffsets: intSliceLiteral(table.oldOffsets),
+		NewSizes:   intSliceLiteral(newSizes),
+		NewOffsets: intSliceLiteral(table.newOffsets),
+	}
+	fn.Review = append(fn.Review, table.Reasons...)
+	usesSignExtend := false
+
+	for _, field := range table.Fields {
+		if field.Destructive {
+			fn.Review = append(fn.Review, field.Name+": "+field.Reason)
+		}
+		if field.oldField == nil {
+			continue
+		}
+		oldIndex := field.oldField.Index
+		// Clear the data stored outside of the row like Delete does, values
+		// that are carried over are written again below
+		switch field.oldField.Type.Type {
+		case BytesType:
+			fn.Clears = append(fn.Clears, fmt.Sprintf("oldRow.GetField_slot(%d).ClearBytes()", oldIndex))
+		case DynamicArrayType:
+			if field.Destructive || field.newField == nil {
+				fn.Clears = append(fn.Clears, fmt.Sprintf("oldRow.GetField_slot(%d).ContiguousArray().Clear()", oldIndex))
+			}
+		}
+		if field.newField == nil || field.Destructive {
+			continue
+		}
+
+		newIndex := field.newField.Index
+		name := lowerFirstLetter(field.Name) + "Data"
+		switch field.newField.Type.Type {
+		case TableType:
+		case BytesType:
+			fn.Reads = append(fn.Reads, fmt.Sprintf("%s := oldRow.GetField_bytes(%d)", name, oldIndex))
+			fn.Writes = append(fn.Writes, fmt.Sprintf("newRow.SetField_bytes(%d, %s)", newIndex, name))
+			if field.newField.Hashed {
+				fn.Writes = append(fn.Writes, fmt.Sprintf("newRow.SetField(%d, crypto.Keccak256(%s))", table.newSchema.HashIndex(*field.newField), name))
+			}
+		case DynamicArrayType:
+			// The length of the array is stored in the slot of the value
+			fn.Reads = append(fn.Reads, fmt.Sprintf("%s := oldRow.GetField_slot(%d).Bytes32()", name, oldIndex))
+			fn.Writes = append(fn.Writes, fmt.Sprintf("newRow.GetField_slot(%d).SetBytes32(%s)", newIndex, name))
+		default:
+			fn.Reads = append(fn.Reads, fmt.Sprintf("%s := oldRow.GetField(%d)", name, oldIndex))
+			value := name
+			if field.Change == FieldWidened {
+				size := field.newField.Type.Size
+				switch resizableKind(field.newField.Type) {
+				case "uint":
+					value = fmt.Sprintf("common.LeftPadBytes(%s, %d)", name, size)
+				case "int":
+					value = fmt.Sprintf("signExtend(%s, %d)", name, size)
+					usesSignExtend = true
+				default:
+					value = fmt.Sprintf("common.RightPadBytes(%s, %d)", name, size)
+				}
+			}
+			fn.Writes = append(fn.Writes, fmt.Sprintf("newRow.SetField(%d, %s)", newIndex, value))
+		}
+	}
+
+	oldSchema, newSchema := table.oldSchema, table.newSchema
+	if newSchema.HasOptional() {
+		if oldSchema.HasOptional() {
+			fn.Reads = append(fn.Reads, fmt.Sprintf("oldPresence := oldRow.GetField(%d)", oldSchema.PresenceIndex()))
+		}
+		fn.Writes = append(fn.Writes, fmt.Sprintf("newPresence := make([]byte, %d)", newSchema.PresenceSize()))
+		for _, field := range table.Fields {
+			if field.newField == nil || field.oldField == nil || field.Destructive || !field.newField.Optional {
+				continue
+			}
+			set := fmt.Sprintf("newPresence[%d] |= %s", field.newField.PresenceByte(), field.newField.PresenceFlag())
+			if field.oldField.Optional {
+				set = fmt.Sprintf("if oldPresence[%d]&%s != 0 {\n\t\t%s\n\t}", field.oldField.PresenceByte(), field.oldField.PresenceFlag(), set)
+			}
+			fn.Writes = append(fn.Writes, set)
+		}
+		fn.Writes = append(fn.Writes, fmt.Sprintf("newRow.SetField(%d, newPresence)", newSchema.PresenceIndex()))
+	}
+	return fn, usesSignExtend
+}
+
+// MigrationConfig configures GenerateMigration.
+type MigrationConfig struct {
+	OldSchemaFilePath string
+	NewSchemaFilePath string
+	// Format is the format of both schema files, FormatDSL if empty.
+	Format  string
+	OutDir  string
+	Package string
+	// DisablePacking must be set if the tables of both schemas are generated
+	// with packing disabled.
+	DisablePacking bool
+	// GoCode enables generating a function per changed table moving the
+	// values of a row from the old layout to the new one.
+	GoCode bool
+}
+
+// MigrationReportFile and MigrationCodeFile are the names of the files written
+// to the output directory by GenerateMigration.
+const (
+	MigrationReportFile = "migration.txt"
+	MigrationCodeFile   = "migration.go"
+)
+
+// GenerateMigration compares the row layouts of two schemas and writes the
+// migration plan report to the output directory, along with the migration
+// code if enabled. The plan is returned so callers can refuse destructive
+// changes.
+func GenerateMigration(config MigrationConfig, allowTableTypes bool) (MigrationPlan, error) {
+	if config.GoCode && !isValidName(config.Package) {
+		return MigrationPlan{}, fmt.Errorf("invalid package name: %s", config.Package)
+	}
+	oldSchemas, err := loadSchemas(config.OldSchemaFilePath, config.Format, allowTableTypes)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+	newSchemas, err := loadSchemas(config.NewSchemaFilePath, config.Format, allowTableTypes)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+
+	plan := PlanMigration(oldSchemas, newSchemas, !config.DisablePacking)
+	if err := os.WriteFile(filepath.Join(config.OutDir, MigrationReportFile), []byte(plan.Report()), 0644); err != nil {
+		return MigrationPlan{}, err
+	}
+	if !config.GoCode {
+		return plan, nil
+	}
+
+	var funcs []migrationFunc
+	usesSignExtend := false
+	for _, table := range plan.Tables {
+		if table.Change != TableChanged || table.RowsMoved {
+			continue
+		}
+		fn, signExtend := newMigrationFunc(table)
+		funcs = append(funcs, fn)
+		usesSignExtend = usesSignExtend || signExtend
+	}
+	data := map[string]interface{}{
+		"Package":        config.Package,
+		"Funcs":          funcs,
+		"UsesSignExtend": usesSignExtend,
+	}
+	tpl, err := template.New("migration").Parse(migrationTpl)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+	if err := ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, MigrationCodeFile)); err != nil {
+		return MigrationPlan{}, err
+	}
+	return plan, nil
+}
+
+// loadSchemas reads the table schemas of a schema file, without its views.
+func loadSchemas(path, format string, allowTableTypes bool) ([]TableSchema, error) {
+	schemas, _, err := loadSchemaFile(path, format, allowTableTypes)
+	return schemas, err
+}
+
+// loadSchemaFile reads and parses a schema file, recording its path in schema
+// errors.
+func loadSchemaFile(path, format string, allowTableTypes bool) ([]TableSchema, []ViewSchema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	schemas, views, err := unmarshalSchemaFile(format, content, allowTableTypes)
+	if err != nil {
+		var schemaErr *SchemaError
+		if errors.As(err, &schemaErr) {
+			schemaErr.File = path
+		}
+		return nil, nil, err
+	}
+	return schemas, views, nil
+}
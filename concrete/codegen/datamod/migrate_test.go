@@ -0,0 +1,202 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const oldMigrationSchema = `{
+    "balances": {
+        "keySchema": {"owner": "address"},
+        "schema": {
+            "amount": "uint64",
+            "flag": "bool",
+            "note": "string",
+            "legacy": "uint32",
+            "nick": "bytes8",
+            "delta": "int16",
+            "level": "optional uint8"
+        }
+    },
+    "same": {"schema": {"value": "uint"}},
+    "rekeyed": {"keySchema": {"id": "uint64"}, "schema": {"value": "uint"}},
+    "repacked": {"keySchema": {"id": "uint64"}, "schema": {"value": "uint"}},
+    "dropped": {"schema": {"value": "uint"}}
+}`
+
+const newMigrationSchema = `{
+    "balances": {
+        "keySchema": {"owner": "address"},
+        "schema": {
+            "amount": "uint128",
+            "note": "string",
+            "flag": "bool",
+            "nick": "bytes4",
+            "delta": "int64",
+            "level": "optional uint8",
+            "score": "optional uint32"
+        }
+    },
+    "same": {"schema": {"value": "uint256"}},
+    "rekeyed": {"keySchema": {"id": "uint128"}, "schema": {"value": "uint"}},
+    "repacked": {"keySchema": {"id": "uint64"}, "keyPacking": "padded", "schema": {"value": "uint"}},
+    "added": {"schema": {"value": "uint"}}
+}`
+
+func TestPlanMigration(t *testing.T) {
+	r := require.New(t)
+
+	oldSchemas, err := UnmarshalTableSchemas([]byte(oldMigrationSchema), false)
+	r.NoError(err)
+	newSchemas, err := UnmarshalTableSchemas([]byte(newMigrationSchema), false)
+	r.NoError(err)
+
+	plan := PlanMigration(oldSchemas, newSchemas, true)
+	r.True(plan.Destructive())
+
+	tables := make(map[string]TableMigration)
+	for _, table := range plan.Tables {
+		tables[table.Name] = table
+	}
+	r.Len(tables, 6)
+	r.Equal(TableUnchanged, tables["Same"].Change)
+	r.False(tables["Same"].Destructive())
+	r.Equal(TableAdded, tables["Added"].Change)
+	r.False(tables["Added"].Destructive())
+	r.Equal(TableRemoved, tables["Dropped"].Change)
+	r.True(tables["Dropped"].Destructive())
+	r.Equal(TableChanged, tables["Rekeyed"].Change)
+	r.True(tables["Rekeyed"].RowsMoved)
+	r.Equal(TableChanged, tables["Repacked"].Change)
+	r.True(tables["Repacked"].RowsMoved)
+
+	balances := tables["Balances"]
+	r.Equal(TableChanged, balances.Change)
+	r.False(balances.RowsMoved)
+	fields := make(map[string]FieldMigration)
+	for _, field := range balances.Fields {
+		fields[field.Name] = field
+	}
+	r.Equal(FieldWidened, fields["amount"].Change)
+	r.Equal(0, fields["amount"].OldOffset)
+	r.Equal(0, fields["amount"].NewOffset)
+	r.Equal(FieldUnchanged, fields["note"].Change)
+	r.Equal(FieldMoved, fields["flag"].Change)
+	r.Equal(8, fields["flag"].OldOffset)
+	r.Equal(64, fields["flag"].NewOffset)
+	r.Equal(FieldShrunk, fields["nick"].Change)
+	r.True(fields["nick"].Destructive)
+	r.Equal(FieldWidened, fields["delta"].Change)
+	r.False(fields["delta"].Destructive)
+	r.Equal(FieldRemoved, fields["legacy"].Change)
+	r.True(fields["legacy"].Destructive)
+	r.Equal(FieldAdded, fields["score"].Change)
+	r.False(fields["score"].Destructive)
+	r.Equal(-1, fields["score"].OldOffset)
+
+	report := plan.Report()
+	r.Contains(report, "table Balances: changed")
+	r.Contains(report, "REVIEW: nick: the width shrinks from 8 to 4 bytes")
+	r.Contains(report, "REVIEW: legacy: the value is removed")
+	r.Contains(report, "REVIEW: the table is removed")
+	r.Contains(report, "5 destructive changes require manual review.")
+
+	plan = PlanMigration(oldSchemas, oldSchemas, true)
+	r.False(plan.Destructive())
+	r.Contains(plan.Report(), "No destructive changes.")
+
+	// Changing the alignment of fixed bytes moves their data
+	leftSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"schema": {"tag": "bytes8"}}}`), false)
+	r.NoError(err)
+	rightSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"schema": {"tag": "bytes8 align:\"right\""}}}`), false)
+	r.NoError(err)
+	plan = PlanMigration(leftSchemas, rightSchemas, true)
+	r.Equal(FieldRetyped, plan.Tables[0].Fields[0].Change)
+	r.True(plan.Destructive())
+
+	// Hashing a value adds a slot for its hash, which the migration writes
+	plainSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"schema": {"data": "bytes"}}}`), false)
+	r.NoError(err)
+	hashedSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"schema": {"data": "bytes @hashed"}}}`), false)
+	r.NoError(err)
+	plan = PlanMigration(plainSchemas, hashedSchemas, true)
+	r.Equal(TableChanged, plan.Tables[0].Change)
+	r.Equal(FieldUnchanged, plan.Tables[0].Fields[0].Change)
+	r.False(plan.Destructive())
+	fn, _ := newMigrationFunc(plan.Tables[0])
+	r.Contains(fn.Writes, "newRow.SetField(1, crypto.Keccak256(dataData))")
+
+	// Initializing a table leaves existing rows uncreated
+	keyedSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"keySchema": {"id": "uint64"}, "schema": {"value": "uint64"}}}`), false)
+	r.NoError(err)
+	initializedSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"keySchema": {"id": "uint64"}, "initialized": true, "schema": {"value": "uint64"}}}`), false)
+	r.NoError(err)
+	plan = PlanMigration(keyedSchemas, initializedSchemas, true)
+	r.Equal(TableChanged, plan.Tables[0].Change)
+	r.Contains(plan.Report(), "REVIEW: the table becomes initialized")
+	plan = PlanMigration(initializedSchemas, initializedSchemas, true)
+	r.False(plan.Destructive())
+	movedSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"keySchema": {"id": "uint64"}, "initialized": true, "schema": {"value": "uint64", "note": "optional uint64"}}}`), false)
+	r.NoError(err)
+	plan = PlanMigration(initializedSchemas, movedSchemas, true)
+	r.Contains(plan.Report(), "REVIEW: the flag of created rows moves")
+
+	// Offsetting the base slot moves all the rows
+	offsetSchemas, err := UnmarshalTableSchemas([]byte(`{"t": {"keySchema": {"id": "uint64"}, "baseSlot": 8, "schema": {"value": "uint64"}}}`), false)
+	r.NoError(err)
+	plan = PlanMigration(keyedSchemas, offsetSchemas, true)
+	r.True(plan.Tables[0].RowsMoved)
+	r.Contains(plan.Report(), "REVIEW: the base slot offset changes from 0 to 8")
+}
+
+func TestGenerateMigration(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.json"), filepath.Join(dir, "new.json")
+	r.NoError(os.WriteFile(oldPath, []byte(oldMigrationSchema), 0644))
+	r.NoError(os.WriteFile(newPath, []byte(newMigrationSchema), 0644))
+
+	config := MigrationConfig{
+		OldSchemaFilePath: oldPath,
+		NewSchemaFilePath: newPath,
+		OutDir:            dir,
+		Package:           "migration",
+		GoCode:            true,
+	}
+	plan, err := GenerateMigration(config, false)
+	r.NoError(err)
+	r.True(plan.Destructive())
+
+	report, err := os.ReadFile(filepath.Join(dir, MigrationReportFile))
+	r.NoError(err)
+	r.Equal(plan.Report(), string(report))
+
+	code, err := os.ReadFile(filepath.Join(dir, MigrationCodeFile))
+	r.NoError(err)
+	r.Contains(string(code), "func MigrateBalancesRow(dsSlot lib.DatastoreSlot) {")
+	r.Contains(string(code), "newRow.SetField(0, common.LeftPadBytes(amountData, 16))")
+	r.Contains(string(code), "signExtend(deltaData, 8)")
+	// Tables whose rows move get no function
+	r.False(strings.Contains(string(code), "MigrateRekeyedRow"))
+	r.False(strings.Contains(string(code), "MigrateSameRow"))
+}
@@ -0,0 +1,57 @@
+/* Autogenerated file. Review the migration plan before using it. */
+
+package {{$.Package}}
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/ethereum/go-ethereum/concrete/lib"
+)
+
+// Reference imports to suppress errors if they are not used.
+var (
+	_ = common.LeftPadBytes
+	_ = crypto.Keccak256
+)
+{{- if $.UsesSignExtend }}
+
+// signExtend widens a big-endian two's complement integer to size bytes.
+func signExtend(data []byte, size int) []byte {
+	ext := make([]byte, size)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		for ii := 0; ii < size-len(data); ii++ {
+			ext[ii] = 0xff
+		}
+	}
+	copy(ext[size-len(data):], data)
+	return ext
+}
+{{- end }}
+{{- range $.Funcs }}
+
+// Migrate{{.Name}}Row moves the values of a row from the old layout to the new
+// one in place, e.g. while iterating the keys of the table. Values that are
+// not carried over are cleared like Delete does.
+{{- range .Review }}
+//
+// REVIEW: {{.}}
+{{- end }}
+func Migrate{{.Name}}Row(dsSlot lib.DatastoreSlot) {
+	oldRow := lib.NewDatastoreStructWithOffsets(dsSlot, {{.OldSizes}}, {{.OldOffsets}})
+{{- if .Writes }}
+	newRow := lib.NewDatastoreStructWithOffsets(dsSlot, {{.NewSizes}}, {{.NewOffsets}})
+{{- end }}
+
+	// Read all the old values first, the layouts may overlap
+{{- range .Reads }}
+	{{.}}
+{{- end }}
+{{- range .Clears }}
+	{{.}}
+{{- end }}
+	oldRow.Clear()
+{{- range .Writes }}
+	{{.}}
+{{- end }}
+}
+{{- end }}
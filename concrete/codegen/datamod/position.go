@@ -0,0 +1,139 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemaError is an error in a schema file, with the position of the element
+// of the schema it refers to. Lines and columns start at 1 and columns count
+// bytes.
+type SchemaError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SchemaError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// schemaPositions holds the byte offset of every element of a JSON document
+// by path, where a path is made of the object keys and array indices leading
+// to the element. The offset of an object member is that of its key.
+type schemaPositions struct {
+	content []byte
+	offsets map[string]int
+}
+
+func positionKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// indexPositions records the position of every element of a JSON document.
+// An invalid document yields the positions read until the error.
+func indexPositions(content []byte) schemaPositions {
+	positions := schemaPositions{content: content, offsets: make(map[string]int)}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	positions.index(decoder, nil)
+	return positions
+}
+
+// next returns the offset of the next token of the decoder.
+func (p schemaPositions) next(decoder *json.Decoder) int {
+	offset := int(decoder.InputOffset())
+	for offset < len(p.content) && strings.IndexByte(" \t\r\n,:", p.content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (p schemaPositions) index(decoder *json.Decoder, path []string) bool {
+	if _, ok := p.offsets[positionKey(path)]; !ok {
+		p.offsets[positionKey(path)] = p.next(decoder)
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return false
+	}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			offset := p.next(decoder)
+			key, err := decoder.Token()
+			if err != nil {
+				return false
+			}
+			memberPath := append(append([]string{}, path...), key.(string))
+			p.offsets[positionKey(memberPath)] = offset
+			if !p.index(decoder, memberPath) {
+				return false
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for ii := 0; decoder.More(); ii++ {
+			if !p.index(decoder, append(append([]string{}, path...), strconv.Itoa(ii))) {
+				return false
+			}
+		}
+		_, err = decoder.Token()
+	}
+	return err == nil
+}
+
+// errorAt returns err positioned at the given byte offset.
+func (p schemaPositions) errorAt(err error, offset int) error {
+	line := 1 + bytes.Count(p.content[:offset], []byte("\n"))
+	column := offset - bytes.LastIndexByte(p.content[:offset], '\n')
+	return &SchemaError{Line: line, Column: column, Err: err}
+}
+
+// wrap returns err positioned at the element at path, or at its closest
+// ancestor with a known position. Errors that already have a position and
+// JSON syntax errors are positioned where they occurred, other errors without
+// a path are returned as they are.
+func (p schemaPositions) wrap(err error, path ...string) error {
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		return err
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && int(syntaxErr.Offset) <= len(p.content) {
+		// The offset of syntax errors is that of the last byte read
+		return p.errorAt(err, max(int(syntaxErr.Offset)-1, 0))
+	}
+	for ii := len(path); ii > 0; ii-- {
+		if offset, ok := p.offsets[positionKey(path[:ii])]; ok {
+			return p.errorAt(err, offset)
+		}
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaErrorPositions(t *testing.T) {
+	dsl := `{
+  "pools": {
+    "keySchema": {
+      "id": "uint64"
+    },
+    "schema": {
+      "fee": "uint64",
+      "owner": "adress"
+    }
+  }
+}`
+	format := `{"tables": [
+  {
+    "name": "pools",
+    "keys": [{"name": "id", "type": "uint64"}],
+    "values": [
+      {"name": "fee", "type": "uint64"},
+      {"name": "owner", "type": "adress"}
+    ]
+  }
+]}`
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		err     string
+	}{
+		{"unknownType", FormatDSL, dsl, "8:7: invalid type 'adress' for field 'owner': unknown field type adress"},
+		{"badKey", FormatDSL, strings.Replace(dsl, `"id": "uint64"`, `"id": "uint64[]"`, 1), "4:7: table 'pools' cannot have array keys"},
+		{"badTable", FormatDSL, strings.Replace(dsl, `"schema": {`, `"values": {`, 1), "2:3: no value schema for table 'pools'"},
+		{"badIterable", FormatDSL, strings.NewReplacer(`"schema": {`, `"iterable": 1, "schema": {`, "adress", "address").Replace(dsl), "6:5: invalid iterable schema for table 'pools'"},
+		{"syntax", FormatDSL, strings.Replace(dsl, `"uint64",`, `"uint64"`, 1), "8:7: invalid character '\"' after object key:value pair"},
+		{"formatUnknownType", FormatJSON, format, "7:7: invalid type 'adress' for field 'owner': unknown field type adress"},
+		{"formatBadKey", FormatJSON, strings.Replace(format, `"type": "uint64"}]`, `"type": "uint64[]"}]`, 1), "4:14: table 'pools' cannot have array keys"},
+		{"formatUnknownProperty", FormatJSON, strings.Replace(format, `"name": "fee",`, `"name": "fee", "size": 8,`, 1), "6:23: invalid json schema: json: unknown field \"size\""},
+		{"formatDuplicate", FormatJSON, strings.Replace(format, `"name": "owner"`, `"name": "fee"`, 1), "7:7: invalid schema for table 'pools': duplicate field 'fee'"},
+		{"badView", FormatDSL, strings.NewReplacer("adress", "address", "\n}", ",\n  \"poolView\": {\"view\": {\"fee\": \"pools.fees\"}}\n}").Replace(dsl), "11:25: invalid schema for view 'poolView': table 'pools' has no value 'fees'"},
+		{"formatBadView", FormatJSON, strings.NewReplacer("adress", "address", "\n]}", "\n], \"views\": [\n  {\"name\": \"poolView\", \"fields\": [{\"name\": \"fee\", \"table\": \"pools\", \"value\": \"fees\"}]}\n]}").Replace(format), "11:35: invalid schema for view 'poolView': table 'pools' has no value 'fees'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			_, err := unmarshalSchemas(test.format, []byte(test.content), false)
+			var schemaErr *SchemaError
+			r.ErrorAs(err, &schemaErr)
+			r.Equal(test.err, err.Error())
+		})
+	}
+}
+
+func TestSchemaErrorFile(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-position"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	schemaPath := filepath.Join(tmpDir, "schema.json")
+	r.NoError(os.WriteFile(schemaPath, []byte("{\n  \"t\": {\n    \"schema\": {\n      \"a\": \"uint7\"\n    }\n  }\n}\n"), 0644))
+	err := GenerateDataModel(Config{SchemaFilePath: schemaPath, OutDir: tmpDir, Package: "test"}, false)
+	r.EqualError(err, schemaPath+":4:7: invalid type 'uint7' for field 'a': invalid integer size 7")
+	r.ErrorIs(err, ErrInvalidIntSize)
+}
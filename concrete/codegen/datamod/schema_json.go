@@ -0,0 +1,422 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// Input formats of the schema file.
+const (
+	// FormatDSL is the default format, a JSON object mapping every table name
+	// to its schema, with one annotated type string per field.
+	FormatDSL = "dsl"
+	// FormatJSON describes tables and fields as JSON objects with one
+	// property per annotation, which is easier to emit from other tools.
+	FormatJSON = "json"
+)
+
+// jsonSchema is the root of a schema in the JSON format, e.g.
+//
+//	{"tables": [{
+//	    "name": "pools",
+//	    "keys": [{"name": "id", "type": "uint64"}],
+//	    "values": [{"name": "fee", "type": "uint64", "optional": true, "slot": 1}]
+//	}]}
+//
+// Types are written as in the DSL without annotations, which are given by the
+// other properties of the field instead. Views list the table and value read
+// by every field, e.g. `{"name": "balance", "table": "pools", "value": "fee"}`.
+type jsonSchema struct {
+	Tables []jsonTableSchema `json:"tables"`
+	Views  []jsonViewSchema  `json:"views"`
+}
+
+type jsonTableSchema struct {
+	Name         string            `json:"name"`
+	Keys         []jsonFieldSchema `json:"keys"`
+	Values       []jsonFieldSchema `json:"values"`
+	CompositeKey bool              `json:"compositeKey"`
+	KeyPacking   string            `json:"keyPacking"`
+	Iterable     bool              `json:"iterable"`
+	Order        string            `json:"order"`
+	// BaseSlot is a JSON integer or a decimal or hex string
+	BaseSlot json.RawMessage `json:"baseSlot"`
+	// Initialized is a JSON boolean or "strict"
+	Initialized json.RawMessage `json:"initialized"`
+	// Emit is a JSON boolean or event signature
+	Emit json.RawMessage `json:"emit"`
+}
+
+type jsonFieldSchema struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional"`
+	Slot     *int   `json:"slot"`
+	Order    *int   `json:"order"`
+	GoType   string `json:"goType"`
+	BigInt   bool   `json:"bigInt"`
+	Endian   string `json:"endian"`
+	Align    string `json:"align"`
+	MaxLen   *int   `json:"maxLen"`
+	Hashed   bool   `json:"hashed"`
+	// Default is a JSON string, number or boolean
+	Default json.RawMessage `json:"default"`
+}
+
+type jsonViewSchema struct {
+	Name   string                `json:"name"`
+	Fields []jsonViewFieldSchema `json:"fields"`
+}
+
+type jsonViewFieldSchema struct {
+	Name  string `json:"name"`
+	Table string `json:"table"`
+	Value string `json:"value"`
+}
+
+// Properties of the objects of the JSON format, used to locate unknown ones.
+var (
+	jsonSchemaProperties    = []string{"tables", "views"}
+	jsonTableProperties     = []string{"name", "keys", "values", "compositeKey", "keyPacking", "iterable", "order", "baseSlot", "initialized", "emit"}
+	jsonFieldProperties     = []string{"name", "type", "optional", "slot", "order", "goType", "bigInt", "endian", "align", "maxLen", "hashed", "default"}
+	jsonViewProperties      = []string{"name", "fields"}
+	jsonViewFieldProperties = []string{"name", "table", "value"}
+)
+
+// unknownPropertyOffset returns the offset of the first unknown property of
+// a schema in the JSON format.
+func unknownPropertyOffset(positions schemaPositions) (int, bool) {
+	first, found := 0, false
+	for key, offset := range positions.offsets {
+		path := strings.Split(key, "\x00")
+		var known []string
+		switch {
+		case len(path) == 1 && key != "":
+			known = jsonSchemaProperties
+		case len(path) == 3 && path[0] == "tables":
+			known = jsonTableProperties
+		case len(path) == 5 && path[0] == "tables" && (path[2] == "keys" || path[2] == "values"):
+			known = jsonFieldProperties
+		case len(path) == 3 && path[0] == "views":
+			known = jsonViewProperties
+		case len(path) == 5 && path[0] == "views" && path[2] == "fields":
+			known = jsonViewFieldProperties
+		default:
+			continue
+		}
+		if !slices.Contains(known, path[len(path)-1]) && (!found || offset < first) {
+			first, found = offset, true
+		}
+	}
+	return first, found
+}
+
+// defaultLiteral returns the default value of the field as written in the DSL,
+// i.e. the contents of a string or the text of a number or boolean.
+func (f jsonFieldSchema) defaultLiteral() (string, bool) {
+	if f.Default == nil {
+		return "", false
+	}
+	var literal string
+	if err := json.Unmarshal(f.Default, &literal); err == nil {
+		return literal, true
+	}
+	return string(f.Default), true
+}
+
+// dslType returns the annotated type string of the field in the DSL.
+func (f jsonFieldSchema) dslType() string {
+	typeStr := strings.TrimSpace(f.Type)
+	if f.Optional {
+		typeStr = "optional " + typeStr
+	}
+	if f.Align != "" {
+		typeStr += fmt.Sprintf(" align:\"%s\"", f.Align)
+	}
+	if f.Endian != "" {
+		typeStr += fmt.Sprintf(" endian:\"%s\"", f.Endian)
+	}
+	if f.GoType != "" {
+		typeStr += fmt.Sprintf(" gotype:\"%s\"", f.GoType)
+	}
+	if f.BigInt {
+		typeStr += " @bigint"
+	}
+	if f.MaxLen != nil {
+		typeStr += fmt.Sprintf(" maxlen:\"%d\"", *f.MaxLen)
+	}
+	if f.Hashed {
+		typeStr += " @hashed"
+	}
+	if literal, ok := f.defaultLiteral(); ok {
+		typeStr += fmt.Sprintf(" default:\"%s\"", literal)
+	}
+	if f.Slot != nil {
+		typeStr += fmt.Sprintf(" @slot %d", *f.Slot)
+	}
+	if f.Order != nil {
+		typeStr += fmt.Sprintf(" @order %d", *f.Order)
+	}
+	return typeStr
+}
+
+func (f jsonFieldSchema) validate(tableName string, isKey bool) error {
+	kind := "value"
+	if isKey {
+		kind = "key"
+	}
+	if f.Name == "" {
+		return fmt.Errorf("invalid %s schema for table '%s': missing field name", kind, tableName)
+	}
+	if strings.TrimSpace(f.Type) == "" {
+		return fmt.Errorf("invalid schema for %s '%s' in table '%s': missing type", kind, f.Name, tableName)
+	}
+	defaultLiteral, _ := f.defaultLiteral()
+	if strings.Contains(f.GoType, "\"") || strings.Contains(f.Endian, "\"") || strings.Contains(f.Align, "\"") || strings.Contains(defaultLiteral, "\"") {
+		return fmt.Errorf("invalid schema for %s '%s' in table '%s': annotations cannot contain quotes", kind, f.Name, tableName)
+	}
+	if isKey && f.Optional {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot be optional", tableName, f.Name)
+	}
+	if isKey && f.Slot != nil {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot be pinned to a slot", tableName, f.Name)
+	}
+	if f.Slot != nil && *f.Slot < 0 {
+		return fmt.Errorf("invalid slot schema for table '%s': negative slot for value '%s'", tableName, f.Name)
+	}
+	if isKey && f.MaxLen != nil {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot have a maximum length", tableName, f.Name)
+	}
+	if isKey && f.Hashed {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot be hashed", tableName, f.Name)
+	}
+	if isKey && f.Default != nil {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot have a default", tableName, f.Name)
+	}
+	if isKey && f.Order != nil {
+		return fmt.Errorf("invalid key schema for table '%s': key '%s' cannot be ordered", tableName, f.Name)
+	}
+	if f.Order != nil && *f.Order < 0 {
+		return fmt.Errorf("invalid order schema for table '%s': negative rank for value '%s'", tableName, f.Name)
+	}
+	return nil
+}
+
+func jsonFieldsToDSL(tableName string, fields []jsonFieldSchema, isKey bool, wrap func(err error, index int) error) (orderedmap.OrderedMap, error) {
+	dsl := orderedmap.New()
+	for ii, field := range fields {
+		if err := field.validate(tableName, isKey); err != nil {
+			return orderedmap.OrderedMap{}, wrap(err, ii)
+		}
+		if _, ok := dsl.Get(field.Name); ok {
+			return orderedmap.OrderedMap{}, wrap(fmt.Errorf("invalid schema for table '%s': duplicate field '%s'", tableName, field.Name), ii)
+		}
+		dsl.Set(field.Name, field.dslType())
+	}
+	return *dsl, nil
+}
+
+// toDSL converts a schema in the JSON format to the DSL, so both formats go
+// through the same parsing and produce the same output.
+func (s jsonSchema) toDSL(positions schemaPositions) ([]byte, error) {
+	dsl := orderedmap.New()
+	for ii, table := range s.Tables {
+		tablePath := []string{"tables", strconv.Itoa(ii)}
+		if table.Name == "" {
+			return nil, positions.wrap(fmt.Errorf("invalid json schema: missing table name"), tablePath...)
+		}
+		if _, ok := dsl.Get(table.Name); ok {
+			return nil, positions.wrap(fmt.Errorf("invalid schema for table '%s': duplicate table", table.Name), tablePath...)
+		}
+		fieldsWrap := func(list string) func(err error, index int) error {
+			return func(err error, index int) error {
+				return positions.wrap(err, append(tablePath, list, strconv.Itoa(index))...)
+			}
+		}
+		tableDSL := orderedmap.New()
+		if len(table.Keys) > 0 {
+			keys, err := jsonFieldsToDSL(table.Name, table.Keys, true, fieldsWrap("keys"))
+			if err != nil {
+				return nil, err
+			}
+			tableDSL.Set("keySchema", keys)
+		}
+		if table.CompositeKey {
+			tableDSL.Set("compositeKey", true)
+		}
+		if table.KeyPacking != "" {
+			tableDSL.Set("keyPacking", table.KeyPacking)
+		}
+		if table.Iterable {
+			tableDSL.Set("iterable", true)
+		}
+		if table.Order != "" {
+			tableDSL.Set("order", table.Order)
+		}
+		if table.BaseSlot != nil {
+			var baseSlot interface{}
+			if err := json.Unmarshal(table.BaseSlot, &baseSlot); err != nil {
+				return nil, positions.wrap(fmt.Errorf("invalid base slot schema for table '%s'", table.Name), append(tablePath, "baseSlot")...)
+			}
+			tableDSL.Set("baseSlot", baseSlot)
+		}
+		if table.Initialized != nil {
+			var initialized interface{}
+			if err := json.Unmarshal(table.Initialized, &initialized); err != nil {
+				return nil, positions.wrap(fmt.Errorf("invalid initialized schema for table '%s'", table.Name), append(tablePath, "initialized")...)
+			}
+			tableDSL.Set("initialized", initialized)
+		}
+		if table.Emit != nil {
+			var emit interface{}
+			if err := json.Unmarshal(table.Emit, &emit); err != nil {
+				return nil, positions.wrap(fmt.Errorf("invalid emit schema for table '%s'", table.Name), append(tablePath, "emit")...)
+			}
+			tableDSL.Set("emit", emit)
+		}
+		if len(table.Values) == 0 {
+			return nil, positions.wrap(fmt.Errorf("no value schema for table '%s'", table.Name), tablePath...)
+		}
+		values, err := jsonFieldsToDSL(table.Name, table.Values, false, fieldsWrap("values"))
+		if err != nil {
+			return nil, err
+		}
+		tableDSL.Set("schema", values)
+		dsl.Set(table.Name, tableDSL)
+	}
+	for ii, view := range s.Views {
+		viewPath := []string{"views", strconv.Itoa(ii)}
+		if view.Name == "" {
+			return nil, positions.wrap(fmt.Errorf("invalid json schema: missing view name"), viewPath...)
+		}
+		if _, ok := dsl.Get(view.Name); ok {
+			return nil, positions.wrap(fmt.Errorf("invalid schema for view '%s': duplicate table or view", view.Name), viewPath...)
+		}
+		fields := orderedmap.New()
+		for jj, field := range view.Fields {
+			if _, ok := fields.Get(field.Name); ok {
+				return nil, positions.wrap(fmt.Errorf("invalid schema for view '%s': duplicate field '%s'", view.Name, field.Name), append(viewPath, "fields", strconv.Itoa(jj))...)
+			}
+			fields.Set(field.Name, field.Table+"."+field.Value)
+		}
+		viewDSL := orderedmap.New()
+		viewDSL.Set("view", fields)
+		dsl.Set(view.Name, viewDSL)
+	}
+	return json.Marshal(dsl)
+}
+
+// jsonPath returns the path in the JSON format of the element at a path of
+// the DSL it converts to.
+func (s jsonSchema) jsonPath(dslPath []string) []string {
+	if len(dslPath) == 0 {
+		return nil
+	}
+	for ii, view := range s.Views {
+		if view.Name != dslPath[0] {
+			continue
+		}
+		path := []string{"views", strconv.Itoa(ii)}
+		if len(dslPath) < 3 {
+			return path
+		}
+		for jj, field := range view.Fields {
+			if field.Name == dslPath[2] {
+				return append(path, "fields", strconv.Itoa(jj))
+			}
+		}
+		return append(path, "fields")
+	}
+	for ii, table := range s.Tables {
+		if table.Name != dslPath[0] {
+			continue
+		}
+		path := []string{"tables", strconv.Itoa(ii)}
+		if len(dslPath) == 1 {
+			return path
+		}
+		fields, list := table.Values, "values"
+		switch dslPath[1] {
+		case "keySchema":
+			fields, list = table.Keys, "keys"
+		case "schema":
+		default:
+			return append(path, dslPath[1])
+		}
+		path = append(path, list)
+		if len(dslPath) == 2 {
+			return path
+		}
+		for jj, field := range fields {
+			if field.Name == dslPath[2] {
+				return append(path, strconv.Itoa(jj))
+			}
+		}
+		return path
+	}
+	return nil
+}
+
+// unmarshalJSONSchema parses the table and view schemas of a schema file in
+// the JSON format. Unknown properties are rejected.
+func unmarshalJSONSchema(content []byte, allowTableTypes bool) ([]TableSchema, []ViewSchema, error) {
+	positions := indexPositions(content)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	var schema jsonSchema
+	if err := decoder.Decode(&schema); err != nil {
+		err = fmt.Errorf("invalid json schema: %w", err)
+		if offset, ok := unknownPropertyOffset(positions); ok {
+			return nil, nil, positions.errorAt(err, offset)
+		}
+		return nil, nil, positions.wrap(err)
+	}
+	dslContent, err := schema.toDSL(positions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return unmarshalTableSchemas(dslContent, allowTableTypes, func(err error, path []string) error {
+		return positions.wrap(err, schema.jsonPath(path)...)
+	})
+}
+
+// unmarshalSchemas parses the table schemas of a schema file in the given
+// format.
+func unmarshalSchemas(format string, content []byte, allowTableTypes bool) ([]TableSchema, error) {
+	schemas, _, err := unmarshalSchemaFile(format, content, allowTableTypes)
+	return schemas, err
+}
+
+// unmarshalSchemaFile parses the table and view schemas of a schema file in
+// the given format.
+func unmarshalSchemaFile(format string, content []byte, allowTableTypes bool) ([]TableSchema, []ViewSchema, error) {
+	switch format {
+	case "", FormatDSL:
+		return unmarshalDSLSchemas(content, allowTableTypes)
+	case FormatJSON:
+		return unmarshalJSONSchema(content, allowTableTypes)
+	default:
+		return nil, nil, fmt.Errorf("unknown schema format: %s", format)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	_ "embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed solidity.tpl
+var solidityTpl string
+
+const (
+	SolidityInterfaceFileName = "datamod.sol"
+	defaultSolidityPragma     = "^0.8.0"
+)
+
+func solidityArgs(args []accessorArg) string {
+	strs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg.Name != "" {
+			strs = append(strs, fmt.Sprintf("%s %s", arg.Type.SolArgType(), arg.Name))
+		} else {
+			strs = append(strs, arg.Type.SolArgType())
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+func solidityMethods(schema TableSchema) []map[string]interface{} {
+	methods := []map[string]interface{}{}
+	for _, method := range accessorMethods(schema) {
+		methods = append(methods, map[string]interface{}{
+			"Name":    method.Name,
+			"Inputs":  solidityArgs(method.Inputs),
+			"Outputs": solidityArgs(method.Outputs),
+			"IsView":  method.IsView,
+		})
+	}
+	return methods
+}
+
+func GenerateSolidityInterfaces(config Config, schemas []TableSchema) error {
+	pragma := config.SolidityPragma
+	if pragma == "" {
+		pragma = defaultSolidityPragma
+	}
+
+	interfaces := []map[string]interface{}{}
+	for _, schema := range schemas {
+		methods := solidityMethods(schema)
+		if len(methods) == 0 {
+			continue
+		}
+		interfaces = append(interfaces, map[string]interface{}{
+			"Name":    "I" + formatTableName(schema.Name),
+			"Methods": methods,
+		})
+	}
+
+	structs, err := collectStructs(schemas, nil)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Pragma":     pragma,
+		"Structs":    structs,
+		"Interfaces": interfaces,
+	}
+
+	tpl, err := template.New("solidity").Parse(solidityTpl)
+	if err != nil {
+		return err
+	}
+	return ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, SolidityInterfaceFileName))
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/testdata"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// compileRuntime compiles a solidity contract with solc and returns its
+// runtime bytecode. The test is skipped if solc is not installed.
+func compileRuntime(t *testing.T, path, contract string) []byte {
+	solc, err := exec.LookPath("solc")
+	if err != nil {
+		t.Skip("solc not installed")
+	}
+	out, err := exec.Command(solc, "--combined-json", "bin-runtime", path).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output struct {
+		Contracts map[string]struct {
+			BinRuntime string `json:"bin-runtime"`
+		} `json:"contracts"`
+	}
+	if err := json.Unmarshal(out, &output); err != nil {
+		t.Fatal(err)
+	}
+	compiled, ok := output.Contracts[path+":"+contract]
+	if !ok {
+		t.Fatalf("contract %s not found in %s", contract, path)
+	}
+	return common.FromHex(compiled.BinRuntime)
+}
+
+func TestCompositeKeySolidity(t *testing.T) {
+	r := require.New(t)
+	code := compileRuntime(t, filepath.Join("testdata", "PackedKeyTable.sol"), "PackedKeyTable")
+
+	var (
+		id    = uint32(0x01020304)
+		delta = int16(-2)
+		flag  = true
+		tag   = [4]byte{0xaa, 0xbb}
+		owner = common.HexToAddress("0xc0de")
+	)
+	var args abi.Arguments
+	for _, typ := range []string{"uint32", "int16", "bool", "bytes4", "address"} {
+		abiType, err := abi.NewType(typ, "", nil)
+		r.NoError(err)
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	input, err := args.Pack(id, delta, flag, tag, owner)
+	r.NoError(err)
+	selector := crypto.Keccak256([]byte("rowSlot(uint32,int16,bool,bytes4,address)"))[:4]
+	ret, _, err := runtime.Execute(code, append(selector, input...), nil)
+	r.NoError(err)
+
+	contract := api.NewContract(common.Address{}, common.Address{}, common.Address{}, new(uint256.Int))
+	ds := lib.NewDatastore(mock.NewMockEnvironment(api.EnvConfig{}, false, contract))
+	row := testdata.NewPackedKeyTable(ds).Get(id, delta, flag, tag[:], owner)
+	r.Equal(common.BytesToHash(ret), row.GetBase_slot().Slot())
+}
@@ -1,115 +1,1403 @@
 /* Autogenerated file. Do not edit manually. */
-
+{{ if $.BuildTag }}
+//go:build {{$.BuildTag}}
+{{ end }}
 package {{$.Package}}
 
 import (
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
-	"github.com/ethereum/go-ethereum/concrete/crypto"
-	"github.com/ethereum/go-ethereum/concrete/lib"
-	"github.com/holiman/uint256"
+{{- if $.Context }}
+	"context"
+{{- end }}
+{{- if $.Schema.RowValues }}
+	"encoding/json"
+{{- end }}
+	"{{$.Paths.Common}}"
+	"{{$.Paths.Hexutil}}"
+{{- if $.Schema.Emit }}
+	"{{$.Paths.API}}"
+{{- end }}
+	"{{$.Paths.Codec}}"
+	"{{$.Paths.Crypto}}"
+	"{{$.Paths.Lib}}"
+	"{{$.Paths.Storage}}"
+	"{{$.Paths.Uint256}}"
+{{- range $.Imports }}
+	{{if .Alias}}{{.Alias}} {{end}}"{{.Path}}"
+{{- end }}
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
+{{ if $.Schema.BaseSlot }}
+// var (
+//	{{$.TableStructName}}DefaultKey = storage.OffsetSlot(storage.TableSlot("{{$.TableStructName}}"), {{$.Schema.BaseSlot}}).Bytes()
+// )
 
+// {{$.TableStructName}}DefaultKey returns the base slot of the table, offset by {{$.Schema.BaseSlot}} slots.
+func {{$.TableStructName}}DefaultKey() []byte {
+	return storage.OffsetSlot(storage.TableSlot("{{$.TableStructName}}"), {{$.Schema.BaseSlot}}).Bytes()
+}
+{{- else }}
 // var (
-//	{{$.TableStructName}}DefaultKey = crypto.Keccak256([]byte("datamod.v1.{{$.TableStructName}}"))
+//	{{$.TableStructName}}DefaultKey = storage.TableSlot("{{$.TableStructName}}").Bytes()
 // )
 
 func {{$.TableStructName}}DefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.{{$.TableStructName}}"))
+	return storage.TableSlot("{{$.TableStructName}}").Bytes()
 }
+{{- end }}
+{{- if $.Schema.Emit }}
+
+// {{$.TableStructName}}Event is logged by tables created with New{{$.TableStructName}}WithEvents
+// every time a value of a row is written, with the hash of the packed keys
+// and the index of the value as topics.
+var {{$.TableStructName}}Event = &lib.Event{
+	Name: "{{$.Schema.EmitName}}",
+	Args: []lib.EventArg{
+		{Name: "key", Type: "bytes32", Indexed: true},
+		{Name: "index", Type: "uint256", Indexed: true},
+		{Name: "value", Type: "bytes"},
+	},
+}
+{{- end }}
 
 type {{$.RowStructName}} struct {
 	lib.DatastoreStruct
+{{- if $.Schema.Iterable }}
+	onWrite  func()
+	onDelete func()
+{{- end }}
+{{- if $.Schema.Emit }}
+	onSet func(index int, data []byte)
+{{- end }}
 }
 
 func New{{$.RowStructName}}(dsSlot lib.DatastoreSlot) *{{$.RowStructName}} {
 	sizes := {{$.SizesStr}}
-	return &{{$.RowStructName}}{*lib.NewDatastoreStruct(dsSlot, sizes)}
+{{- if $.OffsetsStr }}
+	offsets := {{$.OffsetsStr}}
+	return &{{$.RowStructName}}{ {{- if or $.Schema.Iterable $.Schema.Emit}}DatastoreStruct: {{end}}*lib.NewDatastoreStructWithOffsets(dsSlot, sizes, offsets)}
+{{- else }}
+	return &{{$.RowStructName}}{ {{- if or $.Schema.Iterable $.Schema.Emit}}DatastoreStruct: {{end}}*lib.NewDatastoreStruct(dsSlot, sizes)}
+{{- end }}
+}
+{{- if $.Schema.Iterable }}
+
+func (v *{{$.RowStructName}}) written() {
+	if v.onWrite != nil {
+		v.onWrite()
+	}
+}
+{{- end }}
+{{- if $.Schema.Initialized }}
+
+// Exists reports whether the row was created and not deleted since.
+func (v *{{$.RowStructName}}) Exists() bool {
+	return v.GetField({{$.Schema.ExistsIndex}})[0] != 0
 }
 
+// create sets the flag of the row, writing the default values first if all
+// its slots are zero so that they keep reading the same.
+func (v *{{$.RowStructName}}) create() {
+	if v.Exists() {
+		return
+	}
+{{- if $.Schema.HasDefaults }}
+	defaults := v.IsZero()
+{{- end }}
+	v.SetField({{$.Schema.ExistsIndex}}, []byte{1})
+{{- if $.Schema.HasDefaults }}
+	if defaults {
+{{- range $value := $.Schema.Values }}
+{{- if $value.Default }}
+		v.Set{{$value.Title}}({{$value.Default}})
+{{- end }}
+{{- end }}
+	}
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+}
+{{- if $.Schema.StrictInit }}
+
+func (v *{{$.RowStructName}}) mustExist() {
+	if !v.Exists() {
+		panic(codec.ErrRowNotCreated)
+	}
+}
+{{- end }}
+{{- end }}
+{{- if $.Schema.Emit }}
+
+func (v *{{$.RowStructName}}) emit(index int, data []byte) {
+	if v.onSet != nil {
+		v.onSet(index, data)
+	}
+}
+{{- end }}
+{{- if $.Schema.HasOptional }}
+
+func (v *{{$.RowStructName}}) isPresent(bit int) bool {
+	data := v.GetField({{$.Schema.PresenceIndex}})
+	return data[bit/8]&(1<<(bit%8)) != 0
+}
+
+func (v *{{$.RowStructName}}) setPresent(bit int, present bool) {
+	data := v.GetField({{$.Schema.PresenceIndex}})
+	if present {
+		data[bit/8] |= 1 << (bit % 8)
+	} else {
+		data[bit/8] &^= 1 << (bit % 8)
+	}
+	v.SetField({{$.Schema.PresenceIndex}}, data)
+}
+{{- end }}
+{{- if $.Schema.HashedValues }}
+
+func (v *{{$.RowStructName}}) setHashed(index int, hashIndex int, data []byte) {
+	v.SetField_bytes(index, data)
+	v.SetField(hashIndex, crypto.Keccak256(data))
+}
+
+// Verify returns an error wrapping codec.ErrHashMismatch if the content of a
+// hashed value does not match the hash stored along with it, e.g. after a
+// partial write.
+func (v *{{$.RowStructName}}) Verify() error {
+{{- range $value := $.Schema.HashedValues }}
+	if err := codec.CheckHash("{{$value.Name}}", v.GetField_bytes({{$value.Index}}), v.GetField({{$.Schema.HashIndex $value}})); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
+{{- end }}
+
 func (v *{{$.RowStructName}}) Get() (
 {{- range $value := $.Schema.Values }}
 	{{$value.Name}} {{if eq $value.Type.Type 2}}*{{end}}{{$value.Type.GoType}},
 {{- end }}
 ) {
 	return {{ range $value := $.Schema.Values }}
-		{{- if lt $value.Type.Type 2 -}}
-		codec.{{$value.Type.DecodeFunc}}({{$value.Type.Size}}, {{if eq $value.Type.Type 0}}v.GetField{{else}}v.GetField_bytes{{end}}({{$value.Index}}))
+		{{- if or $value.Type.Elem $value.Default -}}
+		v.Get{{$value.Title}}()
+		{{- else if lt $value.Type.Type 2 -}}
+		{{$value.Type.DecodeFunc}}({{$value.Type.Size}}, {{if eq $value.Type.Type 0}}v.GetField{{else}}v.GetField_bytes{{end}}({{$value.Index}}))
 		{{- else -}}
-		New{{$value.Type.GoType}}FromSlot(v.GetField_slot({{$value.Index}}))
+		v.Get{{$value.Title}}()
 		{{- end }}
 		{{- if ne .Index (sub (len $.Schema.Values) 1) }},
 		{{end}}
 	{{- end }}
 }
-
+{{ if $.Schema.CappedValues }}
+// Set returns an error without writing anything if a value is longer than
+// its maximum length.
+{{- end }}
 func (v *{{$.RowStructName}}) Set(
 {{- range $value := $.Schema.Values }}
-{{- if lt $value.Type.Type 2 }}
+{{- if ne $value.Type.Type 2 }}
 	{{$value.Name}} {{$value.Type.GoType}},
 {{- end }}
 {{- end }}
-) {
+) {{if $.Schema.CappedValues}}error {{end}}{
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+{{- range $value := $.Schema.CappedValues }}
+	if err := codec.CheckMaxLen("{{$value.Name}}", {{$value.MaxLen}}, {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, {{$value.Name}})); err != nil {
+		return err
+	}
+{{- end }}
 {{- range $value := $.Schema.Values }}
-{{- if lt $value.Type.Type 2 }}
-	{{if eq $value.Type.Type 0}}v.SetField{{else if eq $value.Type.Type 1}}v.SetField_bytes{{end -}}
-	({{$value.Index}}, codec.{{$value.Type.EncodeFunc}}({{$value.Type.Size}}, {{$value.Name}}))
+{{- if $value.Type.Elem }}
+	v.Set{{$value.Title}}({{$value.Name}})
+{{- else if lt $value.Type.Type 2 }}
+	{{if eq $value.Type.Type 0}}v.SetField{{else if $value.Hashed}}v.setHashed{{else if eq $value.Type.Type 1}}v.SetField_bytes{{end -}}
+	({{$value.Index}}, {{if $value.Hashed}}{{$.Schema.HashIndex $value}}, {{end}}{{$value.Type.EncodeFunc}}({{$value.Type.Size}}, {{$value.Name}}))
+{{- end }}
 {{- end }}
+{{- if $.Schema.HasOptional }}
+	v.SetField({{$.Schema.PresenceIndex}}, {{$.Schema.PresenceMask}})
 {{- end }}
+{{- if $.Schema.Emit }}
+{{- range $value := $.Schema.Values }}
+{{- if and (lt $value.Type.Type 2) (not $value.Type.Elem) }}
+	v.emit({{$value.Index}}, {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, {{$value.Name}}))
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+{{- if $.Schema.CappedValues }}
+	return nil
+{{- end }}
+}
+
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+{{- if $.Schema.Initialized }} The row no longer exists
+// afterwards.
+{{- end }}
+func (v *{{$.RowStructName}}) Delete() {
+{{- range $value := $.Schema.Values }}
+{{- if eq $value.Type.Type 1 }}
+	v.GetField_slot({{$value.Index}}).ClearBytes()
+{{- else if eq $value.Type.Type 3 }}
+	v.GetField_slot({{$value.Index}}).ContiguousArray().Clear()
+{{- end }}
+{{- end }}
+	v.Clear()
+{{- if $.Schema.Emit }}
+{{- range $value := $.Schema.RowValues }}
+	v.emit({{$value.Index}}, {{if eq $value.Type.Type 0}}make([]byte, {{$value.Type.Size}}){{else}}nil{{end}})
+{{- end }}
+{{- end }}
+{{- if $.Schema.Iterable }}
+	if v.onDelete != nil {
+		v.onDelete()
+	}
+{{- end }}
+}
+{{- if $.Schema.RowValues }}
+{{- $packed := $.Schema.PackedValues }}
+
+// {{$.TableStructName}}Values holds all the values of a row, except tables.
+type {{$.TableStructName}}Values struct {
+{{- range $value := $.Schema.RowValues }}
+	{{$value.Title}} {{$value.Type.GoType}}
+{{- if $value.Optional }}
+	Has{{$value.Title}} bool
+{{- end }}
+{{- end }}
+}
+
+// GetValues reads all the values of the row, loading every slot only once.
+func (v *{{$.RowStructName}}) GetValues() {{$.TableStructName}}Values {
+	var values {{$.TableStructName}}Values
+{{- if $packed }}
+	fields := v.GetFields({{$.Schema.PackedIndices}})
+{{- range $k, $value := $packed }}
+{{- if $value.Type.Elem }}
+	for ii := range values.{{$value.Title}} {
+		values.{{$value.Title}}[ii] = {{$value.Type.Elem.DecodeFunc}}({{$value.Type.Elem.Size}}, fields[{{$k}}][ii*{{$value.Type.Elem.Size}}:(ii+1)*{{$value.Type.Elem.Size}}])
+	}
+{{- else if $value.Optional }}
+	if fields[{{len $packed}}][{{$value.PresenceByte}}]&{{$value.PresenceFlag}} != 0 {
+		values.{{$value.Title}} = {{$value.Type.DecodeFunc}}({{$value.Type.Size}}, fields[{{$k}}])
+		values.Has{{$value.Title}} = true
+	}
+{{- else }}
+	values.{{$value.Title}} = {{$value.Type.DecodeFunc}}({{$value.Type.Size}}, fields[{{$k}}])
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if $.Schema.HasDefaults }}
+	if {{ range $k, $value := $packed }}{{if $value.Default}}codec.IsZero(fields[{{$k}}]) && {{end}}{{end}}v.IsZero() {
+{{- range $value := $packed }}
+{{- if $value.Default }}
+		values.{{$value.Title}} = {{$value.Default}}
+{{- end }}
+{{- end }}
+	}
+{{- end }}
+{{- range $value := $.Schema.RowValues }}
+{{- if eq $value.Type.Type 1 }}
+	values.{{$value.Title}} = {{$value.Type.DecodeFunc}}({{$value.Type.Size}}, v.GetField_bytes({{$value.Index}}))
+{{- else if eq $value.Type.Type 3 }}
+	values.{{$value.Title}} = v.Get{{$value.Title}}()
+{{- end }}
+{{- end }}
+	return values
 }
+
+// SetValues writes all the values of the row, storing every slot only once.
+{{- if $.Schema.CappedValues }}
+// It returns an error without writing anything if a value is longer than its
+// maximum length.
+{{- end }}
+func (v *{{$.RowStructName}}) SetValues(values {{$.TableStructName}}Values) {{if $.Schema.CappedValues}}error {{end}}{
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+{{- range $value := $.Schema.CappedValues }}
+	if err := codec.CheckMaxLen("{{$value.Name}}", {{$value.MaxLen}}, {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}})); err != nil {
+		return err
+	}
+{{- end }}
+{{- if $.Schema.HasOptional }}
+	presence := make([]byte, {{$.Schema.PresenceSize}})
+{{- end }}
+{{- range $value := $packed }}
+{{- if $value.Type.Elem }}
+	{{$value.Name}}Data := make([]byte, 0, {{$value.Type.Size}})
+	for _, elem := range values.{{$value.Title}} {
+		{{$value.Name}}Data = append({{$value.Name}}Data, {{$value.Type.Elem.EncodeFunc}}({{$value.Type.Elem.Size}}, elem)...)
+	}
+{{- else if $value.Optional }}
+	{{$value.Name}}Data := make([]byte, {{$value.Type.Size}})
+	if values.Has{{$value.Title}} {
+		{{$value.Name}}Data = {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}})
+		presence[{{$value.PresenceByte}}] |= {{$value.PresenceFlag}}
+	}
+{{- end }}
+{{- end }}
+{{- if $packed }}
+	v.SetFields([]int{ {{- $.Schema.PackedIndices -}} }, [][]byte{
+{{- range $value := $packed }}
+		{{if or $value.Type.Elem $value.Optional}}{{$value.Name}}Data{{else}}{{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}}){{end}},
+{{- end }}
+{{- if $.Schema.HasOptional }}
+		presence,
+{{- end }}
+	})
+{{- end }}
+{{- range $value := $.Schema.RowValues }}
+{{- if $value.Hashed }}
+	v.setHashed({{$value.Index}}, {{$.Schema.HashIndex $value}}, {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}}))
+{{- else if eq $value.Type.Type 1 }}
+	v.SetField_bytes({{$value.Index}}, {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}}))
+{{- else if eq $value.Type.Type 3 }}
+	v.Set{{$value.Title}}(values.{{$value.Title}})
+{{- end }}
+{{- end }}
+{{- if $.Schema.Emit }}
+{{- range $value := $.Schema.RowValues }}
+	v.emit({{$value.Index}}, {{if and (eq $value.Type.Type 0) (or $value.Type.Elem $value.Optional)}}{{$value.Name}}Data{{else}}{{$value.Type.EncodeFunc}}({{$value.Type.Size}}, values.{{$value.Title}}){{end}})
+{{- end }}
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+{{- if $.Schema.CappedValues }}
+	return nil
+{{- end }}
+}
+
+// Equal reports whether all the values of two rows are equal. Absent optional
+// values are equal whatever their value.
+func (a {{$.TableStructName}}Values) Equal(b {{$.TableStructName}}Values) bool {
+{{- range $k, $value := $.Schema.RowValues }}
+	{{if eq $k 0}}return {{else}}	{{end}}{{$value.CompareExpr "a" "b"}} == 0{{if lt (add $k 1) (len $.Schema.RowValues)}} &&{{end}}
+{{- end }}
+}
+{{- if $.Schema.Packable }}
+
+// Packed returns the values concatenated in declaration order as solidity does
+// with abi.encodePacked, e.g. to reproduce the hash of a row computed by a
+// contract. Dynamic values are not padded or length prefixed, while the
+// elements of arrays are padded to 32 bytes. Optional values are encoded
+// whether they are set or not.
+func (v {{$.TableStructName}}Values) Packed() []byte {
+	var data []byte
+{{- range $value := $.Schema.RowValues }}
+{{- if $value.Type.Elem }}
+	for _, elem := range v.{{$value.Title}} {
+		data = append(data, {{$value.Type.Elem.PaddedEncodeExpr "elem"}}...)
+	}
+{{- else }}
+	data = append(data, {{$value.Type.PackedEncodeExpr (printf "v.%s" $value.Title)}}...)
+{{- end }}
+{{- end }}
+	return data
+}
+{{- end }}
+{{- if $.MultiReturn }}
+
+// tuple returns the values as the results of GetRow.
+func (v {{$.TableStructName}}Values) tuple() (
+{{- range $value := $.Schema.RowValues }}
+	{{$value.Type.GoType}},
+{{- if $value.Optional }}
+	bool,
+{{- end }}
+{{- end }}
+) {
+	return {{ range $k, $value := $.Schema.RowValues }}{{if $k}}, {{end}}v.{{$value.Title}}{{if $value.Optional}}, v.Has{{$value.Title}}{{end}}{{end}}
+}
+{{- end }}
+{{- if $.Schema.OrderedValues }}
+
+// Compare orders rows by {{$.Schema.OrderedNames}}, returning -1, 0 or 1.
+func (a {{$.TableStructName}}Values) Compare(b {{$.TableStructName}}Values) int {
+{{- range $value := $.Schema.OrderedValues }}
+	if c := {{$value.CompareExpr "a" "b"}}; c != 0 {
+		return c
+	}
+{{- end }}
+	return 0
+}
+
+// Less reports whether a is ordered before b, e.g. to sort rows with sort.Slice.
+func (a {{$.TableStructName}}Values) Less(b {{$.TableStructName}}Values) bool {
+	return a.Compare(b) < 0
+}
+{{- end }}
+
+// json{{$.TableStructName}}Values is the JSON representation of {{$.TableStructName}}Values.
+type json{{$.TableStructName}}Values struct {
+{{- range $value := $.Schema.RowValues }}
+	{{$value.Title}} {{if $value.Optional}}*{{end}}{{$value.Type.JSONType}} `json:"{{$value.Name}}"`
+{{- end }}
+}
+
+// MarshalJSON encodes the values as an object keyed by value name, with bytes
+// as hex strings and big integers as decimal strings. Absent optional values
+// are null.
+func (v {{$.TableStructName}}Values) MarshalJSON() ([]byte, error) {
+	var j json{{$.TableStructName}}Values
+{{- range $value := $.Schema.RowValues }}
+{{- if $value.Optional }}
+	if v.Has{{$value.Title}} {
+		var value {{$value.Type.JSONType}}
+		{{indent 2 ($value.Type.ToJSONStmt "value" (printf "v.%s" $value.Title))}}
+		j.{{$value.Title}} = &value
+	}
+{{- else }}
+	{{indent 1 ($value.Type.ToJSONStmt (printf "j.%s" $value.Title) (printf "v.%s" $value.Title))}}
+{{- end }}
+{{- end }}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes values encoded by MarshalJSON.
+func (v *{{$.TableStructName}}Values) UnmarshalJSON(data []byte) error {
+	var j json{{$.TableStructName}}Values
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+{{- if $.Schema.JSONNeedsErr }}
+	var err error
+{{- end }}
+	var values {{$.TableStructName}}Values
+{{- range $value := $.Schema.RowValues }}
+{{- if $value.Optional }}
+	if j.{{$value.Title}} != nil {
+		{{indent 2 ($value.Type.FromJSONStmt $value.Name (printf "values.%s" $value.Title) (printf "*j.%s" $value.Title))}}
+		values.Has{{$value.Title}} = true
+	}
+{{- else }}
+	{{indent 1 ($value.Type.FromJSONStmt $value.Name (printf "values.%s" $value.Title) (printf "j.%s" $value.Title))}}
+{{- end }}
+{{- end }}
+	*v = values
+	return nil
+}
+{{- end }}
 {{range $value := .Schema.Values}}
-{{- if lt $value.Type.Type 2 }}
+{{- if eq $value.Type.Type 3 }}
+type {{$.RowStructName}}{{$value.Title}}Array struct {
+	arr lib.ContiguousArray
+{{- if or $.Schema.Iterable $.Schema.StrictInit }}
+	row *{{$.RowStructName}}
+{{- end }}
+}
+
+func (a *{{$.RowStructName}}{{$value.Title}}Array) Len() uint64 {
+	return a.arr.Length()
+}
+
+func (a *{{$.RowStructName}}{{$value.Title}}Array) Get(index uint64) {{$value.Type.Elem.GoType}} {
+	slotRef := a.arr.Get(index)
+	if slotRef == nil {
+		panic("index out of bounds")
+	}
+	data := slotRef.Bytes32()
+{{- if $.MaskDirtyBytes }}
+	return {{$value.Type.Elem.DecodeFunc}}({{$value.Type.Elem.Size}}, data[32-{{$value.Type.Elem.Size}}:])
+{{- else }}
+	return {{$value.Type.Elem.DecodeFunc}}({{$value.Type.Elem.Size}}, codec.MustWordBytes({{$value.Type.Elem.Size}}, data[:]))
+{{- end }}
+}
+
+func (a *{{$.RowStructName}}{{$value.Title}}Array) Set(index uint64, value {{$value.Type.Elem.GoType}}) {
+{{- if $.Schema.StrictInit }}
+	a.row.mustExist()
+{{- end }}
+	slotRef := a.arr.Get(index)
+	if slotRef == nil {
+		panic("index out of bounds")
+	}
+	data := {{$value.Type.Elem.EncodeFunc}}({{$value.Type.Elem.Size}}, value)
+	slotRef.SetBytes32(common.BytesToHash(data))
+{{- if $.Schema.Iterable }}
+	a.row.written()
+{{- end }}
+}
+
+func (a *{{$.RowStructName}}{{$value.Title}}Array) Push(value {{$value.Type.Elem.GoType}}) {
+{{- if $.Schema.StrictInit }}
+	a.row.mustExist()
+{{- end }}
+	data := {{$value.Type.Elem.EncodeFunc}}({{$value.Type.Elem.Size}}, value)
+	a.arr.Push().SetBytes32(common.BytesToHash(data))
+{{- if $.Schema.Iterable }}
+	a.row.written()
+{{- end }}
+}
+
+func (v *{{$.RowStructName}}) Get{{$value.Title}}Array() *{{$.RowStructName}}{{$value.Title}}Array {
+	dsSlot := v.GetField_slot({{$value.Index}})
+	return &{{$.RowStructName}}{{$value.Title}}Array{dsSlot.ContiguousArray(){{if or $.Schema.Iterable $.Schema.StrictInit}}, v{{end}}}
+}
+
 func (v *{{$.RowStructName}}) Get{{$value.Title}}() {{$value.Type.GoType}} {
-	data := {{if eq $value.Type.Type 0}}v.GetField{{else}}v.GetField_bytes{{end}}({{$value.Index}})
-	return codec.{{$value.Type.DecodeFunc}}({{$value.Type.Size}}, data)
+	arr := v.Get{{$value.Title}}Array()
+	value := make({{$value.Type.GoType}}, arr.Len())
+	for ii := range value {
+		value[ii] = arr.Get(uint64(ii))
+	}
+	return value
+}
+
+func (v *{{$.RowStructName}}) Set{{$value.Title}}(value {{$value.Type.GoType}}) {
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+	arr := v.Get{{$value.Title}}Array()
+	for arr.Len() > uint64(len(value)) {
+		arr.arr.Pop()
+	}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+	for ii, elem := range value {
+		if uint64(ii) < arr.Len() {
+			arr.Set(uint64(ii), elem)
+		} else {
+			arr.Push(elem)
+		}
+	}
+}
+{{ else if $value.Type.Elem }}
+func (v *{{$.RowStructName}}) Get{{$value.Title}}() {{$value.Type.GoType}} {
+	var value {{$value.Type.GoType}}
+	data := v.GetField({{$value.Index}})
+	for ii := range value {
+		value[ii] = {{$value.Type.Elem.DecodeFunc}}({{$value.Type.Elem.Size}}, data[ii*{{$value.Type.Elem.Size}}:(ii+1)*{{$value.Type.Elem.Size}}])
+	}
+	return value
+}
+
+func (v *{{$.RowStructName}}) Set{{$value.Title}}(value {{$value.Type.GoType}}) {
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+	data := make([]byte, 0, {{$value.Type.Size}})
+	for _, elem := range value {
+		data = append(data, {{$value.Type.Elem.EncodeFunc}}({{$value.Type.Elem.Size}}, elem)...)
+	}
+	v.SetField({{$value.Index}}, data)
+{{- if $.Schema.Emit }}
+	v.emit({{$value.Index}}, data)
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+}
+{{ else if $value.Optional }}
+// Get{{$value.Title}} returns the zero value and false if {{$value.Name}} is not set.
+func (v *{{$.RowStructName}}) Get{{$value.Title}}() ({{$value.Type.GoType}}, bool) {
+	if !v.isPresent({{$value.PresenceBit}}) {
+		var value {{$value.Type.GoType}}
+		return value, false
+	}
+	data := v.GetField({{$value.Index}})
+	return {{$value.Type.DecodeFunc}}({{$value.Type.Size}}, data), true
 }
 
 func (v *{{$.RowStructName}}) Set{{$value.Title}}(value {{$value.Type.GoType}}) {
-	data := codec.{{$value.Type.EncodeFunc}}({{$value.Type.Size}}, value)
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+	data := {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, value)
+	v.SetField({{$value.Index}}, data)
+	v.setPresent({{$value.PresenceBit}}, true)
+{{- if $.Schema.Emit }}
+	v.emit({{$value.Index}}, data)
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+}
+
+func (v *{{$.RowStructName}}) Clear{{$value.Title}}() {
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+	v.SetField({{$value.Index}}, make([]byte, {{$value.Type.Size}}))
+	v.setPresent({{$value.PresenceBit}}, false)
+{{- if $.Schema.Emit }}
+	v.emit({{$value.Index}}, make([]byte, {{$value.Type.Size}}))
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+}
+{{ else if lt $value.Type.Type 2 }}
+{{- if $value.Default }}
+// Get{{$value.Title}} returns the default value of {{$value.Name}} while all the slots of the row are zero.
+{{- end }}
+func (v *{{$.RowStructName}}) Get{{$value.Title}}() {{$value.Type.GoType}} {
+	data := {{if eq $value.Type.Type 0}}v.GetField{{else}}v.GetField_bytes{{end}}({{$value.Index}})
+{{- if $value.Default }}
+	if codec.IsZero(data) && v.IsZero() {
+		return {{$value.Default}}
+	}
+{{- end }}
+	return {{$value.Type.DecodeFunc}}({{$value.Type.Size}}, data)
+}
+{{ if $value.MaxLen }}
+// Set{{$value.Title}} returns an error if the value is longer than {{$value.MaxLen}} bytes.
+{{- end }}
+func (v *{{$.RowStructName}}) Set{{$value.Title}}(value {{$value.Type.GoType}}) {{if $value.MaxLen}}error {{end}}{
+{{- if $.Schema.StrictInit }}
+	v.mustExist()
+{{- end }}
+	data := {{$value.Type.EncodeFunc}}({{$value.Type.Size}}, value)
+{{- if $value.MaxLen }}
+	if err := codec.CheckMaxLen("{{$value.Name}}", {{$value.MaxLen}}, data); err != nil {
+		return err
+	}
+{{- end }}
+{{- if $value.Hashed }}
+	v.setHashed({{$value.Index}}, {{$.Schema.HashIndex $value}}, data)
+{{- else }}
 	{{if eq $value.Type.Type 0}}v.SetField{{else}}v.SetField_bytes{{end}}({{$value.Index}}, data)
+{{- end }}
+{{- if $.Schema.Emit }}
+	v.emit({{$value.Index}}, data)
+{{- end }}
+{{- if $.Schema.Iterable }}
+	v.written()
+{{- end }}
+{{- if $value.MaxLen }}
+	return nil
+{{- end }}
+}
+{{- if $value.Type.Counter }}
+
+// Current{{$value.Title}} returns the current value of the {{$value.Name}} counter.
+func (v *{{$.RowStructName}}) Current{{$value.Title}}() {{$value.Type.GoType}} {
+	return v.Get{{$value.Title}}()
 }
+
+// Next{{$value.Title}} returns the current value of the {{$value.Name}} counter and increments it,
+// returning an error without writing anything if the counter overflows.
+func (v *{{$.RowStructName}}) Next{{$value.Title}}() (value {{$value.Type.GoType}}, err error) {
+	current := v.Get{{$value.Title}}()
+	next, err := {{$value.Type.CheckedFunc "Add"}}({{$value.Type.Size}}, current, {{$value.Type.OneExpr}})
+	if err != nil {
+		return value, err
+	}
+	v.Set{{$value.Title}}(next)
+	return current, nil
+}
+
+// Increment{{$value.Title}} adds n to the {{$value.Name}} counter, returning an error without writing
+// anything if the counter overflows.
+func (v *{{$.RowStructName}}) Increment{{$value.Title}}(n {{$value.Type.GoType}}) error {
+	value, err := {{$value.Type.CheckedFunc "Add"}}({{$value.Type.Size}}, v.Get{{$value.Title}}(), n)
+	if err != nil {
+		return err
+	}
+	v.Set{{$value.Title}}(value)
+	return nil
+}
+
+// Decrement{{$value.Title}} subtracts n from the {{$value.Name}} counter, returning an error without
+// writing anything if the counter underflows.
+func (v *{{$.RowStructName}}) Decrement{{$value.Title}}(n {{$value.Type.GoType}}) error {
+	value, err := {{$value.Type.CheckedFunc "Sub"}}({{$value.Type.Size}}, v.Get{{$value.Title}}(), n)
+	if err != nil {
+		return err
+	}
+	v.Set{{$value.Title}}(value)
+	return nil
+}
+{{- else if $value.Type.CheckedFunc "Add" }}
+
+// Add{{$value.Title}} adds delta to {{$value.Name}}, returning an error without writing anything if
+// the result overflows.
+func (v *{{$.RowStructName}}) Add{{$value.Title}}(delta {{$value.Type.GoType}}) error {
+	value, err := {{$value.Type.CheckedFunc "Add"}}({{$value.Type.Size}}, v.Get{{$value.Title}}(), delta)
+	if err != nil {
+		return err
+	}
+	v.Set{{$value.Title}}(value)
+	return nil
+}
+
+// Sub{{$value.Title}} subtracts delta from {{$value.Name}}, returning an error without writing
+// anything if the result overflows.
+func (v *{{$.RowStructName}}) Sub{{$value.Title}}(delta {{$value.Type.GoType}}) error {
+	value, err := {{$value.Type.CheckedFunc "Sub"}}({{$value.Type.Size}}, v.Get{{$value.Title}}(), delta)
+	if err != nil {
+		return err
+	}
+	v.Set{{$value.Title}}(value)
+	return nil
+}
+{{- end }}
 {{ else }}
+// Get{{$value.Title}} returns the {{$value.Name}} table nested in the row. Its base slot is
+// hash(rowSlot . uint256({{$value.Index}}) . "datamod.v1.table") with storage.DefaultHasher, where rowSlot is the
+// first slot of the row and {{$value.Index}} the index of the value in the schema, so
+// nested tables never overlap each other or the fields of the row.
 func (v *{{$.RowStructName}}) Get{{$value.Title}}() *{{$value.Type.GoType}} {
-	dsSlot := v.GetField_slot({{$value.Index}})
-	return New{{$value.Type.GoType}}FromSlot(dsSlot)
+	rowSlot := v.GetBase_slot()
+	key := storage.NestedTableSlot(rowSlot.Slot(), {{$value.Index}}).Bytes()
+	return New{{$value.Type.GoType}}FromSlot(rowSlot.Datastore().Get(key))
+}
+
+// {{$value.Title}} is the same as Get{{$value.Title}}.
+func (v *{{$.RowStructName}}) {{$value.Title}}() *{{$value.Type.GoType}} {
+	return v.Get{{$value.Title}}()
 }
 {{ end}}
 {{- end}}
+// {{$.TableStructName}}RowStore reads and writes whole rows of the table.
+{{- if eq (len $.Schema.RowValues) (len $.Schema.Values) }} It is implemented
+// by both {{$.TableStructName}} and Memory{{$.TableStructName}}, generated with the memory option.
+{{- end }}
+type {{$.TableStructName}}RowStore interface {
+{{- if $.Schema.Keys }}
+	Has(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	) bool
+	Delete(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	)
+{{- if $.Schema.RowValues }}
+	GetRow(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	) {{if $.MultiReturn}}(
+{{- range $result := $.Schema.RowResults }}
+		{{$result.Name}} {{$result.GoType}},
+{{- end }}
+	){{else}}{{$.TableStructName}}Values{{end}}
+	SetRow(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+		row {{$.TableStructName}}Values,
+	){{if $.Schema.CappedValues}} error{{end}}
+{{- end }}
+{{- else }}
+	Has({{if $.Context}}ctx context.Context{{end}}) bool
+	Delete({{if $.Context}}ctx context.Context{{end}})
+{{- if $.Schema.RowValues }}
+	GetRow({{if $.Context}}ctx context.Context{{end}}) {{if $.MultiReturn}}(
+{{- range $result := $.Schema.RowResults }}
+		{{$result.Name}} {{$result.GoType}},
+{{- end }}
+	){{else}}{{$.TableStructName}}Values{{end}}
+	SetRow({{if $.Context}}ctx context.Context, {{end}}row {{$.TableStructName}}Values){{if $.Schema.CappedValues}} error{{end}}
+{{- end }}
+{{- end }}
+}
+
+// {{$.TableStructName}}Store lists all the accessors of {{$.TableStructName}}, e.g. for
+// logic to depend on an interface that tests can replace.
+type {{$.TableStructName}}Store interface {
+	{{$.TableStructName}}RowStore
+{{- if $.Schema.Keys }}
+	Get(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	) *{{$.RowStructName}}
+{{- if $.Schema.Initialized }}
+	Exists(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	) bool
+	Create(
+{{- if $.Context }}
+		ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	) *{{$.RowStructName}}
+{{- end }}
+{{- if $.Schema.Iterable }}
+	Len() uint64
+	KeyAt(index uint64) (
+{{- range $key := $.Schema.Keys }}
+		{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	)
+{{- if gt (len $.Schema.Keys) 1 }}
+	Keys() []{{$.TableStructName}}Key
+	KeysPage(offset, limit uint64) []{{$.TableStructName}}Key
+{{- else }}
+	Keys() []{{(index $.Schema.Keys 0).Type.GoType}}
+	KeysPage(offset, limit uint64) []{{(index $.Schema.Keys 0).Type.GoType}}
+{{- end }}
+{{- end }}
+{{- else }}
+	Get({{if $.Context}}ctx context.Context{{end}}) *{{$.RowStructName}}
+{{- end }}
+}
+
+var _ {{$.TableStructName}}Store = (*{{$.TableStructName}})(nil)
+
 type {{$.TableStructName}} struct {
 	dsSlot lib.DatastoreSlot
+{{- if $.Schema.Emit }}
+	env    api.Environment
+{{- end }}
 }
 
 func New{{$.TableStructName}}(ds lib.Datastore) *{{$.TableStructName}} {
 	dsSlot := ds.Get({{$.TableStructName}}DefaultKey())
-	return &{{$.TableStructName}}{dsSlot}
+	return &{{$.TableStructName}}{ {{- if $.Schema.Emit}}dsSlot: {{end}}dsSlot}
 }
 
 func New{{$.TableStructName}}FromSlot(dsSlot lib.DatastoreSlot) *{{$.TableStructName}} {
-	return &{{$.TableStructName}}{dsSlot}
+	return &{{$.TableStructName}}{ {{- if $.Schema.Emit}}dsSlot: {{end}}dsSlot}
+}
+{{- if $.Schema.Emit }}
+
+// New{{$.TableStructName}}WithEvents returns the table stored in the storage of env, logging
+// {{$.TableStructName}}Event every time a value of a row is written. Tables nested in
+// its rows do not log events.
+func New{{$.TableStructName}}WithEvents(env api.Environment) *{{$.TableStructName}} {
+	dsSlot := lib.NewDatastore(env).Get({{$.TableStructName}}DefaultKey())
+	return &{{$.TableStructName}}{dsSlot: dsSlot, env: env}
 }
 
+func (m *{{$.TableStructName}}) emit(key []byte, index int, data []byte) {
+	if err := lib.EmitTypedEvent(m.env, {{$.TableStructName}}Event, common.BytesToHash(key), uint256.NewInt(uint64(index)), data); err != nil {
+		panic(err)
+	}
+}
+{{- end }}
+{{- if $.Context }}
+
+// withContext returns a copy of the table reading and writing storage through
+// ctx, so that its accessors panic with the error of ctx once it is done.
+func (m *{{$.TableStructName}}) withContext(ctx context.Context) *{{$.TableStructName}} {
+	bound := *m
+	bound.dsSlot = lib.WithContext(ctx, m.dsSlot)
+{{- if $.Schema.Emit }}
+	if m.env != nil {
+		bound.env = lib.NewContextEnvironment(ctx, m.env)
+	}
+{{- end }}
+	return &bound
+}
+{{- end }}
+
 {{- if $.Schema.Keys }}
 func (m *{{$.TableStructName}}) Get(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
 {{- range $key := $.Schema.Keys }}
 	{{$key.Name}} {{$key.Type.GoType}},
 {{- end }}
 ) *{{$.RowStructName}} {
+{{- if $.Context }}
+	m = m.withContext(ctx)
+{{- end }}
+{{- if $.Schema.CompositeKey }}
+	dsSlot := m.dsSlot.Mapping().GetComposite(
+		{{- range $key := $.Schema.Keys }}
+		{{$.Schema.KeyEncodeExpr $key}},
+		{{- end }}
+	)
+{{- else }}
 	dsSlot := m.dsSlot.Mapping().GetNested(
 		{{- range $key := $.Schema.Keys }}
-		codec.{{$key.Type.EncodeFunc}}({{$key.Type.Size}}, {{$key.Name}}),
+		{{$.Schema.KeyEncodeExpr $key}},
 		{{- end }}
 	)
+{{- end }}
+{{- if or $.Schema.Iterable $.Schema.Emit }}
+	row := New{{$.RowStructName}}(dsSlot)
+{{- end }}
+{{- if $.Schema.Iterable }}
+	row.onWrite = func() {
+		m.indexInsert(
+			{{- range $key := $.Schema.Keys }}
+			{{$key.Name}},
+			{{- end }}
+		)
+	}
+	row.onDelete = func() {
+		m.indexRemove(
+			{{- range $key := $.Schema.Keys }}
+			{{$key.Name}},
+			{{- end }}
+		)
+	}
+{{- end }}
+{{- if $.Schema.Emit }}
+	if m.env != nil {
+		row.onSet = func(index int, data []byte) {
+			key := crypto.Keccak256(
+				{{- range $key := $.Schema.Keys }}
+				{{$key.Type.PackedEncodeExpr $key.Name}},
+				{{- end }}
+			)
+			m.emit(key, index, data)
+		}
+	}
+{{- end }}
+{{- if or $.Schema.Iterable $.Schema.Emit }}
+	return row
+{{- else }}
 	return New{{$.RowStructName}}(dsSlot)
+{{- end }}
+}
+
+func (m *{{$.TableStructName}}) Has(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) bool {
+	return !m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	).IsZero()
+}
+
+func (m *{{$.TableStructName}}) Delete(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) {
+	m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	).Delete()
+}
+{{- if $.Schema.Initialized }}
+
+// Exists reports whether the row of the keys was created and not deleted since.
+func (m *{{$.TableStructName}}) Exists(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) bool {
+	return m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	).Exists()
 }
+
+// Create marks the row of the keys as created and returns it. Creating an
+// existing row leaves it unchanged.
+func (m *{{$.TableStructName}}) Create(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) *{{$.RowStructName}} {
+	row := m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	)
+	row.create()
+	return row
+}
+{{- end }}
+{{- if $.Schema.RowValues }}
+
+func (m *{{$.TableStructName}}) GetRow(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) {{if $.MultiReturn}}(
+{{- range $result := $.Schema.RowResults }}
+	{{$result.Name}} {{$result.GoType}},
+{{- end }}
+){{else}}{{$.TableStructName}}Values{{end}} {
+	return m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	).GetValues(){{if $.MultiReturn}}.tuple(){{end}}
+}
+
+func (m *{{$.TableStructName}}) SetRow(
+{{- if $.Context }}
+	ctx context.Context,
+{{- end }}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+	row {{$.TableStructName}}Values,
+) {{if $.Schema.CappedValues}}error {{end}}{
+	{{if $.Schema.CappedValues}}return {{end}}m.Get(
+		{{- if $.Context }}
+		ctx,
+		{{- end }}
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	).SetValues(row)
+}
+{{- end }}
+{{- if $.Schema.Iterable }}
+{{- $nKeys := len $.Schema.Keys }}
+{{- if gt $nKeys 1 }}
+
+type {{$.TableStructName}}Key struct {
+{{- range $key := $.Schema.Keys }}
+	{{$key.Title}} {{$key.Type.GoType}}
+{{- end }}
+}
+{{- end }}
+
+{{- $nArrays := $nKeys }}
+{{- if $.Schema.KeyHashOrder }}
+{{- $nArrays = add $nKeys 1 }}
+{{- end }}
+
+// The index of an iterable table stores the position of every key, offset by
+// one, followed by one array of key values per key.
+{{- if $.Schema.KeyHashOrder }} The last array holds
+// the hashes of the keys, by which the index is sorted.
+{{- end }}
+func (m *{{$.TableStructName}}) index() lib.SlotArray {
+	key := storage.IndexSlot(m.dsSlot.Slot()).Bytes()
+	return m.dsSlot.Datastore().Get(key).SlotArray([]int{ {{- add $nArrays 1 -}} })
+}
+
+func (m *{{$.TableStructName}}) indexPosition(
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) lib.DatastoreSlot {
+	return m.index().Get(0).Mapping().GetNested(
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Type.EncodeFunc}}({{$key.Type.Size}}, {{$key.Name}}),
+		{{- end }}
+	)
+}
+
+func (m *{{$.TableStructName}}) indexKeys(index int) lib.ContiguousArray {
+	return m.index().Get(index + 1).ContiguousArray()
+}
+{{- if $.Schema.KeyHashOrder }}
+
+// keyHash returns the hash of the keys of a row, as hashed by the events of
+// the table.
+func (m *{{$.TableStructName}}) keyHash(
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) common.Hash {
+	return common.BytesToHash(crypto.Keccak256(
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Type.PackedEncodeExpr $key.Name}},
+		{{- end }}
+	))
+}
+
+// indexSearch returns the position of the first key of the index whose hash is
+// not less than hash.
+func (m *{{$.TableStructName}}) indexSearch(hash common.Hash) uint64 {
+	hashes := m.indexKeys({{$nKeys}})
+	lo, hi := uint64(0), m.Len()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if hashes.Get(mid).Bytes32().Cmp(hash) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+{{- end }}
+
+func (m *{{$.TableStructName}}) indexInsert(
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) {
+	position := m.indexPosition(
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	)
+	if position.Uint64() != 0 {
+		return
+	}
+{{- if $.Schema.KeyHashOrder }}
+	hash := m.keyHash(
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	)
+	index := m.indexSearch(hash)
+	length := m.Len()
+	for ii := 0; ii < {{$nArrays}}; ii++ {
+		m.indexKeys(ii).Push()
+	}
+	// Move the keys after the new one up by one position
+	for jj := length; jj > index; jj-- {
+		for ii := 0; ii < {{$nArrays}}; ii++ {
+			keys := m.indexKeys(ii)
+			keys.Get(jj).SetBytes32(keys.Get(jj - 1).Bytes32())
+		}
+		{{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}moved{{$key.Title}}{{end}} := m.KeyAt(jj)
+		m.indexPosition(
+			{{- range $key := $.Schema.Keys }}
+			moved{{$key.Title}},
+			{{- end }}
+		).SetUint64(jj + 1)
+	}
+{{- range $key := $.Schema.Keys }}
+	m.indexKeys({{$key.Index}}).Get(index).SetBytes32(common.BytesToHash({{$key.Type.EncodeFunc}}({{$key.Type.Size}}, {{$key.Name}})))
+{{- end }}
+	m.indexKeys({{$nKeys}}).Get(index).SetBytes32(hash)
+	position.SetUint64(index + 1)
+{{- else }}
+{{- range $key := $.Schema.Keys }}
+	m.indexKeys({{$key.Index}}).Push().SetBytes32(common.BytesToHash({{$key.Type.EncodeFunc}}({{$key.Type.Size}}, {{$key.Name}})))
+{{- end }}
+	position.SetUint64(m.Len())
+{{- end }}
+}
+
+{{ if $.Schema.KeyHashOrder -}}
+// indexRemove removes a key from the index by moving the keys after it down by
+// one position.
+{{- else -}}
+// indexRemove removes a key from the index by moving the last key into its
+// position.
+{{- end }}
+func (m *{{$.TableStructName}}) indexRemove(
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) {
+	position := m.indexPosition(
+		{{- range $key := $.Schema.Keys }}
+		{{$key.Name}},
+		{{- end }}
+	)
+	index := position.Uint64()
+	if index == 0 {
+		return
+	}
+	length := m.Len()
+{{- if $.Schema.KeyHashOrder }}
+	for jj := index; jj < length; jj++ {
+		for ii := 0; ii < {{$nArrays}}; ii++ {
+			keys := m.indexKeys(ii)
+			keys.Get(jj - 1).SetBytes32(keys.Get(jj).Bytes32())
+		}
+		{{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}moved{{$key.Title}}{{end}} := m.KeyAt(jj - 1)
+		m.indexPosition(
+			{{- range $key := $.Schema.Keys }}
+			moved{{$key.Title}},
+			{{- end }}
+		).SetUint64(jj)
+	}
+{{- else }}
+	if index != length {
+		{{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}last{{$key.Title}}{{end}} := m.KeyAt(length - 1)
+		for ii := 0; ii < {{$nKeys}}; ii++ {
+			keys := m.indexKeys(ii)
+			keys.Get(index - 1).SetBytes32(keys.Get(length - 1).Bytes32())
+		}
+		m.indexPosition(
+			{{- range $key := $.Schema.Keys }}
+			last{{$key.Title}},
+			{{- end }}
+		).SetUint64(index)
+	}
+{{- end }}
+	for ii := 0; ii < {{$nArrays}}; ii++ {
+		m.indexKeys(ii).Pop().SetBytes32(common.Hash{})
+	}
+	position.SetUint64(0)
+}
+
+// Len returns the number of rows written to the table and not deleted.
+func (m *{{$.TableStructName}}) Len() uint64 {
+	return m.indexKeys(0).Length()
+}
+
+{{ if $.Schema.KeyHashOrder -}}
+// KeyAt returns the keys of the row at index. Rows are sorted by the keccak256
+// hash of their packed keys, whatever the order they were written in.
+{{ end -}}
+func (m *{{$.TableStructName}}) KeyAt(index uint64) (
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}} {{$key.Type.GoType}},
+{{- end }}
+) {
+	if index >= m.Len() {
+		panic("index out of bounds")
+	}
+{{- range $key := $.Schema.Keys }}
+	{{$key.Name}}Data := m.indexKeys({{$key.Index}}).Get(index).Bytes32()
+{{- end }}
+{{- if $.MaskDirtyBytes }}
+	return {{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}{{$key.Type.DecodeFunc}}({{$key.Type.Size}}, {{$key.Name}}Data[32-{{$key.Type.Size}}:]){{end}}
+{{- else }}
+	return {{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}{{$key.Type.DecodeFunc}}({{$key.Type.Size}}, codec.MustWordBytes({{$key.Type.Size}}, {{$key.Name}}Data[:])){{end}}
+{{- end }}
+}
+{{- if gt $nKeys 1 }}
+
+func (m *{{$.TableStructName}}) Keys() []{{$.TableStructName}}Key {
+	return m.KeysPage(0, m.Len())
+}
+
+// KeysPage returns the keys of at most limit rows of the index starting at
+// offset, e.g. to paginate through the table. It returns an empty slice if
+// offset is past the end of the index.
+func (m *{{$.TableStructName}}) KeysPage(offset, limit uint64) []{{$.TableStructName}}Key {
+	length := m.Len()
+	if offset >= length {
+		return []{{$.TableStructName}}Key{}
+	}
+	if limit > length-offset {
+		limit = length - offset
+	}
+	keys := make([]{{$.TableStructName}}Key, limit)
+	for ii := range keys {
+		{{ range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}{{$key.Name}}{{end}} := m.KeyAt(offset + uint64(ii))
+		keys[ii] = {{$.TableStructName}}Key{ {{- range $key := $.Schema.Keys }}{{if $key.Index}}, {{end}}{{$key.Title}}: {{$key.Name}}{{end -}} }
+	}
+	return keys
+}
+{{- else }}
+{{- $key := index $.Schema.Keys 0 }}
+
+func (m *{{$.TableStructName}}) Keys() []{{$key.Type.GoType}} {
+	return m.KeysPage(0, m.Len())
+}
+
+// KeysPage returns the keys of at most limit rows of the index starting at
+// offset, e.g. to paginate through the table. It returns an empty slice if
+// offset is past the end of the index.
+func (m *{{$.TableStructName}}) KeysPage(offset, limit uint64) []{{$key.Type.GoType}} {
+	length := m.Len()
+	if offset >= length {
+		return []{{$key.Type.GoType}}{}
+	}
+	if limit > length-offset {
+		limit = length - offset
+	}
+	keys := make([]{{$key.Type.GoType}}, limit)
+	for ii := range keys {
+		keys[ii] = m.KeyAt(offset + uint64(ii))
+	}
+	return keys
+}
+{{- end }}
+{{- end }}
+{{- else }}
+func (m *{{$.TableStructName}}) Get({{if $.Context}}ctx context.Context{{end}}) *{{$.RowStructName}} {
+{{- if $.Context }}
+	m = m.withContext(ctx)
+{{- end }}
+{{- if $.Schema.Emit }}
+	row := New{{$.RowStructName}}(m.dsSlot)
+	if m.env != nil {
+		row.onSet = func(index int, data []byte) {
+			m.emit(nil, index, data)
+		}
+	}
+	return row
 {{- else }}
-func (m *{{$.TableStructName}}) Get() *{{$.RowStructName}} {
 	return New{{$.RowStructName}}(m.dsSlot)
+{{- end }}
+}
+
+func (m *{{$.TableStructName}}) Has({{if $.Context}}ctx context.Context{{end}}) bool {
+	return !m.Get({{if $.Context}}ctx{{end}}).IsZero()
+}
+
+func (m *{{$.TableStructName}}) Delete({{if $.Context}}ctx context.Context{{end}}) {
+	m.Get({{if $.Context}}ctx{{end}}).Delete()
 }
+{{- if $.Schema.RowValues }}
+
+func (m *{{$.TableStructName}}) GetRow({{if $.Context}}ctx context.Context{{end}}) {{if $.MultiReturn}}(
+{{- range $result := $.Schema.RowResults }}
+	{{$result.Name}} {{$result.GoType}},
+{{- end }}
+){{else}}{{$.TableStructName}}Values{{end}} {
+	return m.Get({{if $.Context}}ctx{{end}}).GetValues(){{if $.MultiReturn}}.tuple(){{end}}
+}
+
+func (m *{{$.TableStructName}}) SetRow({{if $.Context}}ctx context.Context, {{end}}row {{$.TableStructName}}Values) {{if $.Schema.CappedValues}}error {{end}}{
+	{{if $.Schema.CappedValues}}return {{end}}m.Get({{if $.Context}}ctx{{end}}).SetValues(row)
+}
+{{- end }}
 {{- end }}
\ No newline at end of file
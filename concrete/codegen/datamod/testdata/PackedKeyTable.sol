@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+pragma solidity ^0.8.0;
+
+/*
+This contract computes the row slots of the PackedKeyTable table as solidity
+would, to check them against the slots of the generated go code.
+*/
+
+contract PackedKeyTable {
+    bytes32 constant BASE = keccak256("datamod.v1.PackedKeyTable");
+
+    function rowSlot(uint32 id, int16 delta, bool flag, bytes4 tag, address owner) external pure returns (bytes32) {
+        return keccak256(abi.encodePacked(id, delta, flag, tag, owner, BASE));
+    }
+}
@@ -3,26 +3,31 @@
 package testdata
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeyedTableDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeyedTable"))
+//	KeyedTableDefaultKey = storage.TableSlot("KeyedTable").Bytes()
 // )
 
 func KeyedTableDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeyedTable"))
+	return storage.TableSlot("KeyedTable").Bytes()
 }
 
 type KeyedTableRow struct {
@@ -35,12 +40,12 @@ func NewKeyedTableRow(dsSlot lib.DatastoreSlot) *KeyedTableRow {
 }
 
 func (v *KeyedTableRow) Get() (
-	*uint256.Int,
-	string,
-	[]byte,
-	bool,
-	common.Address,
-	[]byte,
+	valueUint *uint256.Int,
+	valueString string,
+	valueBytes []byte,
+	valueBool bool,
+	valueAddress common.Address,
+	valueBytes16 []byte,
 ) {
 	return codec.DecodeUint256(32, v.GetField(0)),
 		codec.DecodeString(32, v.GetField_bytes(1)),
@@ -66,6 +71,120 @@ func (v *KeyedTableRow) Set(
 	v.SetField(5, codec.EncodeFixedBytes(16, valueBytes16))
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeyedTableRow) Delete() {
+	v.GetField_slot(1).ClearBytes()
+	v.GetField_slot(2).ClearBytes()
+	v.Clear()
+}
+
+// KeyedTableValues holds all the values of a row, except tables.
+type KeyedTableValues struct {
+	ValueUint *uint256.Int
+	ValueString string
+	ValueBytes []byte
+	ValueBool bool
+	ValueAddress common.Address
+	ValueBytes16 []byte
+}
+
+// GetValues reads all the values of the row, loading every slot only once.
+func (v *KeyedTableRow) GetValues() KeyedTableValues {
+	var values KeyedTableValues
+	fields := v.GetFields(0, 3, 4, 5)
+	values.ValueUint = codec.DecodeUint256(32, fields[0])
+	values.ValueBool = codec.DecodeBool(1, fields[1])
+	values.ValueAddress = codec.DecodeAddress(20, fields[2])
+	values.ValueBytes16 = codec.DecodeFixedBytes(16, fields[3])
+	values.ValueString = codec.DecodeString(32, v.GetField_bytes(1))
+	values.ValueBytes = codec.DecodeBytes(32, v.GetField_bytes(2))
+	return values
+}
+
+// SetValues writes all the values of the row, storing every slot only once.
+func (v *KeyedTableRow) SetValues(values KeyedTableValues) {
+	v.SetFields([]int{0, 3, 4, 5}, [][]byte{
+		codec.EncodeUint256(32, values.ValueUint),
+		codec.EncodeBool(1, values.ValueBool),
+		codec.EncodeAddress(20, values.ValueAddress),
+		codec.EncodeFixedBytes(16, values.ValueBytes16),
+	})
+	v.SetField_bytes(1, codec.EncodeString(32, values.ValueString))
+	v.SetField_bytes(2, codec.EncodeBytes(32, values.ValueBytes))
+}
+
+// Equal reports whether all the values of two rows are equal. Absent optional
+// values are equal whatever their value.
+func (a KeyedTableValues) Equal(b KeyedTableValues) bool {
+	return codec.CompareUint256(a.ValueUint, b.ValueUint) == 0 &&
+		codec.Compare(a.ValueString, b.ValueString) == 0 &&
+		codec.CompareBytes(a.ValueBytes, b.ValueBytes) == 0 &&
+		codec.CompareBool(a.ValueBool, b.ValueBool) == 0 &&
+		codec.CompareBytes(a.ValueAddress[:], b.ValueAddress[:]) == 0 &&
+		codec.CompareBytes(a.ValueBytes16, b.ValueBytes16) == 0
+}
+
+// Packed returns the values concatenated in declaration order as solidity does
+// with abi.encodePacked, e.g. to reproduce the hash of a row computed by a
+// contract. Dynamic values are not padded or length prefixed, while the
+// elements of arrays are padded to 32 bytes. Optional values are encoded
+// whether they are set or not.
+func (v KeyedTableValues) Packed() []byte {
+	var data []byte
+	data = append(data, codec.EncodeUint256(32, v.ValueUint)...)
+	data = append(data, codec.EncodeString(32, v.ValueString)...)
+	data = append(data, codec.EncodeBytes(32, v.ValueBytes)...)
+	data = append(data, codec.EncodeBool(1, v.ValueBool)...)
+	data = append(data, codec.EncodeAddress(20, v.ValueAddress)...)
+	data = append(data, codec.EncodeFixedBytes(16, v.ValueBytes16)...)
+	return data
+}
+
+// jsonKeyedTableValues is the JSON representation of KeyedTableValues.
+type jsonKeyedTableValues struct {
+	ValueUint *uint256.Int `json:"valueUint"`
+	ValueString string `json:"valueString"`
+	ValueBytes hexutil.Bytes `json:"valueBytes"`
+	ValueBool bool `json:"valueBool"`
+	ValueAddress common.Address `json:"valueAddress"`
+	ValueBytes16 hexutil.Bytes `json:"valueBytes16"`
+}
+
+// MarshalJSON encodes the values as an object keyed by value name, with bytes
+// as hex strings and big integers as decimal strings. Absent optional values
+// are null.
+func (v KeyedTableValues) MarshalJSON() ([]byte, error) {
+	var j jsonKeyedTableValues
+	j.ValueUint = v.ValueUint
+	j.ValueString = v.ValueString
+	j.ValueBytes = hexutil.Bytes(v.ValueBytes)
+	j.ValueBool = v.ValueBool
+	j.ValueAddress = v.ValueAddress
+	j.ValueBytes16 = hexutil.Bytes(v.ValueBytes16)
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes values encoded by MarshalJSON.
+func (v *KeyedTableValues) UnmarshalJSON(data []byte) error {
+	var j jsonKeyedTableValues
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var values KeyedTableValues
+	values.ValueUint = j.ValueUint
+	values.ValueString = j.ValueString
+	values.ValueBytes = []byte(j.ValueBytes)
+	values.ValueBool = j.ValueBool
+	values.ValueAddress = j.ValueAddress
+	if err := codec.CheckMaxLen("valueBytes16", 16, j.ValueBytes16); err != nil {
+		return err
+	}
+	values.ValueBytes16 = []byte(j.ValueBytes16)
+	*v = values
+	return nil
+}
+
 func (v *KeyedTableRow) GetValueUint() *uint256.Int {
 	data := v.GetField(0)
 	return codec.DecodeUint256(32, data)
@@ -76,6 +195,28 @@ func (v *KeyedTableRow) SetValueUint(value *uint256.Int) {
 	v.SetField(0, data)
 }
 
+// AddValueUint adds delta to valueUint, returning an error without writing anything if
+// the result overflows.
+func (v *KeyedTableRow) AddValueUint(delta *uint256.Int) error {
+	value, err := codec.AddBigUint(32, v.GetValueUint(), delta)
+	if err != nil {
+		return err
+	}
+	v.SetValueUint(value)
+	return nil
+}
+
+// SubValueUint subtracts delta from valueUint, returning an error without writing
+// anything if the result overflows.
+func (v *KeyedTableRow) SubValueUint(delta *uint256.Int) error {
+	value, err := codec.SubBigUint(32, v.GetValueUint(), delta)
+	if err != nil {
+		return err
+	}
+	v.SetValueUint(value)
+	return nil
+}
+
 func (v *KeyedTableRow) GetValueString() string {
 	data := v.GetField_bytes(1)
 	return codec.DecodeString(32, data)
@@ -126,6 +267,60 @@ func (v *KeyedTableRow) SetValueBytes16(value []byte) {
 	v.SetField(5, data)
 }
 
+// KeyedTableRowStore reads and writes whole rows of the table. It is implemented
+// by both KeyedTable and MemoryKeyedTable, generated with the memory option.
+type KeyedTableRowStore interface {
+	Has(
+		keyUint *uint256.Int,
+		keyString string,
+		keyBytes []byte,
+		keyBool bool,
+		keyAddress common.Address,
+		keyBytes16 []byte,
+	) bool
+	Delete(
+		keyUint *uint256.Int,
+		keyString string,
+		keyBytes []byte,
+		keyBool bool,
+		keyAddress common.Address,
+		keyBytes16 []byte,
+	)
+	GetRow(
+		keyUint *uint256.Int,
+		keyString string,
+		keyBytes []byte,
+		keyBool bool,
+		keyAddress common.Address,
+		keyBytes16 []byte,
+	) KeyedTableValues
+	SetRow(
+		keyUint *uint256.Int,
+		keyString string,
+		keyBytes []byte,
+		keyBool bool,
+		keyAddress common.Address,
+		keyBytes16 []byte,
+		row KeyedTableValues,
+	)
+}
+
+// KeyedTableStore lists all the accessors of KeyedTable, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeyedTableStore interface {
+	KeyedTableRowStore
+	Get(
+		keyUint *uint256.Int,
+		keyString string,
+		keyBytes []byte,
+		keyBool bool,
+		keyAddress common.Address,
+		keyBytes16 []byte,
+	) *KeyedTableRow
+}
+
+var _ KeyedTableStore = (*KeyedTable)(nil)
+
 type KeyedTable struct {
 	dsSlot lib.DatastoreSlot
 }
@@ -138,7 +333,6 @@ func NewKeyedTable(ds lib.Datastore) *KeyedTable {
 func NewKeyedTableFromSlot(dsSlot lib.DatastoreSlot) *KeyedTable {
 	return &KeyedTable{dsSlot}
 }
-
 func (m *KeyedTable) Get(
 	keyUint *uint256.Int,
 	keyString string,
@@ -157,3 +351,76 @@ func (m *KeyedTable) Get(
 	)
 	return NewKeyedTableRow(dsSlot)
 }
+
+func (m *KeyedTable) Has(
+	keyUint *uint256.Int,
+	keyString string,
+	keyBytes []byte,
+	keyBool bool,
+	keyAddress common.Address,
+	keyBytes16 []byte,
+) bool {
+	return !m.Get(
+		keyUint,
+		keyString,
+		keyBytes,
+		keyBool,
+		keyAddress,
+		keyBytes16,
+	).IsZero()
+}
+
+func (m *KeyedTable) Delete(
+	keyUint *uint256.Int,
+	keyString string,
+	keyBytes []byte,
+	keyBool bool,
+	keyAddress common.Address,
+	keyBytes16 []byte,
+) {
+	m.Get(
+		keyUint,
+		keyString,
+		keyBytes,
+		keyBool,
+		keyAddress,
+		keyBytes16,
+	).Delete()
+}
+
+func (m *KeyedTable) GetRow(
+	keyUint *uint256.Int,
+	keyString string,
+	keyBytes []byte,
+	keyBool bool,
+	keyAddress common.Address,
+	keyBytes16 []byte,
+) KeyedTableValues {
+	return m.Get(
+		keyUint,
+		keyString,
+		keyBytes,
+		keyBool,
+		keyAddress,
+		keyBytes16,
+	).GetValues()
+}
+
+func (m *KeyedTable) SetRow(
+	keyUint *uint256.Int,
+	keyString string,
+	keyBytes []byte,
+	keyBool bool,
+	keyAddress common.Address,
+	keyBytes16 []byte,
+	row KeyedTableValues,
+) {
+	m.Get(
+		keyUint,
+		keyString,
+		keyBytes,
+		keyBool,
+		keyAddress,
+		keyBytes16,
+	).SetValues(row)
+}
\ No newline at end of file
@@ -4,25 +4,29 @@ package testdata
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeyedWithKeyedTableValueDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeyedWithKeyedTableValue"))
+//	KeyedWithKeyedTableValueDefaultKey = storage.TableSlot("KeyedWithKeyedTableValue").Bytes()
 // )
 
 func KeyedWithKeyedTableValueDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeyedWithKeyedTableValue"))
+	return storage.TableSlot("KeyedWithKeyedTableValue").Bytes()
 }
 
 type KeyedWithKeyedTableValueRow struct {
@@ -35,20 +39,57 @@ func NewKeyedWithKeyedTableValueRow(dsSlot lib.DatastoreSlot) *KeyedWithKeyedTab
 }
 
 func (v *KeyedWithKeyedTableValueRow) Get() (
-	*KeyedTable,
+	valueTable *KeyedTable,
 ) {
-	return NewKeyedTableFromSlot(v.GetField_slot(0))
+	return v.GetValueTable()
 }
 
 func (v *KeyedWithKeyedTableValueRow) Set(
 ) {
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeyedWithKeyedTableValueRow) Delete() {
+	v.Clear()
+}
+
+// GetValueTable returns the valueTable table nested in the row. Its base slot is
+// hash(rowSlot . uint256(0) . "datamod.v1.table") with storage.DefaultHasher, where rowSlot is the
+// first slot of the row and 0 the index of the value in the schema, so
+// nested tables never overlap each other or the fields of the row.
 func (v *KeyedWithKeyedTableValueRow) GetValueTable() *KeyedTable {
-	dsSlot := v.GetField_slot(0)
-	return NewKeyedTableFromSlot(dsSlot)
+	rowSlot := v.GetBase_slot()
+	key := storage.NestedTableSlot(rowSlot.Slot(), 0).Bytes()
+	return NewKeyedTableFromSlot(rowSlot.Datastore().Get(key))
+}
+
+// ValueTable is the same as GetValueTable.
+func (v *KeyedWithKeyedTableValueRow) ValueTable() *KeyedTable {
+	return v.GetValueTable()
+}
+
+// KeyedWithKeyedTableValueRowStore reads and writes whole rows of the table.
+type KeyedWithKeyedTableValueRowStore interface {
+	Has(
+		keyUint *uint256.Int,
+	) bool
+	Delete(
+		keyUint *uint256.Int,
+	)
+}
+
+// KeyedWithKeyedTableValueStore lists all the accessors of KeyedWithKeyedTableValue, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeyedWithKeyedTableValueStore interface {
+	KeyedWithKeyedTableValueRowStore
+	Get(
+		keyUint *uint256.Int,
+	) *KeyedWithKeyedTableValueRow
 }
 
+var _ KeyedWithKeyedTableValueStore = (*KeyedWithKeyedTableValue)(nil)
+
 type KeyedWithKeyedTableValue struct {
 	dsSlot lib.DatastoreSlot
 }
@@ -61,7 +102,6 @@ func NewKeyedWithKeyedTableValue(ds lib.Datastore) *KeyedWithKeyedTableValue {
 func NewKeyedWithKeyedTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeyedWithKeyedTableValue {
 	return &KeyedWithKeyedTableValue{dsSlot}
 }
-
 func (m *KeyedWithKeyedTableValue) Get(
 	keyUint *uint256.Int,
 ) *KeyedWithKeyedTableValueRow {
@@ -70,3 +110,19 @@ func (m *KeyedWithKeyedTableValue) Get(
 	)
 	return NewKeyedWithKeyedTableValueRow(dsSlot)
 }
+
+func (m *KeyedWithKeyedTableValue) Has(
+	keyUint *uint256.Int,
+) bool {
+	return !m.Get(
+		keyUint,
+	).IsZero()
+}
+
+func (m *KeyedWithKeyedTableValue) Delete(
+	keyUint *uint256.Int,
+) {
+	m.Get(
+		keyUint,
+	).Delete()
+}
\ No newline at end of file
@@ -4,25 +4,29 @@ package testdata
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeyedWithKeylessTableValueDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeyedWithKeylessTableValue"))
+//	KeyedWithKeylessTableValueDefaultKey = storage.TableSlot("KeyedWithKeylessTableValue").Bytes()
 // )
 
 func KeyedWithKeylessTableValueDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeyedWithKeylessTableValue"))
+	return storage.TableSlot("KeyedWithKeylessTableValue").Bytes()
 }
 
 type KeyedWithKeylessTableValueRow struct {
@@ -35,20 +39,57 @@ func NewKeyedWithKeylessTableValueRow(dsSlot lib.DatastoreSlot) *KeyedWithKeyles
 }
 
 func (v *KeyedWithKeylessTableValueRow) Get() (
-	*KeylessTable,
+	valueTable *KeylessTable,
 ) {
-	return NewKeylessTableFromSlot(v.GetField_slot(0))
+	return v.GetValueTable()
 }
 
 func (v *KeyedWithKeylessTableValueRow) Set(
 ) {
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeyedWithKeylessTableValueRow) Delete() {
+	v.Clear()
+}
+
+// GetValueTable returns the valueTable table nested in the row. Its base slot is
+// hash(rowSlot . uint256(0) . "datamod.v1.table") with storage.DefaultHasher, where rowSlot is the
+// first slot of the row and 0 the index of the value in the schema, so
+// nested tables never overlap each other or the fields of the row.
 func (v *KeyedWithKeylessTableValueRow) GetValueTable() *KeylessTable {
-	dsSlot := v.GetField_slot(0)
-	return NewKeylessTableFromSlot(dsSlot)
+	rowSlot := v.GetBase_slot()
+	key := storage.NestedTableSlot(rowSlot.Slot(), 0).Bytes()
+	return NewKeylessTableFromSlot(rowSlot.Datastore().Get(key))
+}
+
+// ValueTable is the same as GetValueTable.
+func (v *KeyedWithKeylessTableValueRow) ValueTable() *KeylessTable {
+	return v.GetValueTable()
+}
+
+// KeyedWithKeylessTableValueRowStore reads and writes whole rows of the table.
+type KeyedWithKeylessTableValueRowStore interface {
+	Has(
+		keyUint *uint256.Int,
+	) bool
+	Delete(
+		keyUint *uint256.Int,
+	)
+}
+
+// KeyedWithKeylessTableValueStore lists all the accessors of KeyedWithKeylessTableValue, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeyedWithKeylessTableValueStore interface {
+	KeyedWithKeylessTableValueRowStore
+	Get(
+		keyUint *uint256.Int,
+	) *KeyedWithKeylessTableValueRow
 }
 
+var _ KeyedWithKeylessTableValueStore = (*KeyedWithKeylessTableValue)(nil)
+
 type KeyedWithKeylessTableValue struct {
 	dsSlot lib.DatastoreSlot
 }
@@ -61,7 +102,6 @@ func NewKeyedWithKeylessTableValue(ds lib.Datastore) *KeyedWithKeylessTableValue
 func NewKeyedWithKeylessTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeyedWithKeylessTableValue {
 	return &KeyedWithKeylessTableValue{dsSlot}
 }
-
 func (m *KeyedWithKeylessTableValue) Get(
 	keyUint *uint256.Int,
 ) *KeyedWithKeylessTableValueRow {
@@ -70,3 +110,19 @@ func (m *KeyedWithKeylessTableValue) Get(
 	)
 	return NewKeyedWithKeylessTableValueRow(dsSlot)
 }
+
+func (m *KeyedWithKeylessTableValue) Has(
+	keyUint *uint256.Int,
+) bool {
+	return !m.Get(
+		keyUint,
+	).IsZero()
+}
+
+func (m *KeyedWithKeylessTableValue) Delete(
+	keyUint *uint256.Int,
+) {
+	m.Get(
+		keyUint,
+	).Delete()
+}
\ No newline at end of file
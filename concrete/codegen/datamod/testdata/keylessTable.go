@@ -3,26 +3,31 @@
 package testdata
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeylessTableDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeylessTable"))
+//	KeylessTableDefaultKey = storage.TableSlot("KeylessTable").Bytes()
 // )
 
 func KeylessTableDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeylessTable"))
+	return storage.TableSlot("KeylessTable").Bytes()
 }
 
 type KeylessTableRow struct {
@@ -35,12 +40,12 @@ func NewKeylessTableRow(dsSlot lib.DatastoreSlot) *KeylessTableRow {
 }
 
 func (v *KeylessTableRow) Get() (
-	*uint256.Int,
-	string,
-	[]byte,
-	bool,
-	common.Address,
-	[]byte,
+	valueUint *uint256.Int,
+	valueString string,
+	valueBytes []byte,
+	valueBool bool,
+	valueAddress common.Address,
+	valueBytes16 []byte,
 ) {
 	return codec.DecodeUint256(32, v.GetField(0)),
 		codec.DecodeString(32, v.GetField_bytes(1)),
@@ -66,6 +71,120 @@ func (v *KeylessTableRow) Set(
 	v.SetField(5, codec.EncodeFixedBytes(16, valueBytes16))
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeylessTableRow) Delete() {
+	v.GetField_slot(1).ClearBytes()
+	v.GetField_slot(2).ClearBytes()
+	v.Clear()
+}
+
+// KeylessTableValues holds all the values of a row, except tables.
+type KeylessTableValues struct {
+	ValueUint *uint256.Int
+	ValueString string
+	ValueBytes []byte
+	ValueBool bool
+	ValueAddress common.Address
+	ValueBytes16 []byte
+}
+
+// GetValues reads all the values of the row, loading every slot only once.
+func (v *KeylessTableRow) GetValues() KeylessTableValues {
+	var values KeylessTableValues
+	fields := v.GetFields(0, 3, 4, 5)
+	values.ValueUint = codec.DecodeUint256(32, fields[0])
+	values.ValueBool = codec.DecodeBool(1, fields[1])
+	values.ValueAddress = codec.DecodeAddress(20, fields[2])
+	values.ValueBytes16 = codec.DecodeFixedBytes(16, fields[3])
+	values.ValueString = codec.DecodeString(32, v.GetField_bytes(1))
+	values.ValueBytes = codec.DecodeBytes(32, v.GetField_bytes(2))
+	return values
+}
+
+// SetValues writes all the values of the row, storing every slot only once.
+func (v *KeylessTableRow) SetValues(values KeylessTableValues) {
+	v.SetFields([]int{0, 3, 4, 5}, [][]byte{
+		codec.EncodeUint256(32, values.ValueUint),
+		codec.EncodeBool(1, values.ValueBool),
+		codec.EncodeAddress(20, values.ValueAddress),
+		codec.EncodeFixedBytes(16, values.ValueBytes16),
+	})
+	v.SetField_bytes(1, codec.EncodeString(32, values.ValueString))
+	v.SetField_bytes(2, codec.EncodeBytes(32, values.ValueBytes))
+}
+
+// Equal reports whether all the values of two rows are equal. Absent optional
+// values are equal whatever their value.
+func (a KeylessTableValues) Equal(b KeylessTableValues) bool {
+	return codec.CompareUint256(a.ValueUint, b.ValueUint) == 0 &&
+		codec.Compare(a.ValueString, b.ValueString) == 0 &&
+		codec.CompareBytes(a.ValueBytes, b.ValueBytes) == 0 &&
+		codec.CompareBool(a.ValueBool, b.ValueBool) == 0 &&
+		codec.CompareBytes(a.ValueAddress[:], b.ValueAddress[:]) == 0 &&
+		codec.CompareBytes(a.ValueBytes16, b.ValueBytes16) == 0
+}
+
+// Packed returns the values concatenated in declaration order as solidity does
+// with abi.encodePacked, e.g. to reproduce the hash of a row computed by a
+// contract. Dynamic values are not padded or length prefixed, while the
+// elements of arrays are padded to 32 bytes. Optional values are encoded
+// whether they are set or not.
+func (v KeylessTableValues) Packed() []byte {
+	var data []byte
+	data = append(data, codec.EncodeUint256(32, v.ValueUint)...)
+	data = append(data, codec.EncodeString(32, v.ValueString)...)
+	data = append(data, codec.EncodeBytes(32, v.ValueBytes)...)
+	data = append(data, codec.EncodeBool(1, v.ValueBool)...)
+	data = append(data, codec.EncodeAddress(20, v.ValueAddress)...)
+	data = append(data, codec.EncodeFixedBytes(16, v.ValueBytes16)...)
+	return data
+}
+
+// jsonKeylessTableValues is the JSON representation of KeylessTableValues.
+type jsonKeylessTableValues struct {
+	ValueUint *uint256.Int `json:"valueUint"`
+	ValueString string `json:"valueString"`
+	ValueBytes hexutil.Bytes `json:"valueBytes"`
+	ValueBool bool `json:"valueBool"`
+	ValueAddress common.Address `json:"valueAddress"`
+	ValueBytes16 hexutil.Bytes `json:"valueBytes16"`
+}
+
+// MarshalJSON encodes the values as an object keyed by value name, with bytes
+// as hex strings and big integers as decimal strings. Absent optional values
+// are null.
+func (v KeylessTableValues) MarshalJSON() ([]byte, error) {
+	var j jsonKeylessTableValues
+	j.ValueUint = v.ValueUint
+	j.ValueString = v.ValueString
+	j.ValueBytes = hexutil.Bytes(v.ValueBytes)
+	j.ValueBool = v.ValueBool
+	j.ValueAddress = v.ValueAddress
+	j.ValueBytes16 = hexutil.Bytes(v.ValueBytes16)
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes values encoded by MarshalJSON.
+func (v *KeylessTableValues) UnmarshalJSON(data []byte) error {
+	var j jsonKeylessTableValues
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var values KeylessTableValues
+	values.ValueUint = j.ValueUint
+	values.ValueString = j.ValueString
+	values.ValueBytes = []byte(j.ValueBytes)
+	values.ValueBool = j.ValueBool
+	values.ValueAddress = j.ValueAddress
+	if err := codec.CheckMaxLen("valueBytes16", 16, j.ValueBytes16); err != nil {
+		return err
+	}
+	values.ValueBytes16 = []byte(j.ValueBytes16)
+	*v = values
+	return nil
+}
+
 func (v *KeylessTableRow) GetValueUint() *uint256.Int {
 	data := v.GetField(0)
 	return codec.DecodeUint256(32, data)
@@ -76,6 +195,28 @@ func (v *KeylessTableRow) SetValueUint(value *uint256.Int) {
 	v.SetField(0, data)
 }
 
+// AddValueUint adds delta to valueUint, returning an error without writing anything if
+// the result overflows.
+func (v *KeylessTableRow) AddValueUint(delta *uint256.Int) error {
+	value, err := codec.AddBigUint(32, v.GetValueUint(), delta)
+	if err != nil {
+		return err
+	}
+	v.SetValueUint(value)
+	return nil
+}
+
+// SubValueUint subtracts delta from valueUint, returning an error without writing
+// anything if the result overflows.
+func (v *KeylessTableRow) SubValueUint(delta *uint256.Int) error {
+	value, err := codec.SubBigUint(32, v.GetValueUint(), delta)
+	if err != nil {
+		return err
+	}
+	v.SetValueUint(value)
+	return nil
+}
+
 func (v *KeylessTableRow) GetValueString() string {
 	data := v.GetField_bytes(1)
 	return codec.DecodeString(32, data)
@@ -126,13 +267,52 @@ func (v *KeylessTableRow) SetValueBytes16(value []byte) {
 	v.SetField(5, data)
 }
 
-type KeylessTable = KeylessTableRow
+// KeylessTableRowStore reads and writes whole rows of the table. It is implemented
+// by both KeylessTable and MemoryKeylessTable, generated with the memory option.
+type KeylessTableRowStore interface {
+	Has() bool
+	Delete()
+	GetRow() KeylessTableValues
+	SetRow(row KeylessTableValues)
+}
+
+// KeylessTableStore lists all the accessors of KeylessTable, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeylessTableStore interface {
+	KeylessTableRowStore
+	Get() *KeylessTableRow
+}
+
+var _ KeylessTableStore = (*KeylessTable)(nil)
 
-func NewKeylessTable(ds lib.Datastore) *KeylessTableRow {
+type KeylessTable struct {
+	dsSlot lib.DatastoreSlot
+}
+
+func NewKeylessTable(ds lib.Datastore) *KeylessTable {
 	dsSlot := ds.Get(KeylessTableDefaultKey())
-	return NewKeylessTableRow(dsSlot)
+	return &KeylessTable{dsSlot}
+}
+
+func NewKeylessTableFromSlot(dsSlot lib.DatastoreSlot) *KeylessTable {
+	return &KeylessTable{dsSlot}
+}
+func (m *KeylessTable) Get() *KeylessTableRow {
+	return NewKeylessTableRow(m.dsSlot)
 }
 
-func NewKeylessTableFromSlot(dsSlot lib.DatastoreSlot) *KeylessTableRow {
-	return NewKeylessTableRow(dsSlot)
+func (m *KeylessTable) Has() bool {
+	return !m.Get().IsZero()
 }
+
+func (m *KeylessTable) Delete() {
+	m.Get().Delete()
+}
+
+func (m *KeylessTable) GetRow() KeylessTableValues {
+	return m.Get().GetValues()
+}
+
+func (m *KeylessTable) SetRow(row KeylessTableValues) {
+	m.Get().SetValues(row)
+}
\ No newline at end of file
@@ -4,25 +4,29 @@ package testdata
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeylessWithKeyedTableValueDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeylessWithKeyedTableValue"))
+//	KeylessWithKeyedTableValueDefaultKey = storage.TableSlot("KeylessWithKeyedTableValue").Bytes()
 // )
 
 func KeylessWithKeyedTableValueDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeylessWithKeyedTableValue"))
+	return storage.TableSlot("KeylessWithKeyedTableValue").Bytes()
 }
 
 type KeylessWithKeyedTableValueRow struct {
@@ -35,27 +39,71 @@ func NewKeylessWithKeyedTableValueRow(dsSlot lib.DatastoreSlot) *KeylessWithKeye
 }
 
 func (v *KeylessWithKeyedTableValueRow) Get() (
-	*KeyedTable,
+	valueTable *KeyedTable,
 ) {
-	return NewKeyedTableFromSlot(v.GetField_slot(0))
+	return v.GetValueTable()
 }
 
 func (v *KeylessWithKeyedTableValueRow) Set(
 ) {
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeylessWithKeyedTableValueRow) Delete() {
+	v.Clear()
+}
+
+// GetValueTable returns the valueTable table nested in the row. Its base slot is
+// hash(rowSlot . uint256(0) . "datamod.v1.table") with storage.DefaultHasher, where rowSlot is the
+// first slot of the row and 0 the index of the value in the schema, so
+// nested tables never overlap each other or the fields of the row.
 func (v *KeylessWithKeyedTableValueRow) GetValueTable() *KeyedTable {
-	dsSlot := v.GetField_slot(0)
-	return NewKeyedTableFromSlot(dsSlot)
+	rowSlot := v.GetBase_slot()
+	key := storage.NestedTableSlot(rowSlot.Slot(), 0).Bytes()
+	return NewKeyedTableFromSlot(rowSlot.Datastore().Get(key))
+}
+
+// ValueTable is the same as GetValueTable.
+func (v *KeylessWithKeyedTableValueRow) ValueTable() *KeyedTable {
+	return v.GetValueTable()
+}
+
+// KeylessWithKeyedTableValueRowStore reads and writes whole rows of the table.
+type KeylessWithKeyedTableValueRowStore interface {
+	Has() bool
+	Delete()
+}
+
+// KeylessWithKeyedTableValueStore lists all the accessors of KeylessWithKeyedTableValue, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeylessWithKeyedTableValueStore interface {
+	KeylessWithKeyedTableValueRowStore
+	Get() *KeylessWithKeyedTableValueRow
 }
 
-type KeylessWithKeyedTableValue = KeylessWithKeyedTableValueRow
+var _ KeylessWithKeyedTableValueStore = (*KeylessWithKeyedTableValue)(nil)
+
+type KeylessWithKeyedTableValue struct {
+	dsSlot lib.DatastoreSlot
+}
 
-func NewKeylessWithKeyedTableValue(ds lib.Datastore) *KeylessWithKeyedTableValueRow {
+func NewKeylessWithKeyedTableValue(ds lib.Datastore) *KeylessWithKeyedTableValue {
 	dsSlot := ds.Get(KeylessWithKeyedTableValueDefaultKey())
-	return NewKeylessWithKeyedTableValueRow(dsSlot)
+	return &KeylessWithKeyedTableValue{dsSlot}
 }
 
-func NewKeylessWithKeyedTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeylessWithKeyedTableValueRow {
-	return NewKeylessWithKeyedTableValueRow(dsSlot)
+func NewKeylessWithKeyedTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeylessWithKeyedTableValue {
+	return &KeylessWithKeyedTableValue{dsSlot}
+}
+func (m *KeylessWithKeyedTableValue) Get() *KeylessWithKeyedTableValueRow {
+	return NewKeylessWithKeyedTableValueRow(m.dsSlot)
 }
+
+func (m *KeylessWithKeyedTableValue) Has() bool {
+	return !m.Get().IsZero()
+}
+
+func (m *KeylessWithKeyedTableValue) Delete() {
+	m.Get().Delete()
+}
\ No newline at end of file
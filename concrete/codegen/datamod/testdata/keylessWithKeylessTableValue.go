@@ -4,25 +4,29 @@ package testdata
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
 	_ = uint256.NewInt
 )
 
 // var (
-//	KeylessWithKeylessTableValueDefaultKey = crypto.Keccak256([]byte("datamod.v1.KeylessWithKeylessTableValue"))
+//	KeylessWithKeylessTableValueDefaultKey = storage.TableSlot("KeylessWithKeylessTableValue").Bytes()
 // )
 
 func KeylessWithKeylessTableValueDefaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.KeylessWithKeylessTableValue"))
+	return storage.TableSlot("KeylessWithKeylessTableValue").Bytes()
 }
 
 type KeylessWithKeylessTableValueRow struct {
@@ -35,27 +39,71 @@ func NewKeylessWithKeylessTableValueRow(dsSlot lib.DatastoreSlot) *KeylessWithKe
 }
 
 func (v *KeylessWithKeylessTableValueRow) Get() (
-	*KeylessTable,
+	valueTable *KeylessTable,
 ) {
-	return NewKeylessTableFromSlot(v.GetField_slot(0))
+	return v.GetValueTable()
 }
 
 func (v *KeylessWithKeylessTableValueRow) Set(
 ) {
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KeylessWithKeylessTableValueRow) Delete() {
+	v.Clear()
+}
+
+// GetValueTable returns the valueTable table nested in the row. Its base slot is
+// hash(rowSlot . uint256(0) . "datamod.v1.table") with storage.DefaultHasher, where rowSlot is the
+// first slot of the row and 0 the index of the value in the schema, so
+// nested tables never overlap each other or the fields of the row.
 func (v *KeylessWithKeylessTableValueRow) GetValueTable() *KeylessTable {
-	dsSlot := v.GetField_slot(0)
-	return NewKeylessTableFromSlot(dsSlot)
+	rowSlot := v.GetBase_slot()
+	key := storage.NestedTableSlot(rowSlot.Slot(), 0).Bytes()
+	return NewKeylessTableFromSlot(rowSlot.Datastore().Get(key))
+}
+
+// ValueTable is the same as GetValueTable.
+func (v *KeylessWithKeylessTableValueRow) ValueTable() *KeylessTable {
+	return v.GetValueTable()
+}
+
+// KeylessWithKeylessTableValueRowStore reads and writes whole rows of the table.
+type KeylessWithKeylessTableValueRowStore interface {
+	Has() bool
+	Delete()
+}
+
+// KeylessWithKeylessTableValueStore lists all the accessors of KeylessWithKeylessTableValue, e.g. for
+// logic to depend on an interface that tests can replace.
+type KeylessWithKeylessTableValueStore interface {
+	KeylessWithKeylessTableValueRowStore
+	Get() *KeylessWithKeylessTableValueRow
 }
 
-type KeylessWithKeylessTableValue = KeylessWithKeylessTableValueRow
+var _ KeylessWithKeylessTableValueStore = (*KeylessWithKeylessTableValue)(nil)
+
+type KeylessWithKeylessTableValue struct {
+	dsSlot lib.DatastoreSlot
+}
 
-func NewKeylessWithKeylessTableValue(ds lib.Datastore) *KeylessWithKeylessTableValueRow {
+func NewKeylessWithKeylessTableValue(ds lib.Datastore) *KeylessWithKeylessTableValue {
 	dsSlot := ds.Get(KeylessWithKeylessTableValueDefaultKey())
-	return NewKeylessWithKeylessTableValueRow(dsSlot)
+	return &KeylessWithKeylessTableValue{dsSlot}
 }
 
-func NewKeylessWithKeylessTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeylessWithKeylessTableValueRow {
-	return NewKeylessWithKeylessTableValueRow(dsSlot)
+func NewKeylessWithKeylessTableValueFromSlot(dsSlot lib.DatastoreSlot) *KeylessWithKeylessTableValue {
+	return &KeylessWithKeylessTableValue{dsSlot}
+}
+func (m *KeylessWithKeylessTableValue) Get() *KeylessWithKeylessTableValueRow {
+	return NewKeylessWithKeylessTableValueRow(m.dsSlot)
 }
+
+func (m *KeylessWithKeylessTableValue) Has() bool {
+	return !m.Get().IsZero()
+}
+
+func (m *KeylessWithKeylessTableValue) Delete() {
+	m.Get().Delete()
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	_ "embed"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"text/template"
+)
+
+//go:embed typescript.tpl
+var typescriptTpl string
+
+const TypeScriptFileName = "datamod.ts"
+
+// maxTSNumberBits is the size of the largest integers represented as a
+// number instead of a bigint, as most ABI libraries do, so they fit in the
+// 53 bits of precision of a double.
+const maxTSNumberBits = 48
+
+var solIntTypeRegexp = regexp.MustCompile(`^u?int(\d+)$`)
+
+// TSType returns the TypeScript type of the ABI representation of a field:
+// bigint for integers larger than 48 bits, string for addresses, bytes and
+// function pointers as 0x prefixed hex strings, and arrays of the element
+// type for fixed and dynamic arrays.
+func (t FieldType) TSType() string {
+	switch {
+	case t.Elem != nil:
+		return t.Elem.TSType() + "[]"
+	case t.Struct != nil:
+		return t.Struct.Name
+	case t.Enum != nil:
+		return t.Enum.Name
+	case t.SolType == "bool":
+		return "boolean"
+	}
+	if match := solIntTypeRegexp.FindStringSubmatch(t.SolType); match != nil {
+		bits, _ := strconv.Atoi(match[1])
+		if bits <= maxTSNumberBits {
+			return "number"
+		}
+		return "bigint"
+	}
+	return "string"
+}
+
+type tsField struct {
+	Name     string
+	Type     string
+	Optional bool
+}
+
+func tsFields(fields []FieldSchema) []tsField {
+	tsFields := make([]tsField, 0, len(fields))
+	for _, field := range fields {
+		if field.Type.Type == TableType {
+			continue
+		}
+		tsFields = append(tsFields, tsField{Name: field.Name, Type: field.Type.TSType(), Optional: field.Optional})
+	}
+	return tsFields
+}
+
+// GenerateTypeScriptTypes writes TypeScript declarations of the enums, structs,
+// and the keys and row values of every table with values other than tables,
+// in declaration order so the output is deterministic.
+func GenerateTypeScriptTypes(config Config, schemas []TableSchema) error {
+	enums, err := collectEnums(schemas)
+	if err != nil {
+		return err
+	}
+	structs, err := collectStructs(schemas, enums)
+	if err != nil {
+		return err
+	}
+
+	tables := []map[string]interface{}{}
+	for _, schema := range schemas {
+		values := tsFields(schema.Values)
+		if len(values) == 0 {
+			continue
+		}
+		tables = append(tables, map[string]interface{}{
+			"Name":   formatTableName(schema.Name),
+			"Keys":   tsFields(schema.Keys),
+			"Values": values,
+		})
+	}
+
+	data := map[string]interface{}{
+		"Enums":   enums,
+		"Structs": structs,
+		"Tables":  tables,
+	}
+
+	tpl, err := template.New("typescript").Parse(typescriptTpl)
+	if err != nil {
+		return err
+	}
+	return ExecuteTemplate(tpl, data, filepath.Join(config.OutDir, TypeScriptFileName))
+}
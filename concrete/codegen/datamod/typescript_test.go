@@ -0,0 +1,74 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTSType(t *testing.T) {
+	r := require.New(t)
+	for name, tsType := range map[string]string{
+		"uint8":     "number",
+		"int48":     "number",
+		"uint56":    "bigint",
+		"int256":    "bigint",
+		"bool":      "boolean",
+		"address":   "string",
+		"bytes16":   "string",
+		"bytes":     "string",
+		"string":    "string",
+		"function":  "string",
+		"uint32[3]": "number[]",
+		"address[]": "string[]",
+	} {
+		fieldType, err := nameToFieldType(name)
+		r.NoError(err, name)
+		r.Equal(tsType, fieldType.TSType(), name)
+	}
+}
+
+func TestDatamodTypeScript(t *testing.T) {
+	r := require.New(t)
+	tmpDir := "./tmp-typescript"
+	os.Mkdir(tmpDir, 0755)
+	defer os.RemoveAll(tmpDir)
+	config := Config{
+		SchemaFilePath: filepath.Join("testdata", "good-datamod.json"),
+		OutDir:         filepath.Join(tmpDir),
+		Package:        "test",
+		TypeScript:     true,
+	}
+	r.NoError(GenerateDataModel(config, true))
+	content, err := os.ReadFile(filepath.Join(tmpDir, TypeScriptFileName))
+	r.NoError(err)
+	r.Contains(string(content), "export enum Status {\n    Pending = 0,\n    Active = 10,\n    Closed = 255,\n}")
+	r.Contains(string(content), "export interface Segment {\n    start: Point;\n    end: Point;\n    label: string;\n}")
+	r.Contains(string(content), "export interface PackedKeyTableKey {\n    id: number;\n    delta: number;\n    flag: boolean;\n    tag: string;\n    owner: string;\n}")
+	r.Contains(string(content), "    nickname?: string;\n    score?: bigint;\n")
+	// Tables without values other than tables have no row
+	r.NotContains(string(content), "KeyedWithKeyedTableValue")
+
+	// The output is deterministic
+	r.NoError(GenerateDataModel(config, true))
+	again, err := os.ReadFile(filepath.Join(tmpDir, TypeScriptFileName))
+	r.NoError(err)
+	r.Equal(content, again)
+}
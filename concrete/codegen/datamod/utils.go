@@ -73,3 +73,10 @@ func isValidName(name string) bool {
 	re := regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 	return re.MatchString(name) && len(strings.TrimSpace(name)) == len(name)
 }
+
+// isValidImportPath reports whether path is a slash separated go import path,
+// e.g. github.com/ethereum/go-ethereum/common.
+func isValidImportPath(path string) bool {
+	re := regexp.MustCompile(`^[a-zA-Z0-9_.~+-]+(/[a-zA-Z0-9_.~+-]+)*$`)
+	return re.MatchString(path)
+}
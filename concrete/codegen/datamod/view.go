@@ -0,0 +1,172 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package datamod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// ViewSchema is a read-only view joining values of several tables with the
+// same keys, e.g. `"accountView": {"view": {"balance": "balances.balance"}}`.
+// It generates a single getter reading all the values for a key.
+type ViewSchema struct {
+	Name string
+	// Keys are the keys of the first table of the view
+	Keys   []FieldSchema
+	Fields []ViewFieldSchema
+	// Tables are the tables read by the view, in order of first reference
+	Tables []TableSchema
+}
+
+// ViewFieldSchema is a field of a view, reading the value of a table.
+type ViewFieldSchema struct {
+	Name       string
+	Title      string
+	TableIndex int
+	Value      FieldSchema
+}
+
+// TableField returns the name of the field of the view holding a table.
+func (s ViewSchema) TableField(table TableSchema) string {
+	return lowerFirstLetter(table.Name)
+}
+
+// HasCBOR reports whether any table of the view has cbor values.
+func (s ViewSchema) HasCBOR() bool {
+	for _, table := range s.Tables {
+		if table.HasCBOR() {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldSchemas returns the keys and the values read by the view.
+func (s ViewSchema) fieldSchemas() []FieldSchema {
+	fields := append([]FieldSchema{}, s.Keys...)
+	for _, field := range s.Fields {
+		fields = append(fields, field.Value)
+	}
+	return fields
+}
+
+// parseViewSchema parses the fields of a view, each referencing a value of a
+// table as `<table>.<value>`, keeping at set to the path of the element being
+// parsed.
+func parseViewSchema(viewName string, jsonViewSchema orderedmap.OrderedMap, tables []TableSchema, at *[]string) (ViewSchema, error) {
+	if len(jsonViewSchema.Keys()) != 1 {
+		return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': views only have a view schema", viewName)
+	}
+	*at = []string{viewName, "view"}
+	_jsonFields, _ := jsonViewSchema.Get("view")
+	jsonFields, ok := _jsonFields.(orderedmap.OrderedMap)
+	if !ok || len(jsonFields.Keys()) == 0 {
+		return ViewSchema{}, fmt.Errorf("invalid schema for view '%s'", viewName)
+	}
+
+	tablesByName := make(map[string]TableSchema)
+	for _, table := range tables {
+		tablesByName[table.Name] = table
+	}
+	if _, ok := tablesByName[upperFirstLetter(viewName)]; ok {
+		return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': view has the same name as a table", viewName)
+	}
+	viewSchema := ViewSchema{Name: upperFirstLetter(viewName)}
+	tableIndices := make(map[string]int)
+	titles := make(map[string]bool)
+	for _, fieldName := range jsonFields.Keys() {
+		*at = []string{viewName, "view", fieldName}
+		if !isValidName(fieldName) {
+			return ViewSchema{}, fmt.Errorf("invalid field name '%s'", fieldName)
+		}
+		if titles[upperFirstLetter(fieldName)] {
+			return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': duplicate field '%s'", viewName, fieldName)
+		}
+		titles[upperFirstLetter(fieldName)] = true
+		_ref, _ := jsonFields.Get(fieldName)
+		ref, ok := _ref.(string)
+		if !ok {
+			return ViewSchema{}, fmt.Errorf("invalid schema for field '%s' in view '%s'", fieldName, viewName)
+		}
+		tableName, valueName, ok := strings.Cut(ref, ".")
+		if !ok {
+			return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': field '%s' must reference a value as <table>.<value>", viewName, fieldName)
+		}
+		table, ok := tablesByName[upperFirstLetter(tableName)]
+		if !ok {
+			return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': table '%s' does not exist", viewName, tableName)
+		}
+		var value *FieldSchema
+		for ii := range table.Values {
+			if table.Values[ii].Name == lowerFirstLetter(valueName) {
+				value = &table.Values[ii]
+			}
+		}
+		if value == nil {
+			return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': table '%s' has no value '%s'", viewName, tableName, valueName)
+		}
+		if value.Optional || value.Type.Type == TableType {
+			return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': value '%s' of table '%s' is optional or a table", viewName, valueName, tableName)
+		}
+
+		index, ok := tableIndices[table.Name]
+		if !ok {
+			if len(viewSchema.Tables) == 0 {
+				viewSchema.Keys = table.Keys
+			} else if !sameKeyTypes(viewSchema.Keys, table.Keys) {
+				return ViewSchema{}, fmt.Errorf("invalid schema for view '%s': table '%s' has different keys than table '%s'", viewName, tableName, lowerFirstLetter(viewSchema.Tables[0].Name))
+			}
+			index = len(viewSchema.Tables)
+			tableIndices[table.Name] = index
+			viewSchema.Tables = append(viewSchema.Tables, table)
+		}
+		viewSchema.Fields = append(viewSchema.Fields, ViewFieldSchema{
+			Name:       lowerFirstLetter(fieldName),
+			Title:      upperFirstLetter(fieldName),
+			TableIndex: index,
+			Value:      *value,
+		})
+	}
+	return viewSchema, nil
+}
+
+// isViewSchema reports whether an element of the DSL declares a view rather
+// than a table.
+func isViewSchema(jsonSchema interface{}) bool {
+	schema, ok := jsonSchema.(orderedmap.OrderedMap)
+	if !ok {
+		return false
+	}
+	_, ok = schema.Get("view")
+	return ok
+}
+
+// sameKeyTypes reports whether two lists of keys have the same go types, so
+// the same key values can be used to get rows of both tables.
+func sameKeyTypes(a, b []FieldSchema) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for ii := range a {
+		if a[ii].Type.GoType != b[ii].Type.GoType {
+			return false
+		}
+	}
+	return true
+}
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -115,7 +116,16 @@ func generateSolidityLibrary(ABI abi.ABI, cABI customABI, config Config) (string
 		"ImportPaths": importPaths,
 	}
 
-	for mIdx, method := range ABI.Methods {
+	// Methods are generated in the order of their names, as ABI.Methods is a
+	// map
+	methodNames := make([]string, 0, len(ABI.Methods))
+	for name := range ABI.Methods {
+		methodNames = append(methodNames, name)
+	}
+	sort.Strings(methodNames)
+
+	for _, mIdx := range methodNames {
+		method := ABI.Methods[mIdx]
 		inputSig := []string{}
 		inputTypes := []string{}
 		inputNames := []string{}
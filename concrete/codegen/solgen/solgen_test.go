@@ -16,6 +16,7 @@
 package solgen
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -44,15 +45,36 @@ func TestValidContractName(t *testing.T) {
 }
 
 func TestSolgen(t *testing.T) {
+	// The library is generated next to a copy of its import, so that it
+	// matches the one in testdata without writing there
+	outDir := t.TempDir()
+	dependency, err := os.ReadFile(filepath.Join("testdata", "Dependency.sol"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "Dependency.sol"), dependency, 0644); err != nil {
+		t.Fatal(err)
+	}
 	config := Config{
 		Name:       "CounterPrecompile",
 		Address:    common.Address{0x80},
 		Pragma:     "^0.8.0",
 		AbiPath:    filepath.Join("testdata", "Counter.abi.json"),
-		OutPath:    filepath.Join("testdata", "CounterPrecompile.sol"),
-		ImportPath: filepath.Join("testdata", "Dependency.sol"),
+		OutPath:    filepath.Join(outDir, "CounterPrecompile.sol"),
+		ImportPath: filepath.Join(outDir, "Dependency.sol"),
 	}
 	if err := GenerateSolidityLibrary(config); err != nil {
 		t.Fatal(err)
 	}
+	got, err := os.ReadFile(config.OutPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "CounterPrecompile.sol"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated library does not match testdata:\n%s", got)
+	}
 }
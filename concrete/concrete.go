@@ -16,7 +16,10 @@
 package concrete
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/concrete/api"
@@ -28,6 +31,39 @@ type Precompile interface {
 	Run(env api.Environment, input []byte) ([]byte, error)
 }
 
+// GasCoster can optionally be implemented by a precompile to charge a gas cost
+// for a call before Run is invoked.
+type GasCoster interface {
+	GasCost(input []byte) uint64
+}
+
+// StreamRunner can optionally be implemented by a precompile to write its
+// output to w instead of returning it from Run, e.g. for large serialized
+// values. If implemented, RunStream is invoked in place of Run. Returning an
+// error discards anything already written.
+type StreamRunner interface {
+	RunStream(env api.Environment, input []byte, w io.Writer) error
+}
+
+// RevertDataError is an error carrying raw revert data, e.g. an ABI encoded
+// solidity custom error. If a precompile returns it from Run, the data is used
+// verbatim as revert data instead of the error message.
+type RevertDataError struct {
+	Data []byte
+}
+
+func (e *RevertDataError) Error() string {
+	return fmt.Sprintf("execution reverted with data 0x%x", e.Data)
+}
+
+func revertData(err error) []byte {
+	var dataErr *RevertDataError
+	if errors.As(err, &dataErr) {
+		return dataErr.Data
+	}
+	return []byte(err.Error())
+}
+
 func RunPrecompile(p Precompile, env *api.Env, input []byte, gas uint64, value *uint256.Int) (ret []byte, remainingGas uint64, err error) {
 	// We can either copy the input or trust the end developer to not modify it
 	inputCopy := make([]byte, len(input))
@@ -43,9 +79,16 @@ func RunPrecompile(p Precompile, env *api.Env, input []byte, gas uint64, value *
 	contract.Gas = gas
 	contract.Value = value
 
+	// A panic computing the gas cost consumes all gas, as if the cost were
+	// higher than the gas given
+	charged := false
 	defer func() {
 		if r := recover(); r != nil {
-			if revertErr := env.RevertError(); revertErr != nil {
+			if !charged {
+				ret = nil
+				err = api.ErrOutOfGas
+				remainingGas = 0
+			} else if revertErr := env.RevertError(); revertErr != nil {
 				// Execution reverted
 				ret = []byte(revertErr.Error()) // Return the revert reason
 				err = api.ErrExecutionReverted
@@ -70,10 +113,26 @@ func RunPrecompile(p Precompile, env *api.Env, input []byte, gas uint64, value *
 		}
 	}()
 
-	ret, err = p.Run(env, inputCopy)
+	if gc, ok := p.(GasCoster); ok {
+		cost := gc.GasCost(inputCopy)
+		if contract.Gas < cost {
+			return nil, 0, api.ErrOutOfGas
+		}
+		contract.Gas -= cost
+	}
+	charged = true
+
+	if sr, ok := p.(StreamRunner); ok {
+		var buf bytes.Buffer
+		if err = sr.RunStream(env, inputCopy, &buf); err == nil {
+			ret = buf.Bytes()
+		}
+	} else {
+		ret, err = p.Run(env, inputCopy)
+	}
 	if err != nil {
 		// Returning an error is equivalent to reverting
-		ret = []byte(err.Error()) // Return the revert reason
+		ret = revertData(err) // Return the revert reason
 		err = api.ErrExecutionReverted
 	}
 
@@ -17,6 +17,8 @@ package concrete
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -223,6 +225,28 @@ func (pc *testPrecompile) Run(API api.Environment, input []byte) ([]byte, error)
 	return pc.runFn(API, input)
 }
 
+type testGasPrecompile struct {
+	testPrecompile
+	gasCostFn func([]byte) uint64
+}
+
+var _ GasCoster = &testGasPrecompile{}
+
+func (pc *testGasPrecompile) GasCost(input []byte) uint64 {
+	return pc.gasCostFn(input)
+}
+
+type testStreamPrecompile struct {
+	testPrecompile
+	runStreamFn func(api.Environment, []byte, io.Writer) error
+}
+
+var _ StreamRunner = &testStreamPrecompile{}
+
+func (pc *testStreamPrecompile) RunStream(API api.Environment, input []byte, w io.Writer) error {
+	return pc.runStreamFn(API, input, w)
+}
+
 func TestRunPrecompile(t *testing.T) {
 	t.Run("NoError", func(t *testing.T) {
 		pc := &testPrecompile{}
@@ -278,6 +302,22 @@ func TestRunPrecompile(t *testing.T) {
 		require.Equal(t, []byte(revertErr.Error()), ret)
 		require.Equal(t, gas, remainingGas)
 	})
+	t.Run("RevertData", func(t *testing.T) {
+		pc := &testPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		revertData := []byte{0x01, 0x02, 0x03, 0x04}
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.runFn = func(API api.Environment, input []byte) ([]byte, error) {
+			return nil, fmt.Errorf("wrapped: %w", &RevertDataError{Data: revertData})
+		}
+		ret, remainingGas, err := RunPrecompile(pc, env, nil, gas, uint256.NewInt(0))
+		require.Equal(t, api.ErrExecutionReverted, err)
+		require.Equal(t, revertData, ret)
+		require.Equal(t, gas, remainingGas)
+	})
 	t.Run("OutOfGas", func(t *testing.T) {
 		pc := &testPrecompile{}
 		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
@@ -310,4 +350,110 @@ func TestRunPrecompile(t *testing.T) {
 		require.Nil(t, ret)
 		require.Equal(t, uint64(0), remainingGas)
 	})
+	t.Run("GasCost", func(t *testing.T) {
+		pc := &testGasPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		cost := uint64(100)
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.gasCostFn = func(input []byte) uint64 {
+			return cost
+		}
+		pc.runFn = func(API api.Environment, input []byte) ([]byte, error) {
+			require.Equal(t, gas-cost, API.GetGasLeft()+api.GasQuickStep)
+			return []byte{}, nil
+		}
+		ret, remainingGas, err := RunPrecompile(pc, env, nil, gas, uint256.NewInt(0))
+		require.NoError(t, err)
+		require.Equal(t, []byte{}, ret)
+		require.Equal(t, gas-cost-api.GasQuickStep, remainingGas)
+	})
+	t.Run("Stream", func(t *testing.T) {
+		pc := &testStreamPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		runCounter := 0
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.runFn = func(API api.Environment, input []byte) ([]byte, error) {
+			runCounter++
+			return nil, nil
+		}
+		pc.runStreamFn = func(API api.Environment, input []byte, w io.Writer) error {
+			for ii := 0; ii < 4; ii++ {
+				if _, err := w.Write([]byte{byte(ii), byte(ii)}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		ret, remainingGas, err := RunPrecompile(pc, env, nil, gas, uint256.NewInt(0))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0, 0, 1, 1, 2, 2, 3, 3}, ret)
+		require.Equal(t, gas, remainingGas)
+		require.Equal(t, 0, runCounter)
+	})
+	t.Run("StreamRevert", func(t *testing.T) {
+		pc := &testStreamPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		revertErr := errors.New("stream revert")
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.runStreamFn = func(API api.Environment, input []byte, w io.Writer) error {
+			w.Write([]byte{0x01, 0x02})
+			return revertErr
+		}
+		ret, remainingGas, err := RunPrecompile(pc, env, nil, gas, uint256.NewInt(0))
+		require.Equal(t, api.ErrExecutionReverted, err)
+		require.Equal(t, []byte(revertErr.Error()), ret)
+		require.Equal(t, gas, remainingGas)
+	})
+	t.Run("GasCostOutOfGas", func(t *testing.T) {
+		pc := &testGasPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		runCounter := 0
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.gasCostFn = func(input []byte) uint64 {
+			return gas + 1
+		}
+		pc.runFn = func(API api.Environment, input []byte) ([]byte, error) {
+			runCounter++
+			return nil, nil
+		}
+		ret, remainingGas, err := RunPrecompile(pc, env, nil, gas, uint256.NewInt(0))
+		require.Equal(t, api.ErrOutOfGas, err)
+		require.Nil(t, ret)
+		require.Equal(t, uint64(0), remainingGas)
+		require.Equal(t, 0, runCounter)
+	})
+	t.Run("GasCostPanic", func(t *testing.T) {
+		pc := &testGasPrecompile{}
+		env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{IsStatic: true}, true)
+		gas := uint64(1234)
+		runCounter := 0
+		pc.isStaticFn = func(input []byte) bool {
+			return true
+		}
+		pc.gasCostFn = func(input []byte) uint64 {
+			return uint64(input[4:36][31])
+		}
+		pc.runFn = func(API api.Environment, input []byte) ([]byte, error) {
+			runCounter++
+			return nil, nil
+		}
+		// Short input makes the gas cost panic without crashing the caller
+		ret, remainingGas, err := RunPrecompile(pc, env, []byte{0x01}, gas, uint256.NewInt(0))
+		require.Equal(t, api.ErrOutOfGas, err)
+		require.Nil(t, ret)
+		require.Equal(t, uint64(0), remainingGas)
+		require.Equal(t, 0, runCounter)
+	})
 }
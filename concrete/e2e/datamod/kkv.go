@@ -3,27 +3,31 @@
 package datamod
 
 import (
-	"math/big"
-
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/concrete/codegen/datamod/codec"
 	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/storage"
+	"github.com/holiman/uint256"
 )
 
 // Reference imports to suppress errors if they are not used.
 var (
-	_ = big.NewInt
 	_ = common.Big1
+	_ = hexutil.Encode
 	_ = codec.EncodeAddress
+	_ = crypto.Keccak256
+	_ = uint256.NewInt
 )
 
 // var (
-//	KkvDefaultKey = crypto.Keccak256([]byte("datamod.v1.Kkv"))
+//	KkvDefaultKey = storage.TableSlot("Kkv").Bytes()
 // )
 
-func defaultKey() []byte {
-	return crypto.Keccak256([]byte("datamod.v1.Kkv"))
+func KkvDefaultKey() []byte {
+	return storage.TableSlot("Kkv").Bytes()
 }
 
 type KkvRow struct {
@@ -36,7 +40,7 @@ func NewKkvRow(dsSlot lib.DatastoreSlot) *KkvRow {
 }
 
 func (v *KkvRow) Get() (
-	common.Hash,
+	value common.Hash,
 ) {
 	return codec.DecodeHash(32, v.GetField(0))
 }
@@ -47,6 +51,75 @@ func (v *KkvRow) Set(
 	v.SetField(0, codec.EncodeHash(32, value))
 }
 
+// Delete zeroes all the slots of the row, including the data of dynamic fields.
+// Rows of table fields are not cleared.
+func (v *KkvRow) Delete() {
+	v.Clear()
+}
+
+// KkvValues holds all the values of a row, except tables.
+type KkvValues struct {
+	Value common.Hash
+}
+
+// GetValues reads all the values of the row, loading every slot only once.
+func (v *KkvRow) GetValues() KkvValues {
+	var values KkvValues
+	fields := v.GetFields(0)
+	values.Value = codec.DecodeHash(32, fields[0])
+	return values
+}
+
+// SetValues writes all the values of the row, storing every slot only once.
+func (v *KkvRow) SetValues(values KkvValues) {
+	v.SetFields([]int{0}, [][]byte{
+		codec.EncodeHash(32, values.Value),
+	})
+}
+
+// Equal reports whether all the values of two rows are equal. Absent optional
+// values are equal whatever their value.
+func (a KkvValues) Equal(b KkvValues) bool {
+	return codec.CompareBytes(a.Value[:], b.Value[:]) == 0
+}
+
+// Packed returns the values concatenated in declaration order as solidity does
+// with abi.encodePacked, e.g. to reproduce the hash of a row computed by a
+// contract. Dynamic values are not padded or length prefixed, while the
+// elements of arrays are padded to 32 bytes. Optional values are encoded
+// whether they are set or not.
+func (v KkvValues) Packed() []byte {
+	var data []byte
+	data = append(data, codec.EncodeHash(32, v.Value)...)
+	return data
+}
+
+// jsonKkvValues is the JSON representation of KkvValues.
+type jsonKkvValues struct {
+	Value common.Hash `json:"value"`
+}
+
+// MarshalJSON encodes the values as an object keyed by value name, with bytes
+// as hex strings and big integers as decimal strings. Absent optional values
+// are null.
+func (v KkvValues) MarshalJSON() ([]byte, error) {
+	var j jsonKkvValues
+	j.Value = v.Value
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes values encoded by MarshalJSON.
+func (v *KkvValues) UnmarshalJSON(data []byte) error {
+	var j jsonKkvValues
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var values KkvValues
+	values.Value = j.Value
+	*v = values
+	return nil
+}
+
 func (v *KkvRow) GetValue() common.Hash {
 	data := v.GetField(0)
 	return codec.DecodeHash(32, data)
@@ -57,19 +130,52 @@ func (v *KkvRow) SetValue(value common.Hash) {
 	v.SetField(0, data)
 }
 
+// KkvRowStore reads and writes whole rows of the table. It is implemented
+// by both Kkv and MemoryKkv, generated with the memory option.
+type KkvRowStore interface {
+	Has(
+		key1 common.Hash,
+		key2 common.Hash,
+	) bool
+	Delete(
+		key1 common.Hash,
+		key2 common.Hash,
+	)
+	GetRow(
+		key1 common.Hash,
+		key2 common.Hash,
+	) KkvValues
+	SetRow(
+		key1 common.Hash,
+		key2 common.Hash,
+		row KkvValues,
+	)
+}
+
+// KkvStore lists all the accessors of Kkv, e.g. for
+// logic to depend on an interface that tests can replace.
+type KkvStore interface {
+	KkvRowStore
+	Get(
+		key1 common.Hash,
+		key2 common.Hash,
+	) *KkvRow
+}
+
+var _ KkvStore = (*Kkv)(nil)
+
 type Kkv struct {
 	dsSlot lib.DatastoreSlot
 }
 
 func NewKkv(ds lib.Datastore) *Kkv {
-	dsSlot := ds.Get(defaultKey())
+	dsSlot := ds.Get(KkvDefaultKey())
 	return &Kkv{dsSlot}
 }
 
 func NewKkvFromSlot(dsSlot lib.DatastoreSlot) *Kkv {
 	return &Kkv{dsSlot}
 }
-
 func (m *Kkv) Get(
 	key1 common.Hash,
 	key2 common.Hash,
@@ -80,3 +186,44 @@ func (m *Kkv) Get(
 	)
 	return NewKkvRow(dsSlot)
 }
+
+func (m *Kkv) Has(
+	key1 common.Hash,
+	key2 common.Hash,
+) bool {
+	return !m.Get(
+		key1,
+		key2,
+	).IsZero()
+}
+
+func (m *Kkv) Delete(
+	key1 common.Hash,
+	key2 common.Hash,
+) {
+	m.Get(
+		key1,
+		key2,
+	).Delete()
+}
+
+func (m *Kkv) GetRow(
+	key1 common.Hash,
+	key2 common.Hash,
+) KkvValues {
+	return m.Get(
+		key1,
+		key2,
+	).GetValues()
+}
+
+func (m *Kkv) SetRow(
+	key1 common.Hash,
+	key2 common.Hash,
+	row KkvValues,
+) {
+	m.Get(
+		key1,
+		key2,
+	).SetValues(row)
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package concrete
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainParams are the parameters of the chain a precompile is created for,
+// e.g. to use different constants on different chains.
+type ChainParams struct {
+	ChainID *big.Int
+	// ForkBlocks holds the activation block of the forks the precompiles of
+	// the chain depend on, by name.
+	ForkBlocks map[string]uint64
+}
+
+// IsForked reports whether the named fork is active at the given block. Forks
+// without an activation block are never active.
+func (p ChainParams) IsForked(fork string, blockNumber uint64) bool {
+	activation, ok := p.ForkBlocks[fork]
+	return ok && blockNumber >= activation
+}
+
+// PrecompileFactory creates a precompile for a chain, so that precompiles can
+// be configured with the parameters of the chain at node startup instead of
+// reading globals.
+type PrecompileFactory interface {
+	New(params ChainParams) Precompile
+}
+
+// PrecompileFactoryFunc adapts a function to a PrecompileFactory.
+type PrecompileFactoryFunc func(params ChainParams) Precompile
+
+var _ PrecompileFactory = (PrecompileFactoryFunc)(nil)
+
+func (f PrecompileFactoryFunc) New(params ChainParams) Precompile {
+	return f(params)
+}
+
+// NewPrecompiles creates the precompile of every factory for a chain, e.g. to
+// add them to a GenericPrecompileRegistry with AddPrecompiles.
+func NewPrecompiles(params ChainParams, factories map[common.Address]PrecompileFactory) PrecompileMap {
+	precompiles := make(PrecompileMap, len(factories))
+	for address, factory := range factories {
+		precompiles[address] = factory.New(params)
+	}
+	return precompiles
+}
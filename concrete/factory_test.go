@@ -0,0 +1,64 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package concrete
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/stretchr/testify/require"
+)
+
+// pcChainID returns the chain ID it was created for.
+type pcChainID struct {
+	chainID *big.Int
+}
+
+func (pc *pcChainID) IsStatic(input []byte) bool {
+	return true
+}
+
+func (pc *pcChainID) Run(API api.Environment, input []byte) ([]byte, error) {
+	return pc.chainID.Bytes(), nil
+}
+
+func TestPrecompileFactory(t *testing.T) {
+	r := require.New(t)
+	factory := PrecompileFactoryFunc(func(params ChainParams) Precompile {
+		return &pcChainID{chainID: params.ChainID}
+	})
+	factories := map[common.Address]PrecompileFactory{addrIncl1: factory}
+
+	for _, chainID := range []int64{1, 10} {
+		precompiles := NewPrecompiles(ChainParams{ChainID: big.NewInt(chainID)}, factories)
+		r.Len(precompiles, 1)
+		ret, err := precompiles[addrIncl1].Run(nil, nil)
+		r.NoError(err)
+		r.Equal(big.NewInt(chainID).Bytes(), ret)
+	}
+}
+
+func TestChainParamsIsForked(t *testing.T) {
+	r := require.New(t)
+	params := ChainParams{ForkBlocks: map[string]uint64{"fees": 100}}
+	r.False(params.IsForked("fees", 99))
+	r.True(params.IsForked("fees", 100))
+	r.True(params.IsForked("fees", 101))
+	r.False(params.IsForked("other", 101))
+	r.False(ChainParams{}.IsForked("fees", 101))
+}
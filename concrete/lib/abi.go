@@ -0,0 +1,143 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will be ignored when building with tinygo, which cannot build the
+// abi package.
+
+package lib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+// ABIArgs are the arguments of a call to an ABI method, decoded with the
+// inputs of the method.
+type ABIArgs struct {
+	Values []interface{}
+	inputs abi.Arguments
+}
+
+// Copy copies the arguments into v as abi.Arguments.Copy does, i.e. into the
+// fields of a pointer to a struct named after the inputs, or into a pointer to
+// a value for methods with a single input.
+func (a ABIArgs) Copy(v interface{}) error {
+	return a.inputs.Copy(v, a.Values)
+}
+
+// ABIMethodFunc runs an ABI method given its decoded arguments and returns its
+// return values, which are encoded with the outputs of the method.
+type ABIMethodFunc func(env api.Environment, args ABIArgs) ([]interface{}, error)
+
+// ABIPrecompile is a precompile implementing the methods of a contract ABI. The
+// ABI is parsed once when the precompile is created, and calls are dispatched
+// by selector to the handlers of the methods, which receive decoded arguments
+// and return values to be encoded instead of raw calldata. View and pure
+// methods are static. Calls to methods without a handler fail with
+// ErrMethodNotFound.
+type ABIPrecompile struct {
+	abi        abi.ABI
+	dispatcher *MethodDispatcher
+}
+
+var (
+	_ concrete.Precompile = (*ABIPrecompile)(nil)
+	_ concrete.GasCoster  = (*ABIPrecompile)(nil)
+)
+
+func NewABIPrecompile(contractABI abi.ABI) *ABIPrecompile {
+	return &ABIPrecompile{abi: contractABI, dispatcher: NewMethodDispatcher()}
+}
+
+// NewABIPrecompileFromJSON creates an ABIPrecompile from the JSON ABI of a
+// contract.
+func NewABIPrecompileFromJSON(abiJSON string) (*ABIPrecompile, error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return NewABIPrecompile(contractABI), nil
+}
+
+// ABI returns the ABI of the precompile.
+func (p *ABIPrecompile) ABI() abi.ABI {
+	return p.abi
+}
+
+func (p *ABIPrecompile) method(name string) abi.Method {
+	method, ok := p.abi.Methods[name]
+	if !ok {
+		panic(fmt.Sprintf("method %s not found in ABI", name))
+	}
+	return method
+}
+
+// Register registers fn as the handler of the method with the given name, as
+// named in the Methods of the ABI. It panics if the ABI has no such method or
+// if it already has a handler.
+func (p *ABIPrecompile) Register(name string, fn ABIMethodFunc) {
+	method := p.method(name)
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	p.dispatcher.Register(selector, func(env api.Environment, args []byte) ([]byte, error) {
+		values, err := method.Inputs.Unpack(args)
+		if err != nil {
+			return nil, fmt.Errorf("%w for %s: %v", ErrInvalidArg, method.Sig, err)
+		}
+		ret, err := fn(env, ABIArgs{Values: values, inputs: method.Inputs})
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(ret...)
+	}, method.IsConstant())
+}
+
+// SetDefaultGasCost sets the fixed gas cost charged for calls to methods that
+// have no GasFunc of their own. It is zero by default.
+func (p *ABIPrecompile) SetDefaultGasCost(gas uint64) {
+	p.dispatcher.SetDefaultGasCost(gas)
+}
+
+// SetGasCost overrides the gas cost of the method with the given name, which
+// must have a handler.
+func (p *ABIPrecompile) SetGasCost(name string, fn GasFunc) {
+	var selector [4]byte
+	copy(selector[:], p.method(name).ID)
+	p.dispatcher.SetGasCost(selector, fn)
+}
+
+// SetOnDispatch sets a hook called after every call, see
+// MethodDispatcher.SetOnDispatch.
+func (p *ABIPrecompile) SetOnDispatch(hook DispatchHook) {
+	p.dispatcher.SetOnDispatch(hook)
+}
+
+func (p *ABIPrecompile) IsStatic(input []byte) bool {
+	return p.dispatcher.IsStatic(input)
+}
+
+func (p *ABIPrecompile) GasCost(input []byte) uint64 {
+	return p.dispatcher.GasCost(input)
+}
+
+func (p *ABIPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	return p.dispatcher.Run(env, input)
+}
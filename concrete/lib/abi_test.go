@@ -0,0 +1,115 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenABI = `[
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "burn", "stateMutability": "nonpayable", "inputs": [{"name": "amount", "type": "uint256"}], "outputs": []}
+]`
+
+func TestABIPrecompile(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		to       = common.HexToAddress("0xbeef")
+	)
+	_, err := NewABIPrecompileFromJSON("[")
+	r.Error(err)
+	pc, err := NewABIPrecompileFromJSON(testTokenABI)
+	r.NoError(err)
+	tokenABI := pc.ABI()
+
+	pc.Register("balanceOf", func(env api.Environment, args ABIArgs) ([]interface{}, error) {
+		owner := args.Values[0].(common.Address)
+		return []interface{}{env.StorageLoad(common.BytesToHash(owner.Bytes())).Big()}, nil
+	})
+	pc.Register("transfer", func(env api.Environment, args ABIArgs) ([]interface{}, error) {
+		var transfer struct {
+			To     common.Address
+			Amount *big.Int
+		}
+		if err := args.Copy(&transfer); err != nil {
+			return nil, err
+		}
+		if transfer.Amount.Sign() == 0 {
+			return nil, RevertError(Selector("ZeroAmount()"))
+		}
+		env.StorageStore(common.BytesToHash(transfer.To.Bytes()), common.BigToHash(transfer.Amount))
+		return []interface{}{true}, nil
+	})
+	pc.SetDefaultGasCost(100)
+	pc.SetGasCost("transfer", FixedGasCost(500))
+	r.Panics(func() { pc.Register("mint", nil) })
+	r.Panics(func() { pc.Register("transfer", nil) })
+	r.Panics(func() { pc.SetGasCost("burn", FixedGasCost(1)) })
+
+	input, err := tokenABI.Pack("transfer", to, big.NewInt(42))
+	r.NoError(err)
+	r.False(pc.IsStatic(input))
+	r.Equal(uint64(500), pc.GasCost(input))
+	ret, err := pc.Run(env, input)
+	r.NoError(err)
+	values, err := tokenABI.Unpack("transfer", ret)
+	r.NoError(err)
+	r.Equal([]interface{}{true}, values)
+
+	input, err = tokenABI.Pack("balanceOf", to)
+	r.NoError(err)
+	r.True(pc.IsStatic(input))
+	r.Equal(uint64(100), pc.GasCost(input))
+	ret, err = pc.Run(env, input)
+	r.NoError(err)
+	values, err = tokenABI.Unpack("balanceOf", ret)
+	r.NoError(err)
+	r.Equal([]interface{}{big.NewInt(42)}, values)
+
+	// Errors of handlers are returned as is
+	input, err = tokenABI.Pack("transfer", to, new(big.Int))
+	r.NoError(err)
+	_, err = pc.Run(env, input)
+	var dataErr *concrete.RevertDataError
+	r.ErrorAs(err, &dataErr)
+
+	// Malformed arguments are rejected before running the handler
+	_, err = pc.Run(env, input[:40])
+	r.ErrorIs(err, ErrInvalidArg)
+
+	// Methods without a handler are not found
+	input, err = tokenABI.Pack("burn", big.NewInt(1))
+	r.NoError(err)
+	_, err = pc.Run(env, input)
+	r.ErrorIs(err, ErrMethodNotFound)
+}
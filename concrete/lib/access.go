@@ -0,0 +1,283 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+var DefaultAccessControlKey = crypto.Keccak256([]byte("concrete.lib.AccessControl"))
+
+// DefaultAdminRole is the admin role of every role that has no admin role set.
+var DefaultAdminRole = common.Hash{}
+
+var (
+	unauthorizedAccountSelector = MustSelector("AccessControlUnauthorizedAccount(address,bytes32)")
+	badConfirmationSelector     = MustSelector("AccessControlBadConfirmation()")
+	ownableUnauthorizedSelector = MustSelector("OwnableUnauthorizedAccount(address)")
+)
+
+const (
+	ownershipTransferredEvent = "OwnershipTransferred(address,address)"
+	roleGrantedEvent          = "RoleGranted(bytes32,address,address)"
+	roleRevokedEvent          = "RoleRevoked(bytes32,address,address)"
+	roleAdminChangedEvent     = "RoleAdminChanged(bytes32,bytes32,bytes32)"
+)
+
+const (
+	accessControlOwnerIndex = iota
+	accessControlRolesIndex
+	accessControlAdminsIndex
+	accessControlLength
+)
+
+// RoleID returns the identifier of a named role, i.e. keccak256(name), in the
+// same way roles are usually defined in solidity.
+func RoleID(name string) common.Hash {
+	return common.BytesToHash(crypto.Keccak256([]byte(name)))
+}
+
+// AccessControl keeps track of an owner address and of the accounts granted
+// each role, following the model of OpenZeppelin's Ownable and AccessControl.
+// The owner can grant and revoke any role, and accounts with the admin role of
+// a role can grant and revoke that role. Unauthorized calls revert with the
+// same custom errors as the OpenZeppelin contracts, and all changes emit the
+// same events.
+type AccessControl struct {
+	key []byte
+}
+
+func NewAccessControl() *AccessControl {
+	return NewAccessControlWithKey(DefaultAccessControlKey)
+}
+
+func NewAccessControlWithKey(key []byte) *AccessControl {
+	return &AccessControl{key: key}
+}
+
+func (ac *AccessControl) slot(env api.Environment, index int) DatastoreSlot {
+	return NewDatastore(env).Get(ac.key).SlotArray([]int{accessControlLength}).Get(index)
+}
+
+func (ac *AccessControl) roleSlot(env api.Environment, role common.Hash, account common.Address) DatastoreSlot {
+	return ac.slot(env, accessControlRolesIndex).Mapping().GetNested(role.Bytes(), account.Bytes())
+}
+
+func (ac *AccessControl) adminSlot(env api.Environment, role common.Hash) DatastoreSlot {
+	return ac.slot(env, accessControlAdminsIndex).Mapping().Get(role.Bytes())
+}
+
+func (ac *AccessControl) Owner(env api.Environment) common.Address {
+	return ac.slot(env, accessControlOwnerIndex).Address()
+}
+
+// SetOwner sets the owner without any authorization check, e.g. to initialize
+// the precompile state.
+func (ac *AccessControl) SetOwner(env api.Environment, owner common.Address) {
+	previous := ac.Owner(env)
+	ac.slot(env, accessControlOwnerIndex).SetAddress(owner)
+	EmitEvent(env, ownershipTransferredEvent, []common.Hash{
+		common.BytesToHash(previous.Bytes()),
+		common.BytesToHash(owner.Bytes()),
+	}, nil)
+}
+
+// CheckOwner returns a revert error if the caller is not the owner.
+func (ac *AccessControl) CheckOwner(env api.Environment) error {
+	caller := env.GetCaller()
+	if caller != ac.Owner(env) {
+		return RevertError(ownableUnauthorizedSelector, common.BytesToHash(caller.Bytes()).Bytes())
+	}
+	return nil
+}
+
+// TransferOwnership sets a new owner if the caller is the current owner.
+func (ac *AccessControl) TransferOwnership(env api.Environment, owner common.Address) error {
+	if err := ac.CheckOwner(env); err != nil {
+		return err
+	}
+	ac.SetOwner(env, owner)
+	return nil
+}
+
+func (ac *AccessControl) HasRole(env api.Environment, role common.Hash, account common.Address) bool {
+	return ac.roleSlot(env, role, account).Bool()
+}
+
+// RoleAdmin returns the role allowed to grant and revoke the given role.
+func (ac *AccessControl) RoleAdmin(env api.Environment, role common.Hash) common.Hash {
+	return ac.adminSlot(env, role).Bytes32()
+}
+
+// SetRoleAdmin sets the admin role of a role without any authorization check.
+func (ac *AccessControl) SetRoleAdmin(env api.Environment, role common.Hash, admin common.Hash) {
+	previous := ac.RoleAdmin(env, role)
+	ac.adminSlot(env, role).SetBytes32(admin)
+	EmitEvent(env, roleAdminChangedEvent, []common.Hash{role, previous, admin}, nil)
+}
+
+// CheckRole returns a revert error if the caller does not have the given role.
+func (ac *AccessControl) CheckRole(env api.Environment, role common.Hash) error {
+	caller := env.GetCaller()
+	if !ac.HasRole(env, role, caller) {
+		return ac.unauthorized(caller, role)
+	}
+	return nil
+}
+
+func (ac *AccessControl) unauthorized(account common.Address, role common.Hash) error {
+	return RevertError(unauthorizedAccountSelector, common.BytesToHash(account.Bytes()).Bytes(), role.Bytes())
+}
+
+func (ac *AccessControl) checkRoleAdmin(env api.Environment, role common.Hash) error {
+	caller := env.GetCaller()
+	if caller == ac.Owner(env) {
+		return nil
+	}
+	admin := ac.RoleAdmin(env, role)
+	if !ac.HasRole(env, admin, caller) {
+		return ac.unauthorized(caller, admin)
+	}
+	return nil
+}
+
+func (ac *AccessControl) setRole(env api.Environment, role common.Hash, account common.Address, granted bool) {
+	if ac.HasRole(env, role, account) == granted {
+		return
+	}
+	ac.roleSlot(env, role, account).SetBool(granted)
+	event := roleRevokedEvent
+	if granted {
+		event = roleGrantedEvent
+	}
+	EmitEvent(env, event, []common.Hash{
+		role,
+		common.BytesToHash(account.Bytes()),
+		common.BytesToHash(env.GetCaller().Bytes()),
+	}, nil)
+}
+
+// GrantRole grants a role to an account if the caller is the owner or has the
+// admin role of the role.
+func (ac *AccessControl) GrantRole(env api.Environment, role common.Hash, account common.Address) error {
+	if err := ac.checkRoleAdmin(env, role); err != nil {
+		return err
+	}
+	ac.setRole(env, role, account, true)
+	return nil
+}
+
+// RevokeRole revokes a role from an account if the caller is the owner or has
+// the admin role of the role.
+func (ac *AccessControl) RevokeRole(env api.Environment, role common.Hash, account common.Address) error {
+	if err := ac.checkRoleAdmin(env, role); err != nil {
+		return err
+	}
+	ac.setRole(env, role, account, false)
+	return nil
+}
+
+// RenounceRole revokes a role from the caller. The account must be the caller,
+// as a confirmation.
+func (ac *AccessControl) RenounceRole(env api.Environment, role common.Hash, account common.Address) error {
+	if account != env.GetCaller() {
+		return RevertError(badConfirmationSelector)
+	}
+	ac.setRole(env, role, account, false)
+	return nil
+}
+
+// OnlyOwner wraps a method so that it reverts unless called by the owner.
+func (ac *AccessControl) OnlyOwner(fn MethodFunc) MethodFunc {
+	return func(env api.Environment, args []byte) ([]byte, error) {
+		if err := ac.CheckOwner(env); err != nil {
+			return nil, err
+		}
+		return fn(env, args)
+	}
+}
+
+// OnlyRole wraps a method so that it reverts unless the caller has the given
+// role.
+func (ac *AccessControl) OnlyRole(role common.Hash, fn MethodFunc) MethodFunc {
+	return func(env api.Environment, args []byte) ([]byte, error) {
+		if err := ac.CheckRole(env, role); err != nil {
+			return nil, err
+		}
+		return fn(env, args)
+	}
+}
+
+// RegisterMethods registers the solidity interface of the access control in
+// a dispatcher, i.e. owner(), transferOwnership(address), hasRole(bytes32,address),
+// getRoleAdmin(bytes32), grantRole(bytes32,address), revokeRole(bytes32,address)
+// and renounceRole(bytes32,address).
+func (ac *AccessControl) RegisterMethods(d *MethodDispatcher) {
+	d.RegisterSignature("owner()", func(env api.Environment, args []byte) ([]byte, error) {
+		return NewArgWriter().WriteAddress(ac.Owner(env)).Bytes(), nil
+	}, true)
+	d.RegisterSignature("transferOwnership(address)", func(env api.Environment, args []byte) ([]byte, error) {
+		owner, err := NewArgReader(args).ReadAddress()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ac.TransferOwnership(env, owner)
+	}, false)
+	d.RegisterSignature("hasRole(bytes32,address)", func(env api.Environment, args []byte) ([]byte, error) {
+		role, account, err := readRoleArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return NewArgWriter().WriteBool(ac.HasRole(env, role, account)).Bytes(), nil
+	}, true)
+	d.RegisterSignature("getRoleAdmin(bytes32)", func(env api.Environment, args []byte) ([]byte, error) {
+		role, err := NewArgReader(args).ReadHash()
+		if err != nil {
+			return nil, err
+		}
+		return NewArgWriter().WriteHash(ac.RoleAdmin(env, role)).Bytes(), nil
+	}, true)
+	roleMethods := map[string]func(api.Environment, common.Hash, common.Address) error{
+		"grantRole(bytes32,address)":    ac.GrantRole,
+		"revokeRole(bytes32,address)":   ac.RevokeRole,
+		"renounceRole(bytes32,address)": ac.RenounceRole,
+	}
+	for signature, method := range roleMethods {
+		method := method
+		d.RegisterSignature(signature, func(env api.Environment, args []byte) ([]byte, error) {
+			role, account, err := readRoleArgs(args)
+			if err != nil {
+				return nil, err
+			}
+			return nil, method(env, role, account)
+		}, false)
+	}
+}
+
+func readRoleArgs(args []byte) (common.Hash, common.Address, error) {
+	reader := NewArgReader(args)
+	role, err := reader.ReadHash()
+	if err != nil {
+		return common.Hash{}, common.Address{}, err
+	}
+	account, err := reader.ReadAddress()
+	if err != nil {
+		return common.Hash{}, common.Address{}, err
+	}
+	return role, account, nil
+}
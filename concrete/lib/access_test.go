@@ -0,0 +1,136 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControl(t *testing.T) {
+	var (
+		r          = require.New(t)
+		env, db    = newEventsTestEnv()
+		contract   = env.(*api.Env).Contract()
+		ac         = NewAccessControl()
+		owner      = common.HexToAddress("0x01")
+		admin      = common.HexToAddress("0x02")
+		minter     = common.HexToAddress("0x03")
+		minterRole = RoleID("MINTER_ROLE")
+		adminRole  = RoleID("MINTER_ADMIN_ROLE")
+	)
+
+	as := func(caller common.Address) { contract.Caller = caller }
+
+	ac.SetOwner(env, owner)
+	r.Equal(owner, ac.Owner(env))
+	r.Len(db.Logs(), 1)
+
+	// Only the owner can transfer ownership
+	as(admin)
+	r.Error(ac.CheckOwner(env))
+	r.Error(ac.TransferOwnership(env, admin))
+
+	// The owner can grant any role
+	as(owner)
+	ac.SetRoleAdmin(env, minterRole, adminRole)
+	r.Equal(adminRole, ac.RoleAdmin(env, minterRole))
+	r.Equal(DefaultAdminRole, ac.RoleAdmin(env, adminRole))
+	r.NoError(ac.GrantRole(env, adminRole, admin))
+	r.True(ac.HasRole(env, adminRole, admin))
+
+	// Role admins can grant and revoke their roles only
+	as(admin)
+	r.NoError(ac.GrantRole(env, minterRole, minter))
+	r.True(ac.HasRole(env, minterRole, minter))
+	r.Error(ac.GrantRole(env, adminRole, minter))
+	r.NoError(ac.RevokeRole(env, minterRole, minter))
+	r.False(ac.HasRole(env, minterRole, minter))
+	r.NoError(ac.GrantRole(env, minterRole, minter))
+
+	// Only the account itself can renounce its role
+	err := ac.RenounceRole(env, minterRole, minter)
+	var dataErr *concrete.RevertDataError
+	r.ErrorAs(err, &dataErr)
+	r.Equal(badConfirmationSelector[:], dataErr.Data)
+	as(minter)
+	r.NoError(ac.RenounceRole(env, minterRole, minter))
+	r.False(ac.HasRole(env, minterRole, minter))
+
+	// Granting a role twice does not emit an event
+	as(owner)
+	logs := len(db.Logs())
+	r.NoError(ac.GrantRole(env, adminRole, admin))
+	r.Len(db.Logs(), logs)
+}
+
+func TestAccessControlMethods(t *testing.T) {
+	var (
+		r          = require.New(t)
+		env, _     = newEventsTestEnv()
+		contract   = env.(*api.Env).Contract()
+		ac         = NewAccessControl()
+		pc         = NewMethodDispatcher()
+		owner      = common.HexToAddress("0x01")
+		minter     = common.HexToAddress("0x02")
+		minterRole = RoleID("MINTER_ROLE")
+		slot       = common.HexToHash("0x01")
+	)
+
+	ac.RegisterMethods(pc)
+	pc.RegisterSignature("mint()", ac.OnlyRole(minterRole, func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, common.HexToHash("0x01"))
+		return nil, nil
+	}), false)
+	ac.SetOwner(env, owner)
+
+	call := func(caller common.Address, signature string, args []byte) ([]byte, error) {
+		contract.Caller = caller
+		selector := Selector(signature)
+		return pc.Run(env, append(selector[:], args...))
+	}
+
+	ret, err := call(minter, "owner()", nil)
+	r.NoError(err)
+	r.Equal(common.BytesToHash(owner.Bytes()).Bytes(), ret)
+
+	_, err = call(minter, "mint()", nil)
+	var dataErr *concrete.RevertDataError
+	r.ErrorAs(err, &dataErr)
+	r.Equal(unauthorizedAccountSelector[:], dataErr.Data[:4])
+
+	args := NewArgWriter().WriteHash(minterRole).WriteAddress(minter).Bytes()
+	_, err = call(minter, "grantRole(bytes32,address)", args)
+	r.Error(err)
+	_, err = call(owner, "grantRole(bytes32,address)", args)
+	r.NoError(err)
+	ret, err = call(owner, "hasRole(bytes32,address)", args)
+	r.NoError(err)
+	r.Equal(NewArgWriter().WriteBool(true).Bytes(), ret)
+
+	_, err = call(minter, "mint()", nil)
+	r.NoError(err)
+	r.Equal(common.HexToHash("0x01"), env.StorageLoad(slot))
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrArgUnderflow = errors.New("argument underflow")
+	ErrInvalidArg   = errors.New("invalid argument")
+)
+
+// ArgReader decodes a sequence of 32-byte ABI words, e.g. the arguments of a
+// method call after the selector. Bytes are read length prefixed and right
+// padded to a multiple of 32 bytes, as written by ArgWriter.
+type ArgReader struct {
+	data   []byte
+	cursor int
+}
+
+func NewArgReader(data []byte) *ArgReader {
+	return &ArgReader{data: data}
+}
+
+// Remaining returns the number of bytes left to read.
+func (r *ArgReader) Remaining() int {
+	return len(r.data) - r.cursor
+}
+
+func (r *ArgReader) read(size int) ([]byte, error) {
+	if size < 0 || r.Remaining() < size {
+		return nil, ErrArgUnderflow
+	}
+	data := r.data[r.cursor : r.cursor+size]
+	r.cursor += size
+	return data, nil
+}
+
+func (r *ArgReader) ReadWord() ([]byte, error) {
+	return r.read(32)
+}
+
+func (r *ArgReader) ReadHash() (common.Hash, error) {
+	word, err := r.ReadWord()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(word), nil
+}
+
+func (r *ArgReader) ReadAddress() (common.Address, error) {
+	word, err := r.ReadWord()
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !isZero(word[:12]) {
+		return common.Address{}, ErrInvalidArg
+	}
+	return common.BytesToAddress(word), nil
+}
+
+func (r *ArgReader) ReadBool() (bool, error) {
+	word, err := r.ReadWord()
+	if err != nil {
+		return false, err
+	}
+	if !isZero(word[:31]) || word[31] > 1 {
+		return false, ErrInvalidArg
+	}
+	return word[31] == 1, nil
+}
+
+func (r *ArgReader) ReadUint256() (*uint256.Int, error) {
+	word, err := r.ReadWord()
+	if err != nil {
+		return nil, err
+	}
+	return new(uint256.Int).SetBytes(word), nil
+}
+
+func (r *ArgReader) ReadUint64() (uint64, error) {
+	value, err := r.ReadUint256()
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, ErrInvalidArg
+	}
+	return value.Uint64(), nil
+}
+
+func (r *ArgReader) ReadBytes() ([]byte, error) {
+	length, err := r.ReadUint64()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(r.Remaining()) {
+		return nil, ErrArgUnderflow
+	}
+	padded, err := r.read((int(length) + 31) / 32 * 32)
+	if err != nil {
+		return nil, err
+	}
+	return padded[:length], nil
+}
+
+func (r *ArgReader) ReadString() (string, error) {
+	data, err := r.ReadBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ArgWriter encodes a sequence of 32-byte ABI words, e.g. the return data of a
+// method call.
+type ArgWriter struct {
+	data []byte
+}
+
+func NewArgWriter() *ArgWriter {
+	return &ArgWriter{}
+}
+
+// Bytes returns the encoded data.
+func (w *ArgWriter) Bytes() []byte {
+	return w.data
+}
+
+func (w *ArgWriter) WriteHash(value common.Hash) *ArgWriter {
+	w.data = append(w.data, value.Bytes()...)
+	return w
+}
+
+func (w *ArgWriter) WriteAddress(value common.Address) *ArgWriter {
+	return w.WriteHash(common.BytesToHash(value.Bytes()))
+}
+
+func (w *ArgWriter) WriteBool(value bool) *ArgWriter {
+	if value {
+		return w.WriteHash(common.BigToHash(common.Big1))
+	}
+	return w.WriteHash(common.Hash{})
+}
+
+func (w *ArgWriter) WriteUint256(value *uint256.Int) *ArgWriter {
+	return w.WriteHash(value.Bytes32())
+}
+
+func (w *ArgWriter) WriteUint64(value uint64) *ArgWriter {
+	return w.WriteHash(common.BigToHash(new(big.Int).SetUint64(value)))
+}
+
+func (w *ArgWriter) WriteBytes(value []byte) *ArgWriter {
+	w.data = append(w.data, encodeDynamicValue(value)...)
+	return w
+}
+
+func (w *ArgWriter) WriteString(value string) *ArgWriter {
+	return w.WriteBytes([]byte(value))
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgReaderWriter(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		address = common.HexToAddress("0xc0ffee")
+		hash    = common.HexToHash("0x1234")
+		value   = uint256.NewInt(1000)
+		data    = []byte("the quick brown fox jumps over the lazy dog")
+	)
+
+	enc := NewArgWriter().
+		WriteAddress(address).
+		WriteUint256(value).
+		WriteBytes(data).
+		WriteBool(true).
+		WriteUint64(42).
+		WriteHash(hash).
+		WriteString("").
+		Bytes()
+	r.Len(enc, 32*9)
+
+	reader := NewArgReader(enc)
+	readAddress, err := reader.ReadAddress()
+	r.NoError(err)
+	r.Equal(address, readAddress)
+	readValue, err := reader.ReadUint256()
+	r.NoError(err)
+	r.Equal(value, readValue)
+	readData, err := reader.ReadBytes()
+	r.NoError(err)
+	r.Equal(data, readData)
+	readBool, err := reader.ReadBool()
+	r.NoError(err)
+	r.True(readBool)
+	readUint64, err := reader.ReadUint64()
+	r.NoError(err)
+	r.Equal(uint64(42), readUint64)
+	readHash, err := reader.ReadHash()
+	r.NoError(err)
+	r.Equal(hash, readHash)
+	readString, err := reader.ReadString()
+	r.NoError(err)
+	r.Equal("", readString)
+	r.Equal(0, reader.Remaining())
+
+	_, err = reader.ReadUint256()
+	r.ErrorIs(err, ErrArgUnderflow)
+}
+
+func TestArgReaderErrors(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewArgReader(make([]byte, 31)).ReadHash()
+	r.ErrorIs(err, ErrArgUnderflow)
+
+	// Length exceeds the remaining data
+	enc := NewArgWriter().WriteUint64(33).WriteHash(common.Hash{}).Bytes()
+	_, err = NewArgReader(enc).ReadBytes()
+	r.ErrorIs(err, ErrArgUnderflow)
+
+	// Missing padding
+	enc = NewArgWriter().WriteBytes([]byte{0x01}).Bytes()
+	_, err = NewArgReader(enc[:33]).ReadBytes()
+	r.ErrorIs(err, ErrArgUnderflow)
+
+	dirty := NewArgWriter().WriteHash(common.HexToHash("0x0100000000000000000000000000000000000000000000000000000000000001")).Bytes()
+	_, err = NewArgReader(dirty).ReadAddress()
+	r.ErrorIs(err, ErrInvalidArg)
+	_, err = NewArgReader(dirty).ReadBool()
+	r.ErrorIs(err, ErrInvalidArg)
+	_, err = NewArgReader(dirty).ReadUint64()
+	r.ErrorIs(err, ErrInvalidArg)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+// CachedEnvironment wraps an environment to memoize storage reads and buffer
+// storage writes until Flush is called, so that repeated accesses to the same
+// slot within a call only hit the state once. Pending writes are flushed and
+// the cache is dropped before any call or create, as the callee may access the
+// storage of the wrapped contract.
+type CachedEnvironment struct {
+	api.Environment
+	cache   map[common.Hash]common.Hash
+	pending map[common.Hash]bool
+	order   []common.Hash
+}
+
+var _ api.Environment = (*CachedEnvironment)(nil)
+
+func NewCachedEnvironment(env api.Environment) *CachedEnvironment {
+	return &CachedEnvironment{
+		Environment: env,
+		cache:       make(map[common.Hash]common.Hash),
+		pending:     make(map[common.Hash]bool),
+	}
+}
+
+func (e *CachedEnvironment) StorageLoad(key common.Hash) common.Hash {
+	if value, ok := e.cache[key]; ok {
+		return value
+	}
+	value := e.Environment.StorageLoad(key)
+	e.cache[key] = value
+	return value
+}
+
+func (e *CachedEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	e.cache[key] = value
+	if !e.pending[key] {
+		e.pending[key] = true
+		e.order = append(e.order, key)
+	}
+}
+
+// Flush writes all pending storage writes to the wrapped environment in the
+// order the slots were first written. Cached reads stay valid.
+func (e *CachedEnvironment) Flush() {
+	for _, key := range e.order {
+		e.Environment.StorageStore(key, e.cache[key])
+	}
+	e.pending = make(map[common.Hash]bool)
+	e.order = nil
+}
+
+// reset flushes pending writes and drops all cached values.
+func (e *CachedEnvironment) reset() {
+	e.Flush()
+	e.cache = make(map[common.Hash]common.Hash)
+}
+
+// GetExternalStorage sees the cached and pending writes when reading the
+// storage of the wrapped contract itself.
+func (e *CachedEnvironment) GetExternalStorage(address common.Address, key common.Hash) common.Hash {
+	if address == e.GetAddress() {
+		return e.StorageLoad(key)
+	}
+	return e.Environment.GetExternalStorage(address, key)
+}
+
+func (e *CachedEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	e.reset()
+	return e.Environment.Execute(op, args)
+}
+
+func (e *CachedEnvironment) CallStatic(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	e.Flush()
+	return e.Environment.CallStatic(address, data, gas)
+}
+
+func (e *CachedEnvironment) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
+	e.reset()
+	return e.Environment.Call(address, data, gas, value)
+}
+
+func (e *CachedEnvironment) CallDelegate(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	e.reset()
+	return e.Environment.CallDelegate(address, data, gas)
+}
+
+func (e *CachedEnvironment) Create(data []byte, value *uint256.Int) ([]byte, common.Address, error) {
+	e.reset()
+	return e.Environment.Create(data, value)
+}
+
+func (e *CachedEnvironment) Create2(data []byte, endowment *uint256.Int, salt *uint256.Int) ([]byte, common.Address, error) {
+	e.reset()
+	return e.Environment.Create2(data, endowment, salt)
+}
+
+// CachedPrecompile wraps a precompile to run it with a CachedEnvironment,
+// flushing pending writes if it returns successfully. Writes of calls that
+// return an error are discarded, as the call reverts anyway.
+type CachedPrecompile struct {
+	pc concrete.Precompile
+}
+
+var _ concrete.Precompile = (*CachedPrecompile)(nil)
+
+func NewCachedPrecompile(pc concrete.Precompile) *CachedPrecompile {
+	return &CachedPrecompile{pc: pc}
+}
+
+func (c *CachedPrecompile) IsStatic(input []byte) bool {
+	return c.pc.IsStatic(input)
+}
+
+// GasCost forwards to the wrapped precompile if it implements
+// concrete.GasCoster.
+func (c *CachedPrecompile) GasCost(input []byte) uint64 {
+	return gasCost(c.pc, input)
+}
+
+func (c *CachedPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	cachedEnv := NewCachedEnvironment(env)
+	ret, err := c.pc.Run(cachedEnv, input)
+	if err != nil {
+		return ret, err
+	}
+	cachedEnv.Flush()
+	return ret, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+type countingEnvironment struct {
+	api.Environment
+	loads  int
+	stores int
+}
+
+func (e *countingEnvironment) StorageLoad(key common.Hash) common.Hash {
+	e.loads++
+	return e.Environment.StorageLoad(key)
+}
+
+func (e *countingEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	e.stores++
+	e.Environment.StorageStore(key, value)
+}
+
+func TestCachedEnvironment(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		base     = &countingEnvironment{Environment: mock.NewMockEnvironment(api.EnvConfig{}, false, contract)}
+		env      = NewCachedEnvironment(base)
+		slot     = common.HexToHash("0x01")
+		value1   = common.HexToHash("0x02")
+		value2   = common.HexToHash("0x03")
+	)
+
+	// Reads are only loaded once
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+	r.Equal(1, base.loads)
+
+	// Writes replace the cached value and are buffered until flushed
+	env.StorageStore(slot, value1)
+	env.StorageStore(slot, value2)
+	r.Equal(value2, env.StorageLoad(slot))
+	r.Equal(common.Hash{}, base.Environment.StorageLoad(slot))
+	r.Equal(0, base.stores)
+
+	env.Flush()
+	r.Equal(1, base.stores)
+	r.Equal(value2, base.Environment.StorageLoad(slot))
+
+	// Flushing again is a no-op
+	env.Flush()
+	r.Equal(1, base.stores)
+
+	// Raw opcodes flush the pending writes and drop the cache
+	env.StorageStore(slot, value1)
+	ret := env.Execute(api.StorageLoad_OpCode, [][]byte{slot.Bytes()})
+	r.Equal(value1.Bytes(), ret[0])
+	r.Equal(2, base.stores)
+	loads := base.loads
+	r.Equal(value1, env.StorageLoad(slot))
+	r.Equal(loads+1, base.loads)
+
+	// Reading the own storage as external storage sees the pending writes
+	env.StorageStore(slot, value2)
+	r.Equal(value2, env.GetExternalStorage(address, slot))
+}
+
+func TestCachedPrecompile(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+		pc       = NewMethodDispatcher()
+		cached   = NewCachedPrecompile(pc)
+	)
+
+	view := pc.RegisterSignature("view()", func(env api.Environment, args []byte) ([]byte, error) {
+		return env.StorageLoad(slot).Bytes(), nil
+	}, true)
+	write := pc.RegisterSignature("write()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, value)
+		return nil, nil
+	}, false)
+	failedWrite := pc.RegisterSignature("failedWrite()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, common.Hash{})
+		return nil, errors.New("failed")
+	}, false)
+
+	r.True(cached.IsStatic(view[:]))
+	r.False(cached.IsStatic(write[:]))
+
+	_, err := cached.Run(env, write[:])
+	r.NoError(err)
+	r.Equal(value, env.StorageLoad(slot))
+
+	// Writes of failed calls are discarded
+	_, err = cached.Run(env, failedWrite[:])
+	r.Error(err)
+	r.Equal(value, env.StorageLoad(slot))
+
+	ret, err := cached.Run(env, view[:])
+	r.NoError(err)
+	r.Equal(value.Bytes(), ret)
+}
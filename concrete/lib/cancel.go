@@ -0,0 +1,146 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+// ContextEnvironment wraps an environment with a context.Context and panics
+// with the error of the context on any call reading or writing state once the
+// context is done, e.g. to stop a long running call at a deadline.
+// Precompiles wrapped with Recover return ErrPrecompilePanic instead.
+type ContextEnvironment struct {
+	api.Environment
+	ctx context.Context
+}
+
+var _ api.Environment = (*ContextEnvironment)(nil)
+
+func NewContextEnvironment(ctx context.Context, env api.Environment) *ContextEnvironment {
+	return &ContextEnvironment{Environment: env, ctx: ctx}
+}
+
+// Context returns the context of the environment, e.g. to read the tracing
+// values it carries.
+func (e *ContextEnvironment) Context() context.Context {
+	return e.ctx
+}
+
+func (e *ContextEnvironment) check() {
+	if err := e.ctx.Err(); err != nil {
+		panic(err)
+	}
+}
+
+func (e *ContextEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	e.check()
+	return e.Environment.Execute(op, args)
+}
+
+func (e *ContextEnvironment) StorageLoad(key common.Hash) common.Hash {
+	e.check()
+	return e.Environment.StorageLoad(key)
+}
+
+func (e *ContextEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	e.check()
+	e.Environment.StorageStore(key, value)
+}
+
+func (e *ContextEnvironment) TransientLoad(key common.Hash) common.Hash {
+	e.check()
+	return e.Environment.TransientLoad(key)
+}
+
+func (e *ContextEnvironment) TransientStore(key common.Hash, value common.Hash) {
+	e.check()
+	e.Environment.TransientStore(key, value)
+}
+
+func (e *ContextEnvironment) Log(topics []common.Hash, data []byte) {
+	e.check()
+	e.Environment.Log(topics, data)
+}
+
+func (e *ContextEnvironment) GetExternalStorage(address common.Address, key common.Hash) common.Hash {
+	e.check()
+	return e.Environment.GetExternalStorage(address, key)
+}
+
+func (e *ContextEnvironment) CallStatic(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	e.check()
+	return e.Environment.CallStatic(address, data, gas)
+}
+
+func (e *ContextEnvironment) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
+	e.check()
+	return e.Environment.Call(address, data, gas, value)
+}
+
+func (e *ContextEnvironment) CallDelegate(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	e.check()
+	return e.Environment.CallDelegate(address, data, gas)
+}
+
+func (e *ContextEnvironment) Create(data []byte, value *uint256.Int) ([]byte, common.Address, error) {
+	e.check()
+	return e.Environment.Create(data, value)
+}
+
+func (e *ContextEnvironment) Create2(data []byte, endowment *uint256.Int, salt *uint256.Int) ([]byte, common.Address, error) {
+	e.check()
+	return e.Environment.Create2(data, endowment, salt)
+}
+
+type contextKV struct {
+	kv  KeyValueStore
+	ctx context.Context
+}
+
+func (kv *contextKV) check() {
+	if err := kv.ctx.Err(); err != nil {
+		panic(err)
+	}
+}
+
+func (kv *contextKV) Set(key common.Hash, value common.Hash) {
+	kv.check()
+	kv.kv.Set(key, value)
+}
+
+func (kv *contextKV) Get(key common.Hash) common.Hash {
+	kv.check()
+	return kv.kv.Get(key)
+}
+
+var _ KeyValueStore = (*contextKV)(nil)
+
+// WithContext returns the slot bound to ctx: reading or writing it, or any
+// slot derived from it, panics with the error of the context once it is done.
+// Slots not created by the datastores of this package are returned as is.
+func WithContext(ctx context.Context, slot DatastoreSlot) DatastoreSlot {
+	s, ok := slot.(*dsSlot)
+	if !ok {
+		return slot
+	}
+	ds := newDatastore(&contextKV{kv: s.ds.kv, ctx: ctx})
+	return newDatastoreSlot(ds, s.slot)
+}
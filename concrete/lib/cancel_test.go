@@ -0,0 +1,82 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextEnvironment(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cEnv := NewContextEnvironment(ctx, env)
+	r.Equal(ctx, cEnv.Context())
+	cEnv.StorageStore(slot, value)
+	r.Equal(value, cEnv.StorageLoad(slot))
+
+	cancel()
+	r.PanicsWithError(context.Canceled.Error(), func() { cEnv.StorageLoad(slot) })
+	r.PanicsWithError(context.Canceled.Error(), func() { cEnv.StorageStore(slot, value) })
+	r.PanicsWithError(context.Canceled.Error(), func() {
+		cEnv.Execute(api.StorageLoad_OpCode, [][]byte{slot.Bytes()})
+	})
+	// Reads that do not touch state still work
+	r.Equal(address, cEnv.GetAddress())
+}
+
+func TestWithContext(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		ds       = NewDatastore(env)
+		key      = []byte("key")
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	slot := WithContext(ctx, ds.Get(key))
+	r.Equal(ds.Get(key).Slot(), slot.Slot())
+	slot.Mapping().Get([]byte("a")).SetUint64(7)
+	r.Equal(uint64(7), ds.Get(key).Mapping().Get([]byte("a")).Uint64())
+
+	nested := slot.Mapping().Get([]byte("a"))
+	cancel()
+	r.PanicsWithError(context.Canceled.Error(), func() { nested.Uint64() })
+	r.PanicsWithError(context.Canceled.Error(), func() { slot.SetUint64(1) })
+	// The slot it was derived from is not bound to the context
+	r.Equal(uint64(7), ds.Get(key).Mapping().Get([]byte("a")).Uint64())
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+// Middleware wraps a precompile to add behavior around it, e.g. inspecting the
+// input, wrapping the environment or post-processing the output.
+type Middleware func(next concrete.Precompile) concrete.Precompile
+
+// chainedPrecompile is a precompile wrapped by a list of middlewares.
+type chainedPrecompile struct {
+	layers []concrete.Precompile // From the base precompile to the outermost middleware
+}
+
+var _ concrete.Precompile = (*chainedPrecompile)(nil)
+
+// Chain wraps base with the given middlewares. The first middleware is the
+// outermost one, i.e. it is the first to see the input and the last to see the
+// output, in the same way as http middleware chains.
+func Chain(base concrete.Precompile, middlewares ...Middleware) concrete.Precompile {
+	layers := make([]concrete.Precompile, 0, len(middlewares)+1)
+	layers = append(layers, base)
+	pc := base
+	for ii := len(middlewares) - 1; ii >= 0; ii-- {
+		pc = middlewares[ii](pc)
+		layers = append(layers, pc)
+	}
+	return &chainedPrecompile{layers: layers}
+}
+
+func (c *chainedPrecompile) outer() concrete.Precompile {
+	return c.layers[len(c.layers)-1]
+}
+
+// IsStatic returns true only if the base precompile and all middlewares
+// report the input as static.
+func (c *chainedPrecompile) IsStatic(input []byte) bool {
+	for _, pc := range c.layers {
+		if !pc.IsStatic(input) {
+			return false
+		}
+	}
+	return true
+}
+
+// GasCost forwards to the outermost middleware if it implements
+// concrete.GasCoster.
+func (c *chainedPrecompile) GasCost(input []byte) uint64 {
+	return gasCost(c.outer(), input)
+}
+
+func (c *chainedPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	return c.outer().Run(env, input)
+}
+
+// ReentrancyGuardMiddleware wraps the next precompile in a ReentrancyGuard.
+func ReentrancyGuardMiddleware(next concrete.Precompile) concrete.Precompile {
+	return NewReentrancyGuard(next)
+}
+
+// StaticGuardMiddleware wraps the next precompile in a StaticGuard.
+func StaticGuardMiddleware(next concrete.Precompile) concrete.Precompile {
+	return NewStaticGuard(next)
+}
+
+// CacheMiddleware wraps the next precompile in a CachedPrecompile.
+func CacheMiddleware(next concrete.Precompile) concrete.Precompile {
+	return NewCachedPrecompile(next)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+type testPrecompile struct {
+	static bool
+	run    func(env api.Environment, input []byte) ([]byte, error)
+}
+
+func (pc *testPrecompile) IsStatic(input []byte) bool {
+	return pc.static
+}
+
+func (pc *testPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	return pc.run(env, input)
+}
+
+func TestChain(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		trace    []string
+	)
+
+	tag := func(name string, static bool) Middleware {
+		return func(next concrete.Precompile) concrete.Precompile {
+			return &testPrecompile{
+				static: static,
+				run: func(env api.Environment, input []byte) ([]byte, error) {
+					trace = append(trace, "enter "+name)
+					ret, err := next.Run(env, append(input, name...))
+					trace = append(trace, "exit "+name)
+					return ret, err
+				},
+			}
+		}
+	}
+	base := &testPrecompile{
+		static: true,
+		run: func(env api.Environment, input []byte) ([]byte, error) {
+			trace = append(trace, "base")
+			return input, nil
+		},
+	}
+
+	pc := Chain(base, tag("a", true), tag("b", true))
+	r.True(pc.IsStatic(nil))
+	ret, err := pc.Run(env, nil)
+	r.NoError(err)
+	r.Equal([]byte("ab"), ret)
+	r.Equal([]string{"enter a", "enter b", "base", "exit b", "exit a"}, trace)
+
+	// Static only if all layers agree
+	r.False(Chain(base, tag("a", true), tag("b", false)).IsStatic(nil))
+	base.static = false
+	r.False(Chain(base, tag("a", true)).IsStatic(nil))
+
+	// Without middlewares the chain behaves like the base precompile
+	ret, err = Chain(base).Run(env, []byte{0x01})
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+}
+
+func TestChainGasCost(t *testing.T) {
+	r := require.New(t)
+	pc := NewMethodDispatcher()
+	pc.SetDefaultGasCost(100)
+	chained := Chain(pc, ReentrancyGuardMiddleware, CacheMiddleware)
+	r.Equal(uint64(100), chained.(concrete.GasCoster).GasCost(nil))
+}
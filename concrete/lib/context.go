@@ -0,0 +1,77 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+// Context is a typed view of the block, transaction and call a precompile runs
+// in, i.e. the block.*, tx.* and msg.* values of solidity. Precompiles taking a
+// Context instead of reading the environment directly can be tested with any
+// implementation of the interface.
+type Context interface {
+	// BlockNumber returns block.number.
+	BlockNumber() uint64
+	// Timestamp returns block.timestamp.
+	Timestamp() uint64
+	// Origin returns tx.origin.
+	Origin() common.Address
+	// GasPrice returns tx.gasprice.
+	GasPrice() *uint256.Int
+	// Caller returns msg.sender.
+	Caller() common.Address
+	// CallValue returns msg.value.
+	CallValue() *uint256.Int
+}
+
+type envContext struct {
+	env api.Environment
+}
+
+// NewContext returns the context of the call the environment executes. Every
+// method reads the environment, charging the gas of the matching opcode.
+func NewContext(env api.Environment) Context {
+	return &envContext{env: env}
+}
+
+func (c *envContext) BlockNumber() uint64 {
+	return c.env.GetBlockNumber()
+}
+
+func (c *envContext) Timestamp() uint64 {
+	return c.env.GetBlockTimestamp()
+}
+
+func (c *envContext) Origin() common.Address {
+	return c.env.GetTxOrigin()
+}
+
+func (c *envContext) GasPrice() *uint256.Int {
+	return c.env.GetTxGasPrice()
+}
+
+func (c *envContext) Caller() common.Address {
+	return c.env.GetCaller()
+}
+
+func (c *envContext) CallValue() *uint256.Int {
+	return c.env.GetCallValue()
+}
+
+var _ Context = (*envContext)(nil)
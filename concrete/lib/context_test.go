@@ -0,0 +1,54 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext(t *testing.T) {
+	var (
+		r        = require.New(t)
+		origin   = common.HexToAddress("0x01")
+		caller   = common.HexToAddress("0x02")
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(origin, caller, address, uint256.NewInt(7))
+		block    = api.NewMockBlockContext()
+	)
+	contract.Value = uint256.NewInt(100)
+	block.SetBlockNumber(12)
+	block.SetTimestamp(1700000000)
+	env := api.NewEnvironment(api.EnvConfig{}, false, mock.NewMockStateDB(), block, api.NewMockCaller(), contract)
+
+	ctx := NewContext(env)
+	r.Equal(uint64(12), ctx.BlockNumber())
+	r.Equal(uint64(1700000000), ctx.Timestamp())
+	r.Equal(origin, ctx.Origin())
+	r.Equal(uint256.NewInt(7), ctx.GasPrice())
+	r.Equal(caller, ctx.Caller())
+	r.Equal(uint256.NewInt(100), ctx.CallValue())
+}
@@ -20,7 +20,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/concrete/api"
-	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 	"github.com/holiman/uint256"
 )
 
@@ -68,11 +68,7 @@ func newDatastore(kv KeyValueStore) *datastore {
 }
 
 func (ds *datastore) value(key []byte) *dsSlot {
-	if len(key) > 32 {
-		key = crypto.Keccak256(key)
-	}
-	slot := common.BytesToHash(key)
-	return newDatastoreSlot(ds, slot)
+	return newDatastoreSlot(ds, storage.KeySlot(key))
 }
 
 func (ds *datastore) Get(key []byte) DatastoreSlot {
@@ -102,6 +98,7 @@ type DatastoreSlot interface {
 	BytesArray(length []int, itemSize int) BytesArray
 	Mapping() Mapping
 	DynamicArray() DynamicArray
+	ContiguousArray() ContiguousArray
 
 	Bytes32() common.Hash
 	SetBytes32(value common.Hash)
@@ -115,8 +112,15 @@ type DatastoreSlot interface {
 	SetUint64(value uint64)
 	Int64() int64
 	SetInt64(value int64)
+	// Bytes values are laid out as solidity bytes: values shorter than 32
+	// bytes are stored in the slot with 2*length in its last byte, longer ones
+	// store 2*length+1 in the slot and their data from the keccak256 hash of
+	// the slot.
 	Bytes() []byte
 	SetBytes(value []byte)
+	// ClearBytes zeroes a value written with SetBytes, including the data
+	// slots of long values.
+	ClearBytes()
 }
 
 type dsSlot struct {
@@ -131,7 +135,7 @@ func newDatastoreSlot(ds *datastore, slot common.Hash) *dsSlot {
 
 func (r *dsSlot) getSlotHash() common.Hash {
 	if r.slotHash == nil {
-		hash := crypto.Keccak256Hash(r.slot.Bytes())
+		hash := storage.DataSlot(r.slot)
 		r.slotHash = &hash
 	}
 	return *r.slotHash
@@ -153,6 +157,10 @@ func (r *dsSlot) array() *dynamicArray {
 	return newDynamicArray(r)
 }
 
+func (r *dsSlot) contiguousArray() *contiguousArray {
+	return newContiguousArray(r)
+}
+
 func (r *dsSlot) getBytes32() common.Hash {
 	return r.ds.kv.Get(r.slot)
 }
@@ -170,7 +178,8 @@ func (r *dsSlot) getBytes() []byte {
 		return slotData[:length]
 	}
 
-	length := slotData.Big().Int64()
+	// Long values store 2*length+1 in the slot, as in solidity
+	length := new(big.Int).Rsh(slotData.Big(), 1).Int64()
 	ptr := r.getSlotHash().Big()
 
 	data := make([]byte, length)
@@ -192,7 +201,7 @@ func (r *dsSlot) setBytes(value []byte) {
 		return
 	}
 
-	lengthBN := big.NewInt(int64(len(value)))
+	lengthBN := big.NewInt(int64(2*len(value) + 1))
 	r.ds.kv.Set(r.slot, common.BigToHash(lengthBN))
 
 	ptr := r.getSlotHash().Big()
@@ -204,6 +213,19 @@ func (r *dsSlot) setBytes(value []byte) {
 	}
 }
 
+func (r *dsSlot) clearBytes() {
+	slotData := r.ds.kv.Get(r.slot)
+	if lsb := slotData[len(slotData)-1]; lsb&1 == 1 {
+		length := new(big.Int).Rsh(slotData.Big(), 1).Int64()
+		ptr := r.getSlotHash().Big()
+		for ii := int64(0); ii < length; ii += 32 {
+			r.ds.kv.Set(common.BigToHash(ptr), common.Hash{})
+			ptr = ptr.Add(ptr, common.Big1)
+		}
+	}
+	r.ds.kv.Set(r.slot, common.Hash{})
+}
+
 func (r *dsSlot) Datastore() Datastore {
 	return r.ds
 }
@@ -228,6 +250,10 @@ func (r *dsSlot) DynamicArray() DynamicArray {
 	return r.array()
 }
 
+func (r *dsSlot) ContiguousArray() ContiguousArray {
+	return r.contiguousArray()
+}
+
 func (r *dsSlot) Bytes32() common.Hash {
 	return r.getBytes32()
 }
@@ -300,6 +326,10 @@ func (r *dsSlot) SetBytes(value []byte) {
 	r.setBytes(value)
 }
 
+func (r *dsSlot) ClearBytes() {
+	r.clearBytes()
+}
+
 var _ DatastoreSlot = (*dsSlot)(nil)
 
 type SlotArray interface {
@@ -343,8 +373,7 @@ func (a *slotArray) indexSlot(index []int) *common.Hash {
 		}
 		flatIndex += index[ii] * a.flatLength[ii]
 	}
-	slotIndex := new(big.Int).Add(big.NewInt(int64(flatIndex)), a.dsSlot.slot.Big())
-	slot := common.BigToHash(slotIndex)
+	slot := storage.OffsetSlot(a.dsSlot.slot, uint64(flatIndex))
 	return &slot
 }
 
@@ -505,6 +534,7 @@ var _ BytesArray = (*bytesArray)(nil)
 type Mapping interface {
 	Datastore
 	GetNested(keys ...[]byte) DatastoreSlot
+	GetComposite(keys ...[]byte) DatastoreSlot
 }
 
 type mapping struct {
@@ -516,7 +546,7 @@ func newMapping(dsSlot *dsSlot) *mapping {
 }
 
 func (m *mapping) keySlot(key []byte) common.Hash {
-	return crypto.Keccak256Hash(key, m.dsSlot.slot.Bytes())
+	return storage.MappingSlot(m.dsSlot.slot, key)
 }
 
 func (m *mapping) value(key []byte) *dsSlot {
@@ -542,10 +572,23 @@ func (m *mapping) nestedValue(keys [][]byte) *dsSlot {
 	return currentMapping.value(mapKey)
 }
 
+// Composite values are stored at the hash of the concatenation of all keys in
+// order followed by the mapping slot, instead of nesting one mapping per key.
+func (m *mapping) compositeValue(keys [][]byte) *dsSlot {
+	if len(keys) == 0 {
+		return nil
+	}
+	return newDatastoreSlot(m.dsSlot.ds, storage.CompositeRowSlot(m.dsSlot.slot, keys...))
+}
+
 func (m *mapping) Get(key []byte) DatastoreSlot {
 	return m.value(key)
 }
 
+func (m *mapping) GetComposite(keys ...[]byte) DatastoreSlot {
+	return m.compositeValue(keys)
+}
+
 func (m *mapping) GetNested(keys ...[]byte) DatastoreSlot {
 	return m.nestedValue(keys)
 }
@@ -639,3 +682,81 @@ func (a *dynamicArray) Pop() DatastoreSlot {
 }
 
 var _ DynamicArray = (*dynamicArray)(nil)
+
+type ContiguousArray interface {
+	Length() uint64
+	Get(index uint64) DatastoreSlot
+	Push() DatastoreSlot
+	Pop() DatastoreSlot
+	// Clear zeroes all items and sets the length to zero.
+	Clear()
+}
+
+type contiguousArray struct {
+	dsSlot *dsSlot
+}
+
+func newContiguousArray(dsSlot *dsSlot) *contiguousArray {
+	return &contiguousArray{dsSlot: dsSlot}
+}
+
+// Contiguous arrays are laid out on memory like solidity dynamic arrays, storing
+// the length of the array in the slot and the items in consecutive slots
+// starting at keccak256(slot).
+func (a *contiguousArray) indexSlot(index uint64) *common.Hash {
+	if index >= a.getLength() {
+		return nil
+	}
+	slot := storage.OffsetSlot(a.dsSlot.getSlotHash(), index)
+	return &slot
+}
+
+func (a *contiguousArray) setLength(length uint64) {
+	a.dsSlot.SetUint64(length)
+}
+
+func (a *contiguousArray) getLength() uint64 {
+	return a.dsSlot.Uint64()
+}
+
+func (a *contiguousArray) value(index uint64) *dsSlot {
+	slot := a.indexSlot(index)
+	if slot == nil {
+		return nil
+	}
+	return newDatastoreSlot(a.dsSlot.ds, *slot)
+}
+
+func (a *contiguousArray) Length() uint64 {
+	return a.getLength()
+}
+
+func (a *contiguousArray) Get(index uint64) DatastoreSlot {
+	return a.value(index)
+}
+
+func (a *contiguousArray) Push() DatastoreSlot {
+	length := a.getLength()
+	a.setLength(length + 1)
+	return a.value(length)
+}
+
+func (a *contiguousArray) Pop() DatastoreSlot {
+	length := a.getLength()
+	if length == 0 {
+		return nil
+	}
+	value := a.value(length - 1)
+	a.setLength(length - 1)
+	return value
+}
+
+func (a *contiguousArray) Clear() {
+	length := a.getLength()
+	for ii := uint64(0); ii < length; ii++ {
+		a.value(ii).setBytes32(common.Hash{})
+	}
+	a.setLength(0)
+}
+
+var _ ContiguousArray = (*contiguousArray)(nil)
@@ -17,6 +17,7 @@ package lib
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/storage"
 )
 
 type DatastoreStruct struct {
@@ -27,25 +28,51 @@ type DatastoreStruct struct {
 }
 
 func NewDatastoreStruct(store DatastoreSlot, sizes []int) *DatastoreStruct {
-	var (
-		offset  = 0
-		offsets = make([]int, len(sizes))
-		nSlots  int
-	)
+	for _, size := range sizes {
+		if size < 0 {
+			panic("negative field size")
+		}
+	}
+	// Fields that do not fit in the remaining space of the current slot start
+	// at the next one. Fields larger than a slot span several consecutive
+	// slots starting at a slot boundary.
+	return newDatastoreStruct(store, sizes, storage.FieldOffsets(sizes))
+}
+
+// NewDatastoreStructWithOffsets creates a struct with fields at the given byte
+// offsets instead of packing them in order. Fields must not cross slot
+// boundaries unless they are larger than a slot, in which case they must start
+// at one. Overlapping fields are not detected.
+func NewDatastoreStructWithOffsets(store DatastoreSlot, sizes []int, offsets []int) *DatastoreStruct {
+	if len(sizes) != len(offsets) {
+		panic("sizes and offsets must have the same length")
+	}
 	for ii, size := range sizes {
+		offset := offsets[ii]
 		if size < 0 {
 			panic("negative field size")
 		}
-		if size > 32 {
-			panic("field size too large")
+		if offset < 0 {
+			panic("negative field offset")
 		}
-		if offset/32 != (offset+size-1)/32 {
-			offset = (offset/32 + 1) * 32
+		if size > 32 && offset%32 != 0 {
+			panic("multi-slot field must start at a slot boundary")
+		}
+		if size <= 32 && size > 0 && offset/32 != (offset+size-1)/32 {
+			panic("field crosses a slot boundary")
+		}
+	}
+	return newDatastoreStruct(store, sizes, offsets)
+}
+
+func newDatastoreStruct(store DatastoreSlot, sizes []int, offsets []int) *DatastoreStruct {
+	end := 0
+	for ii, size := range sizes {
+		if offsets[ii]+size > end {
+			end = offsets[ii] + size
 		}
-		offsets[ii] = offset
-		offset += size
 	}
-	nSlots = (offset + 31) / 32
+	nSlots := (end + 31) / 32
 
 	return &DatastoreStruct{
 		store:   store,
@@ -59,10 +86,35 @@ func (s *DatastoreStruct) GetField(index int) []byte {
 	fieldSize := s.sizes[index]
 	absOffset := s.offsets[index]
 	slotIndex, slotOffset := absOffset/32, absOffset%32
+	if fieldSize > 32 {
+		return s.getMultiSlotField(slotIndex, fieldSize)
+	}
 	slotData := s.arr.Get(slotIndex).Bytes32()
 	return slotData[slotOffset : slotOffset+fieldSize]
 }
 
+func (s *DatastoreStruct) getMultiSlotField(slotIndex int, fieldSize int) []byte {
+	data := make([]byte, 0, (fieldSize+31)/32*32)
+	for ii := 0; ii < fieldSize; ii += 32 {
+		slotData := s.arr.Get(slotIndex + ii/32).Bytes32()
+		data = append(data, slotData[:]...)
+	}
+	return data[:fieldSize]
+}
+
+func (s *DatastoreStruct) setMultiSlotField(slotIndex int, data []byte) {
+	for ii := 0; ii < len(data); ii += 32 {
+		slotRef := s.arr.Get(slotIndex + ii/32)
+		var slotData common.Hash
+		if len(data)-ii < 32 {
+			// Preserve the fields packed after the end of this one
+			slotData = slotRef.Bytes32()
+		}
+		copy(slotData[:], data[ii:])
+		slotRef.SetBytes32(slotData)
+	}
+}
+
 func (s *DatastoreStruct) SetField(index int, data []byte) {
 	fieldSize := s.sizes[index]
 
@@ -72,6 +124,10 @@ func (s *DatastoreStruct) SetField(index int, data []byte) {
 
 	absOffset := s.offsets[index]
 	slotIndex, slotOffset := absOffset/32, absOffset%32
+	if fieldSize > 32 {
+		s.setMultiSlotField(slotIndex, data)
+		return
+	}
 	slotRef := s.arr.Get(slotIndex)
 
 	var slotData common.Hash
@@ -85,12 +141,81 @@ func (s *DatastoreStruct) SetField(index int, data []byte) {
 	slotRef.SetBytes32(slotData)
 }
 
+// GetFields returns the data of several fields, loading each slot only once.
+func (s *DatastoreStruct) GetFields(indices ...int) [][]byte {
+	slots := make(map[int]common.Hash)
+	load := func(slotIndex int) common.Hash {
+		slotData, ok := slots[slotIndex]
+		if !ok {
+			slotData = s.arr.Get(slotIndex).Bytes32()
+			slots[slotIndex] = slotData
+		}
+		return slotData
+	}
+	fields := make([][]byte, len(indices))
+	for ii, index := range indices {
+		fieldSize := s.sizes[index]
+		absOffset := s.offsets[index]
+		data := make([]byte, 0, fieldSize)
+		for offset := absOffset; offset < absOffset+fieldSize; {
+			slotIndex, slotOffset := offset/32, offset%32
+			slotData := load(slotIndex)
+			n := copy(data[len(data):cap(data)], slotData[slotOffset:])
+			data = data[:len(data)+n]
+			offset += n
+		}
+		fields[ii] = data
+	}
+	return fields
+}
+
+// SetFields sets the data of several fields. Every affected slot is loaded at
+// most once, if it is only partially written, and stored once.
+func (s *DatastoreStruct) SetFields(indices []int, data [][]byte) {
+	if len(indices) != len(data) {
+		panic("invalid number of fields")
+	}
+	var (
+		slots = make(map[int]common.Hash)
+		dirty []int
+	)
+	for ii, index := range indices {
+		fieldSize := s.sizes[index]
+		if len(data[ii]) != fieldSize {
+			panic("invalid data size")
+		}
+		absOffset := s.offsets[index]
+		for written := 0; written < fieldSize; {
+			offset := absOffset + written
+			slotIndex, slotOffset := offset/32, offset%32
+			slotData, ok := slots[slotIndex]
+			if !ok {
+				if slotOffset != 0 || fieldSize-written < 32 {
+					// Preserve the other fields packed in the slot
+					slotData = s.arr.Get(slotIndex).Bytes32()
+				}
+				dirty = append(dirty, slotIndex)
+			}
+			written += copy(slotData[slotOffset:], data[ii][written:])
+			slots[slotIndex] = slotData
+		}
+	}
+	for _, slotIndex := range dirty {
+		s.arr.Get(slotIndex).SetBytes32(slots[slotIndex])
+	}
+}
+
 func (s *DatastoreStruct) GetField_slot(index int) DatastoreSlot {
 	absOffset := s.offsets[index]
 	slotIndex := absOffset / 32
 	return s.arr.Get(slotIndex)
 }
 
+// GetBase_slot returns the first slot of the struct.
+func (s *DatastoreStruct) GetBase_slot() DatastoreSlot {
+	return s.store
+}
+
 func (s *DatastoreStruct) GetField_bytes(index int) []byte {
 	slotRef := s.GetField_slot(index)
 	return slotRef.Bytes()
@@ -100,3 +225,21 @@ func (s *DatastoreStruct) SetField_bytes(index int, data []byte) {
 	slotRef := s.GetField_slot(index)
 	slotRef.SetBytes(data)
 }
+
+// Clear zeroes all the slots of the struct. Data of dynamic fields stored
+// outside of the struct slots must be cleared separately.
+func (s *DatastoreStruct) Clear() {
+	for ii := 0; ii < s.arr.Length(); ii++ {
+		s.arr.Get(ii).SetBytes32(common.Hash{})
+	}
+}
+
+// IsZero reports whether all the slots of the struct are zero.
+func (s *DatastoreStruct) IsZero() bool {
+	for ii := 0; ii < s.arr.Length(); ii++ {
+		if s.arr.Get(ii).Bytes32() != (common.Hash{}) {
+			return false
+		}
+	}
+	return true
+}
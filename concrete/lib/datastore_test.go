@@ -21,10 +21,12 @@
 package lib
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
 	"github.com/ethereum/go-ethereum/concrete/mock"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
@@ -128,6 +130,66 @@ func TestDatastoreSlot(t *testing.T) {
 
 	slot.SetBytes([]byte{0x01, 0x02, 0x03})
 	r.Equal([]byte{0x01, 0x02, 0x03}, slot.Bytes())
+
+	slot.ClearBytes()
+	r.Equal(common.Hash{}, slot.Bytes32())
+}
+
+func TestDatastoreSlotBytes(t *testing.T) {
+	var (
+		r          = require.New(t)
+		slot, _, _ = newSlot("bytes.test")
+	)
+
+	for _, length := range []int{0, 1, 31, 32, 33, 64, 65} {
+		value := make([]byte, length)
+		for ii := range value {
+			value[ii] = byte(ii + 1)
+		}
+		slot.SetBytes(value)
+		r.Equal(value, slot.Bytes(), length)
+	}
+
+	// Long values are laid out as in solidity
+	value := make([]byte, 40)
+	value[0], value[39] = 0x01, 0x02
+	slot.SetBytes(value)
+	r.Equal(common.BigToHash(big.NewInt(81)), slot.Bytes32())
+	dataSlot := crypto.Keccak256Hash(slot.Slot().Bytes())
+	data0 := slot.Datastore().Get(dataSlot.Bytes())
+	data1 := slot.Datastore().Get(common.BigToHash(new(big.Int).Add(dataSlot.Big(), common.Big1)).Bytes())
+	r.Equal(common.Hash{0x01}, data0.Bytes32())
+	r.Equal(byte(0x02), data1.Bytes32()[7])
+
+	slot.ClearBytes()
+	r.Equal(common.Hash{}, slot.Bytes32())
+	r.Equal(common.Hash{}, data0.Bytes32())
+	r.Equal(common.Hash{}, data1.Bytes32())
+	r.Equal([]byte{}, slot.Bytes())
+}
+
+func TestDatastoreSlotLongBytes(t *testing.T) {
+	var (
+		r          = require.New(t)
+		slot, _, _ = newSlot("bytes.long.test")
+	)
+
+	// Values of 32 bytes and more are long values, whatever the parity of
+	// their length
+	for _, length := range []int{32, 33} {
+		value := make([]byte, length)
+		for ii := range value {
+			value[ii] = 0xff
+		}
+		slot.SetBytes(value)
+		r.Equal(common.BigToHash(big.NewInt(int64(2*length+1))), slot.Bytes32(), length)
+		r.Equal(value, slot.Bytes(), length)
+	}
+
+	value := make([]byte, 31)
+	slot.SetBytes(value)
+	r.Equal(byte(62), slot.Bytes32()[31])
+	r.Equal(value, slot.Bytes())
 }
 
 func TestMapping(t *testing.T) {
@@ -143,6 +205,12 @@ func TestMapping(t *testing.T) {
 	testSlot(t, func() DatastoreSlot {
 		return mapping.GetNested([]byte{0x01}, []byte{0x02})
 	})
+	testSlot(t, func() DatastoreSlot {
+		return mapping.GetComposite([]byte{0x03}, []byte{0x04})
+	})
+	r.Nil(mapping.GetComposite())
+	r.NotEqual(mapping.GetNested([]byte{0x01}, []byte{0x02}).Slot(), mapping.GetComposite([]byte{0x01}, []byte{0x02}).Slot())
+	r.Equal(crypto.Keccak256Hash([]byte{0x01}, []byte{0x02}, slot.Slot().Bytes()), mapping.GetComposite([]byte{0x01}, []byte{0x02}).Slot())
 }
 
 func TestDynamicArray(t *testing.T) {
@@ -178,3 +246,163 @@ func TestDynamicArray(t *testing.T) {
 		return array.GetNested(1, 0) // slot1_0
 	})
 }
+
+func TestDatastoreStruct(t *testing.T) {
+	var (
+		r          = require.New(t)
+		slot, _, _ = newSlot("struct.test")
+	)
+
+	sizes := []int{1, 40, 20, 32}
+	values := make([][]byte, len(sizes))
+	for ii, size := range sizes {
+		values[ii] = make([]byte, size)
+		for jj := range values[ii] {
+			values[ii][jj] = byte(ii + 1)
+		}
+	}
+
+	s := NewDatastoreStruct(slot, sizes)
+	for ii, size := range sizes {
+		r.Equal(make([]byte, size), s.GetField(ii))
+	}
+	for ii, value := range values {
+		s.SetField(ii, value)
+	}
+
+	s = NewDatastoreStruct(slot, sizes)
+	for ii, value := range values {
+		r.Equal(value, s.GetField(ii))
+	}
+
+	r.False(s.IsZero())
+	s.Clear()
+	r.True(s.IsZero())
+	for ii, size := range sizes {
+		r.Equal(make([]byte, size), s.GetField(ii))
+	}
+}
+
+func TestDatastoreStructWithOffsets(t *testing.T) {
+	var (
+		r          = require.New(t)
+		slot, _, _ = newSlot("struct.offsets.test")
+	)
+
+	sizes := []int{8, 40, 8}
+	offsets := []int{96, 0, 104}
+	s := NewDatastoreStructWithOffsets(slot, sizes, offsets)
+	for ii, size := range sizes {
+		value := make([]byte, size)
+		for jj := range value {
+			value[jj] = byte(ii + 1)
+		}
+		s.SetField(ii, value)
+	}
+	arr := slot.SlotArray([]int{4})
+	r.Equal(byte(1), arr.Get(3).Bytes32()[0])
+	r.Equal(byte(2), arr.Get(1).Bytes32()[7])
+	r.Equal(byte(3), arr.Get(3).Bytes32()[8])
+
+	// The last slot is part of the struct
+	s.Clear()
+	r.True(s.IsZero())
+
+	r.Panics(func() { NewDatastoreStructWithOffsets(slot, []int{8}, []int{28}) })
+	r.Panics(func() { NewDatastoreStructWithOffsets(slot, []int{40}, []int{8}) })
+	r.Panics(func() { NewDatastoreStructWithOffsets(slot, []int{8}, []int{}) })
+}
+
+type countingKV struct {
+	store map[common.Hash]common.Hash
+	gets  int
+	sets  int
+}
+
+func (kv *countingKV) Get(key common.Hash) common.Hash {
+	kv.gets++
+	return kv.store[key]
+}
+
+func (kv *countingKV) Set(key common.Hash, value common.Hash) {
+	kv.sets++
+	kv.store[key] = value
+}
+
+func TestDatastoreStructFields(t *testing.T) {
+	var (
+		r    = require.New(t)
+		kv   = &countingKV{store: make(map[common.Hash]common.Hash)}
+		slot = NewKVDatastore(kv).Get([]byte("struct.fields.test"))
+	)
+
+	// Slot 0 holds fields 0 and 1, field 2 spans slots 1 and 2 and fields 3
+	// and 4 are packed after it in slot 2
+	sizes := []int{8, 20, 40, 1, 2}
+	values := make([][]byte, len(sizes))
+	for ii, size := range sizes {
+		values[ii] = make([]byte, size)
+		for jj := range values[ii] {
+			values[ii][jj] = byte(ii + 1)
+		}
+	}
+	s := NewDatastoreStruct(slot, sizes)
+	s.SetField(4, values[4])
+
+	kv.gets, kv.sets = 0, 0
+	s.SetFields([]int{0, 1, 2, 3}, values[:4])
+	// Only slot 1 is fully overwritten, and slot 2 is loaded and stored once
+	// for fields 2 and 3
+	r.Equal(2, kv.gets)
+	r.Equal(3, kv.sets)
+	for ii, value := range values {
+		r.Equal(value, s.GetField(ii))
+	}
+
+	kv.gets, kv.sets = 0, 0
+	r.Equal(values, s.GetFields(0, 1, 2, 3, 4))
+	r.Equal(3, kv.gets)
+	r.Equal([][]byte{values[3], values[0]}, s.GetFields(3, 0))
+
+	r.Panics(func() { s.SetFields([]int{0}, [][]byte{{0x01}}) })
+	r.Panics(func() { s.SetFields([]int{0, 1}, values[:1]) })
+}
+
+func TestContiguousArray(t *testing.T) {
+	var (
+		r          = require.New(t)
+		slot, _, _ = newSlot("contiguous.test")
+	)
+
+	array := slot.ContiguousArray()
+
+	r.NotNil(array)
+	r.Zero(array.Length())
+	r.Nil(array.Get(0))
+	r.Nil(array.Pop())
+
+	slot0 := array.Push()
+	r.NotNil(slot0)
+	slot1 := array.Push()
+	r.NotNil(slot1)
+	r.Equal(uint64(2), array.Length())
+	r.Equal(uint64(2), slot.Uint64())
+
+	// Items are stored contiguously starting at keccak256(slot)
+	start := crypto.Keccak256Hash(slot.Slot().Bytes())
+	r.Equal(start, slot0.Slot())
+	r.Equal(common.BigToHash(new(big.Int).Add(start.Big(), common.Big1)), slot1.Slot())
+
+	testSlot(t, func() DatastoreSlot {
+		return array.Get(1)
+	})
+
+	r.Equal(slot1.Slot(), array.Pop().Slot())
+	r.Equal(uint64(1), array.Length())
+	r.Nil(array.Get(1))
+
+	slot0.SetBytes32(common.Hash{0x02})
+	array.Clear()
+	r.Zero(array.Length())
+	r.Equal(common.Hash{}, slot0.Bytes32())
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+var ErrStorageNotEnumerable = errors.New("storage cannot be enumerated")
+
+// StorageEnumerator is implemented by environments that can list the storage
+// slots of the running contract that may be non-zero. Slots that are not
+// listed must be zero.
+type StorageEnumerator interface {
+	StorageSlots() []common.Hash
+}
+
+// SlotValue is the value of a storage slot.
+type SlotValue struct {
+	Slot  common.Hash
+	Value common.Hash
+}
+
+// DumpStorage returns the non-zero storage slots of the running contract
+// ordered by slot, e.g. to inspect the state of a precompile in tests or debug
+// builds. The environment must implement StorageEnumerator. Environments that
+// do not can be wrapped in a DiffEnvironment before running the precompile, in
+// which case only the slots written through it are dumped. It returns
+// ErrStorageNotEnumerable otherwise.
+func DumpStorage(env api.Environment) ([]SlotValue, error) {
+	enumerator, ok := env.(StorageEnumerator)
+	if !ok {
+		return nil, ErrStorageNotEnumerable
+	}
+	var dump []SlotValue
+	for _, slot := range enumerator.StorageSlots() {
+		if value := env.StorageLoad(slot); value != (common.Hash{}) {
+			dump = append(dump, SlotValue{Slot: slot, Value: value})
+		}
+	}
+	sort.Slice(dump, func(i, j int) bool {
+		return bytes.Compare(dump[i].Slot[:], dump[j].Slot[:]) < 0
+	})
+	return dump, nil
+}
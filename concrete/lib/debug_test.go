@@ -0,0 +1,90 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// enumerableEnvironment lists a fixed set of slots as its storage.
+type enumerableEnvironment struct {
+	api.Environment
+	slots []common.Hash
+}
+
+func (e *enumerableEnvironment) StorageSlots() []common.Hash {
+	return e.slots
+}
+
+func TestDumpStorage(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot1    = common.HexToHash("0x01")
+		slot2    = common.HexToHash("0x02")
+		slot3    = common.HexToHash("0x03")
+		value    = common.HexToHash("0x04")
+	)
+	_, err := DumpStorage(env)
+	r.ErrorIs(err, ErrStorageNotEnumerable)
+
+	env.StorageStore(slot2, value)
+	env.StorageStore(slot3, value)
+	base := &enumerableEnvironment{Environment: env, slots: []common.Hash{slot3, slot2}}
+	dump, err := DumpStorage(base)
+	r.NoError(err)
+	r.Equal([]SlotValue{{slot2, value}, {slot3, value}}, dump)
+
+	// Environments that cannot enumerate their storage dump the slots written
+	// through a DiffEnvironment, zero slots left out
+	diffEnv := NewDiffEnvironment(env)
+	pc := NewMethodDispatcher()
+	write := pc.RegisterSignature("write()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot3, common.Hash{})
+		env.StorageStore(slot1, value)
+		NewDatastore(env).Get([]byte("counter")).SetUint64(1)
+		return nil, nil
+	}, false)
+	_, err = pc.Run(diffEnv, write[:])
+	r.NoError(err)
+	dump, err = DumpStorage(diffEnv)
+	r.NoError(err)
+	r.Equal([]SlotValue{
+		{slot1, value},
+		{NewDatastore(env).Get([]byte("counter")).Slot(), common.BigToHash(common.Big1)},
+	}, dump)
+
+	// Slots of the wrapped environment are dumped too if it can enumerate them
+	diffEnv = NewDiffEnvironment(base)
+	diffEnv.StorageStore(slot1, common.Hash{})
+	diffEnv.StorageStore(slot3, value)
+	dump, err = DumpStorage(diffEnv)
+	r.NoError(err)
+	r.Equal([]SlotValue{{slot2, value}, {slot3, value}}, dump)
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+// SlotDiff is the change of a storage slot between its value before the first
+// write and after the last one.
+type SlotDiff struct {
+	Slot common.Hash
+	Old  common.Hash
+	New  common.Hash
+}
+
+// DiffEnvironment wraps an environment and records the storage slots written
+// through it, so that the resulting state diff can be retrieved with Diff.
+// Writes are applied to the wrapped environment as usual. The original value
+// of a slot is loaded on its first write, which is charged as a storage read.
+// Writes made by other call frames, e.g. reentrant calls, are not recorded.
+type DiffEnvironment struct {
+	api.Environment
+	original map[common.Hash]common.Hash
+	current  map[common.Hash]common.Hash
+	order    []common.Hash
+}
+
+var (
+	_ api.Environment   = (*DiffEnvironment)(nil)
+	_ StorageEnumerator = (*DiffEnvironment)(nil)
+)
+
+func NewDiffEnvironment(env api.Environment) *DiffEnvironment {
+	return &DiffEnvironment{
+		Environment: env,
+		original:    make(map[common.Hash]common.Hash),
+		current:     make(map[common.Hash]common.Hash),
+	}
+}
+
+func (e *DiffEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	if _, ok := e.original[key]; !ok {
+		e.original[key] = e.Environment.StorageLoad(key)
+		e.order = append(e.order, key)
+	}
+	e.current[key] = value
+	e.Environment.StorageStore(key, value)
+}
+
+func (e *DiffEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	if op == api.StorageStore_OpCode {
+		e.StorageStore(common.BytesToHash(args[0]), common.BytesToHash(args[1]))
+		return nil
+	}
+	return e.Environment.Execute(op, args)
+}
+
+// Diff returns one entry per written slot in the order the slots were first
+// written, leaving out slots that hold their original value again.
+func (e *DiffEnvironment) Diff() []SlotDiff {
+	var diff []SlotDiff
+	for _, key := range e.order {
+		if e.current[key] == e.original[key] {
+			continue
+		}
+		diff = append(diff, SlotDiff{Slot: key, Old: e.original[key], New: e.current[key]})
+	}
+	return diff
+}
+
+// StorageSlots returns the slots written through the environment, along with
+// the slots of the wrapped environment if it is a StorageEnumerator, so that
+// the storage of a precompile run with it can be dumped with DumpStorage.
+func (e *DiffEnvironment) StorageSlots() []common.Hash {
+	slots := append([]common.Hash(nil), e.order...)
+	if enumerator, ok := e.Environment.(StorageEnumerator); ok {
+		for _, slot := range enumerator.StorageSlots() {
+			if _, ok := e.original[slot]; !ok {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEnvironment(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot1    = common.HexToHash("0x01")
+		slot2    = common.HexToHash("0x02")
+		slot3    = common.HexToHash("0x03")
+		value1   = common.HexToHash("0x04")
+		value2   = common.HexToHash("0x05")
+	)
+
+	env.StorageStore(slot1, value1)
+	env.StorageStore(slot3, value1)
+
+	diffEnv := NewDiffEnvironment(env)
+	r.Empty(diffEnv.Diff())
+
+	// Multiple writes collapse into one entry
+	diffEnv.StorageStore(slot2, value1)
+	diffEnv.StorageStore(slot1, value2)
+	diffEnv.StorageStore(slot2, value2)
+	// Slots written back to their original value are left out
+	diffEnv.StorageStore(slot3, value2)
+	diffEnv.Execute(api.StorageStore_OpCode, [][]byte{slot3.Bytes(), value1.Bytes()})
+
+	r.Equal([]SlotDiff{
+		{Slot: slot2, Old: common.Hash{}, New: value2},
+		{Slot: slot1, Old: value1, New: value2},
+	}, diffEnv.Diff())
+
+	// Writes are applied to the wrapped environment
+	r.Equal(value2, env.StorageLoad(slot1))
+	r.Equal(value2, env.StorageLoad(slot2))
+	r.Equal(value1, env.StorageLoad(slot3))
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+var ErrMethodNotFound = errors.New("method not found")
+
+// MethodFunc runs a precompile method given its ABI encoded arguments, i.e. the
+// input without the 4-byte selector.
+type MethodFunc func(env api.Environment, args []byte) ([]byte, error)
+
+// Selector returns the 4-byte selector of a method signature such as
+// "transfer(address,uint256)", computed from its CanonicalSignature. Malformed
+// signatures are hashed with spaces removed, use ParseSelector or MustSelector
+// to reject them instead.
+func Selector(signature string) [4]byte {
+	if selector, err := ParseSelector(signature); err == nil {
+		return selector
+	}
+	var selector [4]byte
+	signature = strings.ReplaceAll(signature, " ", "")
+	copy(selector[:], crypto.Keccak256([]byte(signature))[:4])
+	return selector
+}
+
+// GasFunc returns the gas cost of a call to a method given its ABI encoded
+// arguments.
+type GasFunc func(args []byte) uint64
+
+// FixedGasCost returns a GasFunc that charges the same amount for every call.
+func FixedGasCost(gas uint64) GasFunc {
+	return func(args []byte) uint64 {
+		return gas
+	}
+}
+
+// DispatchHook observes a call to a MethodDispatcher once it returns, given the
+// selector and full input of the call, the output and error of the method and
+// how long it ran. The selector is zero for inputs shorter than 4 bytes.
+type DispatchHook func(selector [4]byte, input []byte, out []byte, err error, dur time.Duration)
+
+// MethodDispatcher is a precompile that dispatches calls to the method
+// registered for the 4-byte selector at the start of the input.
+type MethodDispatcher struct {
+	methods map[[4]byte]MethodFunc
+	static  map[[4]byte]bool
+	gas     map[[4]byte]GasFunc
+	names   map[[4]byte]string
+	// Gas charged for calls to methods without a GasFunc
+	defaultGas uint64
+	onDispatch DispatchHook
+}
+
+var (
+	_ concrete.Precompile = (*MethodDispatcher)(nil)
+	_ concrete.GasCoster  = (*MethodDispatcher)(nil)
+)
+
+func NewMethodDispatcher() *MethodDispatcher {
+	return &MethodDispatcher{
+		methods: make(map[[4]byte]MethodFunc),
+		static:  make(map[[4]byte]bool),
+		gas:     make(map[[4]byte]GasFunc),
+		names:   make(map[[4]byte]string),
+	}
+}
+
+// Register registers fn as the method for the given selector. Static methods
+// can be called from static contexts and must not modify state.
+func (d *MethodDispatcher) Register(selector [4]byte, fn MethodFunc, isStatic bool) {
+	if _, ok := d.methods[selector]; ok {
+		panic(fmt.Sprintf("method already registered for selector %x", selector))
+	}
+	d.methods[selector] = fn
+	d.static[selector] = isStatic
+}
+
+// RegisterSignature registers fn as the method for the selector of the given
+// signature and returns the selector. It panics if the signature is malformed.
+func (d *MethodDispatcher) RegisterSignature(signature string, fn MethodFunc, isStatic bool) [4]byte {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		panic(err)
+	}
+	selector := MustSelector(canonical)
+	d.Register(selector, fn, isStatic)
+	d.names[selector] = canonical
+	return selector
+}
+
+// MethodNames returns the signatures of the methods registered with
+// RegisterSignature by selector, e.g. to label metrics.
+func (d *MethodDispatcher) MethodNames() map[[4]byte]string {
+	names := make(map[[4]byte]string, len(d.names))
+	for selector, name := range d.names {
+		names[selector] = name
+	}
+	return names
+}
+
+// SetDefaultGasCost sets the fixed gas cost charged for calls to methods that
+// have no GasFunc of their own. It is zero by default.
+func (d *MethodDispatcher) SetDefaultGasCost(gas uint64) {
+	d.defaultGas = gas
+}
+
+// SetGasCost overrides the gas cost of the method registered for the given
+// selector.
+func (d *MethodDispatcher) SetGasCost(selector [4]byte, fn GasFunc) {
+	if _, ok := d.methods[selector]; !ok {
+		panic(fmt.Sprintf("no method registered for selector %x", selector))
+	}
+	d.gas[selector] = fn
+}
+
+// SetOnDispatch sets a hook called after every call, including calls failing
+// with ErrMethodNotFound, e.g. to log calls during development. It does not
+// change the results of calls. A nil hook disables it.
+func (d *MethodDispatcher) SetOnDispatch(hook DispatchHook) {
+	d.onDispatch = hook
+}
+
+func (d *MethodDispatcher) method(input []byte) ([4]byte, MethodFunc, bool) {
+	var selector [4]byte
+	if len(input) < 4 {
+		return selector, nil, false
+	}
+	copy(selector[:], input[:4])
+	fn, ok := d.methods[selector]
+	return selector, fn, ok
+}
+
+// IsStatic reports whether the method called by input is static. Calls to
+// unknown methods are considered static so they fail with ErrMethodNotFound
+// instead of a write protection error.
+func (d *MethodDispatcher) IsStatic(input []byte) bool {
+	selector, _, ok := d.method(input)
+	if !ok {
+		return true
+	}
+	return d.static[selector]
+}
+
+// GasCost returns the gas to be charged before running the method called by
+// input.
+func (d *MethodDispatcher) GasCost(input []byte) uint64 {
+	selector, _, ok := d.method(input)
+	if !ok {
+		return d.defaultGas
+	}
+	if fn, ok := d.gas[selector]; ok {
+		return fn(input[4:])
+	}
+	return d.defaultGas
+}
+
+func (d *MethodDispatcher) Run(env api.Environment, input []byte) ([]byte, error) {
+	if d.onDispatch == nil {
+		return d.run(env, input)
+	}
+	start := time.Now()
+	out, err := d.run(env, input)
+	selector, _, _ := d.method(input)
+	d.onDispatch(selector, input, out, err, time.Since(start))
+	return out, err
+}
+
+func (d *MethodDispatcher) run(env api.Environment, input []byte) ([]byte, error) {
+	_, fn, ok := d.method(input)
+	if !ok {
+		return nil, ErrMethodNotFound
+	}
+	return fn(env, input[4:])
+}
+
+func gasCost(pc concrete.Precompile, input []byte) uint64 {
+	if gc, ok := pc.(concrete.GasCoster); ok {
+		return gc.GasCost(input)
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	r := require.New(t)
+	r.Equal([4]byte{0xa9, 0x05, 0x9c, 0xbb}, Selector("transfer(address,uint256)"))
+	r.Equal([4]byte{0xa9, 0x05, 0x9c, 0xbb}, Selector("transfer(address, uint256)"))
+}
+
+func TestMethodDispatcher(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+	)
+
+	d := NewMethodDispatcher()
+	getSelector := d.RegisterSignature("get()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{0x01}, nil
+	}, true)
+	setSelector := Selector("set(uint256)")
+	d.Register(setSelector, func(env api.Environment, args []byte) ([]byte, error) {
+		return args, nil
+	}, false)
+
+	r.Panics(func() {
+		d.Register(setSelector, nil, false)
+	})
+
+	r.True(d.IsStatic(getSelector[:]))
+	r.False(d.IsStatic(setSelector[:]))
+	r.True(d.IsStatic([]byte{0x00}))
+
+	ret, err := d.Run(env, getSelector[:])
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+
+	ret, err = d.Run(env, append(setSelector[:], 0x02, 0x03))
+	r.NoError(err)
+	r.Equal([]byte{0x02, 0x03}, ret)
+
+	_, err = d.Run(env, []byte{0x00, 0x00, 0x00, 0x00})
+	r.ErrorIs(err, ErrMethodNotFound)
+	_, err = d.Run(env, []byte{0x00})
+	r.ErrorIs(err, ErrMethodNotFound)
+}
+
+func TestMethodDispatcherGasCost(t *testing.T) {
+	r := require.New(t)
+	d := NewMethodDispatcher()
+	noop := func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, nil
+	}
+	fixed := d.RegisterSignature("fixed()", noop, true)
+	perByte := d.RegisterSignature("perByte(bytes)", noop, false)
+	d.SetGasCost(perByte, func(args []byte) uint64 {
+		return 10 * uint64(len(args))
+	})
+
+	r.Equal(uint64(0), d.GasCost(fixed[:]))
+	d.SetDefaultGasCost(100)
+	r.Equal(uint64(100), d.GasCost(fixed[:]))
+	r.Equal(uint64(100), d.GasCost([]byte{0x00}))
+	r.Equal(uint64(40), d.GasCost(append(perByte[:], 0x01, 0x02, 0x03, 0x04)))
+
+	d.SetGasCost(fixed, FixedGasCost(5))
+	r.Equal(uint64(5), d.GasCost(fixed[:]))
+	r.Panics(func() {
+		d.SetGasCost(Selector("unknown()"), FixedGasCost(5))
+	})
+
+	// Guards forward the cost of the wrapped precompile
+	r.Equal(uint64(5), NewReentrancyGuard(d).GasCost(fixed[:]))
+	r.Equal(uint64(5), NewStaticGuard(d).GasCost(fixed[:]))
+}
+
+func TestMethodDispatcherOnDispatch(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+	)
+
+	type dispatch struct {
+		selector [4]byte
+		input    []byte
+		out      []byte
+		err      error
+	}
+	var dispatches []dispatch
+
+	d := NewMethodDispatcher()
+	echo := d.RegisterSignature("echo(bytes)", func(env api.Environment, args []byte) ([]byte, error) {
+		time.Sleep(time.Millisecond)
+		return args, nil
+	}, true)
+	fail := d.RegisterSignature("fail()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{0xff}, ErrInvalidArg
+	}, true)
+	d.SetOnDispatch(func(selector [4]byte, input []byte, out []byte, err error, dur time.Duration) {
+		if selector == echo {
+			r.GreaterOrEqual(dur, time.Millisecond)
+		}
+		dispatches = append(dispatches, dispatch{selector, input, out, err})
+	})
+
+	// The hook does not change the results of calls
+	ret, err := d.Run(env, append(echo[:], 0x01))
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+	ret, err = d.Run(env, fail[:])
+	r.ErrorIs(err, ErrInvalidArg)
+	r.Equal([]byte{0xff}, ret)
+	_, err = d.Run(env, []byte{0x00})
+	r.ErrorIs(err, ErrMethodNotFound)
+
+	r.Equal([]dispatch{
+		{echo, append(echo[:], 0x01), []byte{0x01}, nil},
+		{fail, fail[:], []byte{0xff}, ErrInvalidArg},
+		{[4]byte{}, []byte{0x00}, nil, ErrMethodNotFound},
+	}, dispatches)
+
+	d.SetOnDispatch(nil)
+	_, err = d.Run(env, fail[:])
+	r.ErrorIs(err, ErrInvalidArg)
+	r.Len(dispatches, 3)
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrInvalidECDSASignature = errors.New("invalid ecdsa signature")
+	ErrUnauthorizedSigner    = errors.New("unauthorized signer")
+)
+
+// EcrecoverAddress is the address of the ecrecover precompile VerifySignature
+// recovers signers with.
+var EcrecoverAddress = common.BytesToAddress([]byte{0x01})
+
+// secp256k1HalfN is half the order of the secp256k1 curve, the largest s value
+// of a signature allowed by EIP-2.
+var secp256k1HalfN = uint256.MustFromHex("0x7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0")
+
+// VerifySignature returns the address that signed hash, recovered by calling
+// the ecrecover precompile, so that it also works where the secp256k1 library
+// is not available, e.g. in wasm precompiles. The signature is either 65 bytes
+// r || s || v, with v one of 0, 1, 27 or 28, or 64 bytes r || vs in the compact
+// form of EIP-2098. Signatures with s in the upper half of the curve order are
+// rejected as EIP-2 requires, so that a signature cannot be replayed in its
+// malleated form. An error wrapping ErrInvalidECDSASignature is returned if the
+// signature is malformed or no signer can be recovered.
+func VerifySignature(env api.Environment, hash common.Hash, sig []byte) (common.Address, error) {
+	var r, s [32]byte
+	var v byte
+	switch len(sig) {
+	case 65:
+		copy(r[:], sig[:32])
+		copy(s[:], sig[32:64])
+		v = sig[64]
+		if v < 27 {
+			v += 27
+		}
+		if v != 27 && v != 28 {
+			return common.Address{}, fmt.Errorf("%w: invalid recovery id %d", ErrInvalidECDSASignature, sig[64])
+		}
+	case 64:
+		// The highest bit of vs is the parity of the recovery id
+		copy(r[:], sig[:32])
+		copy(s[:], sig[32:64])
+		v = 27 + s[0]>>7
+		s[0] &= 0x7f
+	default:
+		return common.Address{}, fmt.Errorf("%w: expected 64 or 65 bytes, got %d", ErrInvalidECDSASignature, len(sig))
+	}
+	if new(uint256.Int).SetBytes32(s[:]).Gt(secp256k1HalfN) {
+		return common.Address{}, fmt.Errorf("%w: s is in the upper half of the curve order", ErrInvalidECDSASignature)
+	}
+
+	input := make([]byte, 0, 128)
+	input = append(input, hash.Bytes()...)
+	input = append(input, common.LeftPadBytes([]byte{v}, 32)...)
+	input = append(input, r[:]...)
+	input = append(input, s[:]...)
+	output, err := env.CallStatic(EcrecoverAddress, input, env.GetGasLeft())
+	if err != nil {
+		return common.Address{}, err
+	}
+	// The precompile returns nothing for signatures it cannot recover
+	if len(output) != 32 {
+		return common.Address{}, fmt.Errorf("%w: no signer recovered", ErrInvalidECDSASignature)
+	}
+	return common.BytesToAddress(output), nil
+}
+
+// SignedInputHash returns the hash signed to call the precompile at address
+// with the given input through RequireSignature, keccak256(keccak256(domain)
+// . address . input). The domain separates the signatures of precompiles that
+// accept the same inputs, e.g. across chains or versions.
+func SignedInputHash(domain []byte, address common.Address, input []byte) common.Hash {
+	return crypto.Keccak256Hash(crypto.Keccak256(domain), address.Bytes(), input)
+}
+
+// AppendSignature returns the input of a call through RequireSignature, the
+// input of the next precompile followed by the signature and its length in a
+// single byte.
+func AppendSignature(input []byte, sig []byte) []byte {
+	signed := make([]byte, 0, len(input)+len(sig)+1)
+	signed = append(signed, input...)
+	signed = append(signed, sig...)
+	return append(signed, byte(len(sig)))
+}
+
+// splitSignature returns the input and signature of a call through
+// RequireSignature.
+func splitSignature(signed []byte) ([]byte, []byte, error) {
+	if len(signed) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing signature", ErrInvalidECDSASignature)
+	}
+	size := int(signed[len(signed)-1])
+	if size != 64 && size != 65 || len(signed) < size+1 {
+		return nil, nil, fmt.Errorf("%w: missing signature", ErrInvalidECDSASignature)
+	}
+	end := len(signed) - 1 - size
+	return signed[:end], signed[end : len(signed)-1], nil
+}
+
+// signedPrecompile checks the signature appended to its input before calling
+// the next precompile with the rest of it.
+type signedPrecompile struct {
+	next       concrete.Precompile
+	domain     []byte
+	authorized func(env api.Environment, signer common.Address) bool
+}
+
+var (
+	_ concrete.Precompile = (*signedPrecompile)(nil)
+	_ concrete.GasCoster  = (*signedPrecompile)(nil)
+)
+
+// RequireSignature returns a middleware only running calls signed by a signer
+// for which authorized returns true, e.g. an account with a role of an
+// AccessControl. Inputs are built with AppendSignature from the input of the
+// next precompile and a signature of its SignedInputHash for the given domain
+// and the address of the precompile, checked by VerifySignature. Calls without
+// a valid signature fail with an error wrapping ErrInvalidECDSASignature, and
+// calls signed by other signers with ErrUnauthorizedSigner. Signatures can be
+// replayed, the next precompile must reject inputs it already ran if needed,
+// e.g. by including a nonce in them.
+func RequireSignature(domain []byte, authorized func(env api.Environment, signer common.Address) bool) Middleware {
+	return func(next concrete.Precompile) concrete.Precompile {
+		return &signedPrecompile{next: next, domain: domain, authorized: authorized}
+	}
+}
+
+// IsStatic reports inputs without a signature as static, as they fail without
+// running the next precompile.
+func (p *signedPrecompile) IsStatic(signed []byte) bool {
+	input, _, err := splitSignature(signed)
+	if err != nil {
+		return true
+	}
+	return p.next.IsStatic(input)
+}
+
+func (p *signedPrecompile) GasCost(signed []byte) uint64 {
+	input, _, err := splitSignature(signed)
+	if err != nil {
+		return 0
+	}
+	return gasCost(p.next, input)
+}
+
+func (p *signedPrecompile) Run(env api.Environment, signed []byte) ([]byte, error) {
+	input, sig, err := splitSignature(signed)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := VerifySignature(env, SignedInputHash(p.domain, env.GetAddress(), input), sig)
+	if err != nil {
+		return nil, err
+	}
+	if !p.authorized(env, signer) {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorizedSigner, signer.Hex())
+	}
+	return p.next.Run(env, input)
+}
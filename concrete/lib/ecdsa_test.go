@@ -0,0 +1,160 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/core/vm"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// newEcrecoverEnvironment returns an environment whose static calls run the
+// precompiles of the EVM, regardless of gas as it is not metered.
+func newEcrecoverEnvironment(address common.Address) api.Environment {
+	caller := api.NewMockCaller()
+	caller.SetCallStaticFn(func(to common.Address, input []byte, gas uint64) ([]byte, uint64, error) {
+		output, err := vm.PrecompiledContractsBerlin[to].Run(input)
+		return output, gas, err
+	})
+	contract := api.NewContract(common.Address{}, common.HexToAddress("0x02"), address, new(uint256.Int))
+	return api.NewEnvironment(api.EnvConfig{}, false, mock.NewMockStateDB(), api.NewMockBlockContext(), caller, contract)
+}
+
+func TestVerifySignature(t *testing.T) {
+	r := require.New(t)
+	env := newEcrecoverEnvironment(common.HexToAddress("0xc0ffee0001"))
+	key, err := ethcrypto.GenerateKey()
+	r.NoError(err)
+	signer := ethcrypto.PubkeyToAddress(key.PublicKey)
+	hash := common.HexToHash("0x1234")
+	sig, err := ethcrypto.Sign(hash.Bytes(), key)
+	r.NoError(err)
+
+	// Recovery ids are accepted with or without the offset of 27
+	recovered, err := VerifySignature(env, hash, sig)
+	r.NoError(err)
+	r.Equal(signer, recovered)
+	offset := append(append([]byte{}, sig[:64]...), sig[64]+27)
+	recovered, err = VerifySignature(env, hash, offset)
+	r.NoError(err)
+	r.Equal(signer, recovered)
+
+	// EIP-2098 compact signatures carry the recovery id in the top bit of s
+	compact := append([]byte{}, sig[:64]...)
+	compact[32] |= sig[64] << 7
+	recovered, err = VerifySignature(env, hash, compact)
+	r.NoError(err)
+	r.Equal(signer, recovered)
+
+	// The malleated signature recovers the same signer but is rejected
+	n := ethcrypto.S256().Params().N
+	s := new(big.Int).Sub(n, new(big.Int).SetBytes(sig[32:64]))
+	malleated := append(append(append([]byte{}, sig[:32]...), common.LeftPadBytes(s.Bytes(), 32)...), 28-sig[64])
+	_, err = VerifySignature(env, hash, malleated)
+	r.ErrorIs(err, ErrInvalidECDSASignature)
+	r.ErrorContains(err, "upper half of the curve order")
+
+	recovered, err = VerifySignature(env, common.HexToHash("0x5678"), sig)
+	r.NoError(err)
+	r.NotEqual(signer, recovered)
+
+	for _, bad := range [][]byte{
+		sig[:63],
+		append(append([]byte{}, sig[:64]...), 2),
+		append(make([]byte, 64), 27),
+	} {
+		_, err = VerifySignature(env, hash, bad)
+		r.ErrorIs(err, ErrInvalidECDSASignature)
+	}
+}
+
+func TestRequireSignature(t *testing.T) {
+	var (
+		r       = require.New(t)
+		address = common.HexToAddress("0xc0ffee0001")
+		env     = newEcrecoverEnvironment(address)
+		domain  = []byte("example.v1")
+		pc      = NewMethodDispatcher()
+	)
+	key, err := ethcrypto.GenerateKey()
+	r.NoError(err)
+	other, err := ethcrypto.GenerateKey()
+	r.NoError(err)
+	signer := ethcrypto.PubkeyToAddress(key.PublicKey)
+
+	var got []byte
+	write := pc.RegisterSignature("write(uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		got = args
+		return []byte{0x01}, nil
+	}, false)
+	view := pc.RegisterSignature("view()", func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, nil
+	}, true)
+	signed := Chain(pc, RequireSignature(domain, func(env api.Environment, account common.Address) bool {
+		return account == signer
+	}))
+	input := append(write[:], common.LeftPadBytes([]byte{7}, 32)...)
+	sig, err := ethcrypto.Sign(SignedInputHash(domain, address, input).Bytes(), key)
+	r.NoError(err)
+	ret, err := signed.Run(env, AppendSignature(input, sig))
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+	r.Equal(input[4:], got)
+	r.False(signed.IsStatic(AppendSignature(input, sig)))
+	r.True(signed.IsStatic(AppendSignature(view[:], sig)))
+
+	// Compact signatures are accepted too
+	compact := append([]byte{}, sig[:64]...)
+	compact[32] |= sig[64] << 7
+	_, err = signed.Run(env, AppendSignature(input, compact))
+	r.NoError(err)
+
+	// Signatures are bound to the input, domain and precompile
+	otherSig, err := ethcrypto.Sign(SignedInputHash(domain, address, input).Bytes(), other)
+	r.NoError(err)
+	_, err = signed.Run(env, AppendSignature(input, otherSig))
+	r.ErrorIs(err, ErrUnauthorizedSigner)
+	for _, hash := range []common.Hash{
+		SignedInputHash(domain, address, write[:]),
+		SignedInputHash([]byte("example.v2"), address, input),
+		SignedInputHash(domain, common.HexToAddress("0xc0ffee0002"), input),
+	} {
+		sig, err := ethcrypto.Sign(hash.Bytes(), key)
+		r.NoError(err)
+		_, err = signed.Run(env, AppendSignature(input, sig))
+		r.ErrorIs(err, ErrUnauthorizedSigner)
+	}
+
+	// Calls without a signature fail before running the next precompile
+	middleware := RequireSignature(domain, func(api.Environment, common.Address) bool { return true })(pc)
+	for _, bad := range [][]byte{nil, input, append(append([]byte{}, input...), 65)} {
+		_, err = signed.Run(env, bad)
+		r.ErrorIs(err, ErrInvalidECDSASignature)
+		r.True(middleware.IsStatic(bad))
+	}
+}
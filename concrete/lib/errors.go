@@ -0,0 +1,36 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/concrete"
+)
+
+// RevertError returns an error that reverts with the ABI encoded solidity custom
+// error given by selector and args when returned from a precompile's Run method,
+// e.g. for `error InsufficientBalance(uint256 available, uint256 required)`:
+//
+//	return nil, lib.RevertError(lib.Selector("InsufficientBalance(uint256,uint256)"), available, required)
+//
+// Each arg must already be ABI encoded, i.e. a 32-byte word for static types.
+func RevertError(selector [4]byte, args ...[]byte) error {
+	data := make([]byte, 0, 4+32*len(args))
+	data = append(data, selector[:]...)
+	for _, arg := range args {
+		data = append(data, arg...)
+	}
+	return &concrete.RevertDataError{Data: data}
+}
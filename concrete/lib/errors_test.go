@@ -0,0 +1,63 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+const errorsABI = `[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`
+
+func TestRevertError(t *testing.T) {
+	r := require.New(t)
+
+	parsed, err := abi.JSON(strings.NewReader(errorsABI))
+	r.NoError(err)
+	abiErr := parsed.Errors["InsufficientBalance"]
+
+	selector := Selector("InsufficientBalance(uint256,uint256)")
+	r.Equal(abiErr.ID[:4], selector[:])
+
+	pc := NewMethodDispatcher()
+	pc.RegisterSignature("withdraw()", func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, RevertError(selector, common.BigToHash(big.NewInt(1)).Bytes(), common.BigToHash(big.NewInt(2)).Bytes())
+	}, true)
+
+	env, _, _, _ := api.NewMockEnvironment(api.EnvConfig{}, false)
+	input := Selector("withdraw()")
+	ret, _, err := concrete.RunPrecompile(pc, env, input[:], 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrExecutionReverted)
+
+	unpacked, err := abiErr.Unpack(ret)
+	r.NoError(err)
+	values := unpacked.([]interface{})
+	r.Equal(big.NewInt(1), values[0])
+	r.Equal(big.NewInt(2), values[1])
+}
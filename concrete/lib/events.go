@@ -0,0 +1,280 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/holiman/uint256"
+)
+
+// EmitEvent emits a log with topic0 set to the hash of the event signature,
+// e.g. "Transfer(address,address,uint256)", followed by the given topics.
+func EmitEvent(env api.Environment, signature string, topics []common.Hash, data []byte) {
+	signature = strings.ReplaceAll(signature, " ", "")
+	eventID := common.BytesToHash(crypto.Keccak256([]byte(signature)))
+	env.Log(append([]common.Hash{eventID}, topics...), data)
+}
+
+type EventArg struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// Event describes a solidity event, used to ABI encode its arguments.
+type Event struct {
+	Name string
+	Args []EventArg
+}
+
+func (e *Event) Signature() string {
+	types := make([]string, len(e.Args))
+	for ii, arg := range e.Args {
+		types[ii] = arg.Type
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// EmitTypedEvent ABI encodes values according to the event arguments and emits
+// the event. Indexed arguments are emitted as topics, where dynamic values are
+// replaced by their hash, and the rest are encoded as the log data.
+// Supported types are address, bool, (u)intN, bytesN, bytes and string.
+// Integers out of the range of their type are rejected with an error.
+func EmitTypedEvent(env api.Environment, event *Event, values ...interface{}) error {
+	if len(values) != len(event.Args) {
+		return fmt.Errorf("event %s expects %d arguments, got %d", event.Name, len(event.Args), len(values))
+	}
+	var (
+		topics  []common.Hash
+		heads   [][]byte
+		tails   [][]byte
+		dynamic []bool
+	)
+	for ii, arg := range event.Args {
+		word, isDynamic, err := encodeEventValue(arg.Type, values[ii])
+		if err != nil {
+			return fmt.Errorf("invalid argument %s of event %s: %w", arg.Name, event.Name, err)
+		}
+		if arg.Indexed {
+			if isDynamic {
+				topics = append(topics, common.BytesToHash(crypto.Keccak256(word)))
+			} else {
+				topics = append(topics, common.BytesToHash(word))
+			}
+			continue
+		}
+		if isDynamic {
+			heads = append(heads, nil)
+			tails = append(tails, encodeDynamicValue(word))
+		} else {
+			heads = append(heads, word)
+			tails = append(tails, nil)
+		}
+		dynamic = append(dynamic, isDynamic)
+	}
+	if len(topics) > 3 {
+		return fmt.Errorf("event %s has %d indexed arguments, at most 3 are allowed", event.Name, len(topics))
+	}
+
+	data := make([]byte, 0, 32*len(heads))
+	offset := 32 * len(heads)
+	for ii, head := range heads {
+		if dynamic[ii] {
+			head = common.BigToHash(big.NewInt(int64(offset))).Bytes()
+			offset += len(tails[ii])
+		}
+		data = append(data, head...)
+	}
+	for _, tail := range tails {
+		data = append(data, tail...)
+	}
+
+	EmitEvent(env, event.Signature(), topics, data)
+	return nil
+}
+
+// encodeDynamicValue returns the length prefixed and right padded encoding of a
+// dynamic value.
+func encodeDynamicValue(value []byte) []byte {
+	padded := (len(value) + 31) / 32 * 32
+	enc := make([]byte, 32+padded)
+	copy(enc, common.BigToHash(big.NewInt(int64(len(value)))).Bytes())
+	copy(enc[32:], value)
+	return enc
+}
+
+// encodeEventValue returns the 32-byte word of a static value, or the raw bytes
+// of a dynamic value.
+func encodeEventValue(typ string, value interface{}) ([]byte, bool, error) {
+	switch {
+	case typ == "address":
+		v, ok := value.(common.Address)
+		if !ok {
+			return nil, false, fmt.Errorf("expected common.Address, got %T", value)
+		}
+		return common.BytesToHash(v.Bytes()).Bytes(), false, nil
+	case typ == "bool":
+		v, ok := value.(bool)
+		if !ok {
+			return nil, false, fmt.Errorf("expected bool, got %T", value)
+		}
+		word := make([]byte, 32)
+		if v {
+			word[31] = 1
+		}
+		return word, false, nil
+	case typ == "string":
+		v, ok := value.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("expected string, got %T", value)
+		}
+		return []byte(v), true, nil
+	case typ == "bytes":
+		v, ok := value.([]byte)
+		if !ok {
+			return nil, false, fmt.Errorf("expected []byte, got %T", value)
+		}
+		return v, true, nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, false, fmt.Errorf("unsupported type %s", typ)
+		}
+		var v []byte
+		switch value := value.(type) {
+		case common.Hash:
+			v = value.Bytes()
+		case []byte:
+			v = value
+		default:
+			return nil, false, fmt.Errorf("expected common.Hash or []byte, got %T", value)
+		}
+		if len(v) > size {
+			return nil, false, fmt.Errorf("value too long for %s", typ)
+		}
+		word := make([]byte, 32)
+		copy(word, v)
+		return word, false, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+		bits := 256
+		if size := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); size != "" {
+			var err error
+			if bits, err = strconv.Atoi(size); err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+				return nil, false, fmt.Errorf("unsupported type %s", typ)
+			}
+		}
+		v, err := toUint256(value)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := checkIntRange(typ, bits, signed, isNegative(value, v, signed), v); err != nil {
+			return nil, false, err
+		}
+		word := v.Bytes32()
+		return word[:], false, nil
+	default:
+		return nil, false, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+// isNegative reports whether the integer value, converted to v by toUint256,
+// is negative. A *uint256.Int is negative if signed and its top bit is set, as
+// it holds signed values in two's complement.
+func isNegative(value interface{}, v *uint256.Int, signed bool) bool {
+	switch value := value.(type) {
+	case *uint256.Int:
+		return signed && v.Sign() < 0
+	case *big.Int:
+		return value.Sign() < 0
+	case int8, int16, int32, int64, int:
+		return v.Sign() < 0
+	}
+	return false
+}
+
+// checkIntRange returns an error if the integer v, in two's complement if
+// negative, does not fit in an integer of the given bits, as abi.Arguments.Pack
+// does.
+func checkIntRange(typ string, bits int, signed, negative bool, v *uint256.Int) error {
+	switch {
+	case !signed && negative:
+		return fmt.Errorf("negative value for %s", typ)
+	case !signed:
+		if v.BitLen() > bits {
+			return fmt.Errorf("value out of range for %s", typ)
+		}
+	case negative:
+		// -x fits in bits if x-1, i.e. ^v, fits in bits-1
+		if new(uint256.Int).Not(v).BitLen() > bits-1 {
+			return fmt.Errorf("value out of range for %s", typ)
+		}
+	case v.BitLen() > bits-1:
+		return fmt.Errorf("value out of range for %s", typ)
+	}
+	return nil
+}
+
+// toUint256 converts an integer to a uint256, with negative values in two's
+// complement.
+func toUint256(value interface{}) (*uint256.Int, error) {
+	switch v := value.(type) {
+	case *uint256.Int:
+		return v, nil
+	case *big.Int:
+		if v.Sign() < 0 {
+			abs, overflow := uint256.FromBig(new(big.Int).Neg(v))
+			if overflow {
+				return nil, fmt.Errorf("integer overflow")
+			}
+			return abs.Neg(abs), nil
+		}
+		u, overflow := uint256.FromBig(v)
+		if overflow {
+			return nil, fmt.Errorf("integer overflow")
+		}
+		return u, nil
+	case uint8:
+		return uint256.NewInt(uint64(v)), nil
+	case uint16:
+		return uint256.NewInt(uint64(v)), nil
+	case uint32:
+		return uint256.NewInt(uint64(v)), nil
+	case uint64:
+		return uint256.NewInt(v), nil
+	case uint:
+		return uint256.NewInt(uint64(v)), nil
+	case int8:
+		return toUint256(big.NewInt(int64(v)))
+	case int16:
+		return toUint256(big.NewInt(int64(v)))
+	case int32:
+		return toUint256(big.NewInt(int64(v)))
+	case int64:
+		return toUint256(big.NewInt(v))
+	case int:
+		return toUint256(big.NewInt(int64(v)))
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", value)
+	}
+}
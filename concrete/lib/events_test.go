@@ -0,0 +1,174 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+const eventsABI = `[
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Note","inputs":[{"name":"tag","type":"string","indexed":true},{"name":"delta","type":"int64","indexed":false},{"name":"text","type":"string","indexed":false},{"name":"id","type":"bytes4","indexed":false},{"name":"data","type":"bytes","indexed":false}]}
+]`
+
+func newEventsTestEnv() (api.Environment, *state.StateDB) {
+	var (
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		statedb  = mock.NewMockStateDB().(*state.StateDB)
+		env      = api.NewEnvironment(api.EnvConfig{}, false, statedb, api.NewMockBlockContext(), api.NewMockCaller(), contract)
+	)
+	return env, statedb
+}
+
+func requireABILog(r *require.Assertions, event abi.Event, log *types.Log, values ...interface{}) {
+	var (
+		indexed    [][]interface{}
+		nonIndexed []interface{}
+	)
+	for ii, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, []interface{}{values[ii]})
+		} else {
+			nonIndexed = append(nonIndexed, values[ii])
+		}
+	}
+	topics, err := abi.MakeTopics(indexed...)
+	r.NoError(err)
+	expTopics := []common.Hash{event.ID}
+	for _, topic := range topics {
+		expTopics = append(expTopics, topic[0])
+	}
+	expData, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	r.NoError(err)
+	r.Equal(expTopics, log.Topics)
+	r.Equal(expData, log.Data)
+}
+
+func TestEmitEvent(t *testing.T) {
+	r := require.New(t)
+	parsed, err := abi.JSON(strings.NewReader(eventsABI))
+	r.NoError(err)
+
+	var (
+		from  = common.HexToAddress("0x01")
+		to    = common.HexToAddress("0x02")
+		value = big.NewInt(1000)
+	)
+
+	t.Run("EmitEvent", func(t *testing.T) {
+		r := require.New(t)
+		env, statedb := newEventsTestEnv()
+		topics := []common.Hash{common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}
+		EmitEvent(env, "Transfer(address, address, uint256)", topics, common.BigToHash(value).Bytes())
+		logs := statedb.Logs()
+		r.Len(logs, 1)
+		requireABILog(r, parsed.Events["Transfer"], logs[0], from, to, value)
+	})
+
+	t.Run("EmitTypedEvent", func(t *testing.T) {
+		r := require.New(t)
+		env, statedb := newEventsTestEnv()
+		transfer := &Event{
+			Name: "Transfer",
+			Args: []EventArg{
+				{Name: "from", Type: "address", Indexed: true},
+				{Name: "to", Type: "address", Indexed: true},
+				{Name: "value", Type: "uint256"},
+			},
+		}
+		r.NoError(EmitTypedEvent(env, transfer, from, to, uint256.MustFromBig(value)))
+		note := &Event{
+			Name: "Note",
+			Args: []EventArg{
+				{Name: "tag", Type: "string", Indexed: true},
+				{Name: "delta", Type: "int64"},
+				{Name: "text", Type: "string"},
+				{Name: "id", Type: "bytes4"},
+				{Name: "data", Type: "bytes"},
+			},
+		}
+		text := strings.Repeat("concrete", 5)
+		r.NoError(EmitTypedEvent(env, note, "tag", int64(-3), text, []byte{1, 2, 3, 4}, []byte{5, 6}))
+
+		logs := statedb.Logs()
+		r.Len(logs, 2)
+		requireABILog(r, parsed.Events["Transfer"], logs[0], from, to, value)
+		requireABILog(r, parsed.Events["Note"], logs[1], "tag", int64(-3), text, [4]byte{1, 2, 3, 4}, []byte{5, 6})
+
+		r.Error(EmitTypedEvent(env, transfer, from, to))
+		r.Error(EmitTypedEvent(env, transfer, from, "to", uint64(1)))
+		r.Len(statedb.Logs(), 2)
+	})
+
+	t.Run("IntRange", func(t *testing.T) {
+		r := require.New(t)
+		minusOne := new(uint256.Int).Not(new(uint256.Int))
+		for _, tc := range []struct {
+			typ   string
+			value interface{}
+			ok    bool
+		}{
+			{"uint8", uint64(255), true},
+			{"uint8", uint64(256), false},
+			{"uint8", big.NewInt(-1), false},
+			{"uint8", int64(-1), false},
+			{"uint8", uint256.NewInt(256), false},
+			{"uint256", minusOne, true},
+			{"uint", new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1), true},
+			{"int8", int64(127), true},
+			{"int8", int64(128), false},
+			{"int8", int64(-128), true},
+			{"int8", int64(-129), false},
+			{"int8", big.NewInt(-129), false},
+			// Signed values held in a *uint256.Int are in two's complement
+			{"int8", minusOne, true},
+			{"int8", uint256.NewInt(128), false},
+			{"int256", minusOne, true},
+			{"int", new(big.Int).Lsh(common.Big1, 255), false},
+			{"uint7", uint64(1), false},
+			{"int264", uint64(1), false},
+		} {
+			_, _, err := encodeEventValue(tc.typ, tc.value)
+			if tc.ok {
+				r.NoError(err, "%s %v", tc.typ, tc.value)
+			} else {
+				r.Error(err, "%s %v", tc.typ, tc.value)
+			}
+		}
+
+		env, statedb := newEventsTestEnv()
+		small := &Event{Name: "Small", Args: []EventArg{{Name: "value", Type: "uint8"}}}
+		r.ErrorContains(EmitTypedEvent(env, small, uint64(256)), "value out of range for uint8")
+		r.Empty(statedb.Logs())
+	})
+}
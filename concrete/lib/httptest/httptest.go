@@ -0,0 +1,149 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// Package httptest exposes a precompile as an HTTP endpoint backed by an
+// in-memory environment, e.g. for black-box tests or demos without a node.
+package httptest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+)
+
+// Request is the body of a call, e.g. {"input": "0x1234"}. Calls can also be
+// made with GET requests and the input as query parameter.
+type Request struct {
+	Input hexutil.Bytes `json:"input"`
+}
+
+// Log is a log emitted by a call.
+type Log struct {
+	Topics []common.Hash `json:"topics"`
+	Data   hexutil.Bytes `json:"data"`
+}
+
+// Response is the result of a call. Successful calls have an output and the
+// logs they emitted. Failed calls have an error and the revert data, which is
+// either the ABI encoded custom error or the error message.
+type Response struct {
+	Output hexutil.Bytes `json:"output,omitempty"`
+	Logs   []Log         `json:"logs,omitempty"`
+	Error  string        `json:"error,omitempty"`
+	Revert hexutil.Bytes `json:"revert,omitempty"`
+}
+
+// Handler is an http.Handler running a precompile on every call. Calls run one
+// at a time against the same in-memory storage. The writes of a call are only
+// applied if it succeeds, and transient storage is cleared after every call.
+// Calls to other contracts are not supported.
+type Handler struct {
+	mu  sync.Mutex
+	pc  concrete.Precompile
+	env api.Environment
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler running pc at address.
+func NewHandler(pc concrete.Precompile, address common.Address) *Handler {
+	contract := api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+	return &Handler{
+		pc:  pc,
+		env: mock.NewMockEnvironment(api.EnvConfig{}, false, contract),
+	}
+}
+
+// Environment returns the environment holding the storage of the precompile,
+// e.g. to set up or inspect state in tests. It must not be used concurrently
+// with calls.
+func (h *Handler) Environment() api.Environment {
+	return h.env
+}
+
+// Call runs the precompile with input as if it was called over HTTP.
+func (h *Handler) Call(input []byte) Response {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	simEnv := lib.NewSimulatedEnvironment(h.env)
+	output, err := run(h.pc, simEnv, input)
+	if err != nil {
+		resp := Response{Error: err.Error(), Revert: []byte(err.Error())}
+		var dataErr *concrete.RevertDataError
+		if errors.As(err, &dataErr) {
+			resp.Revert = dataErr.Data
+		}
+		return resp
+	}
+	for _, change := range simEnv.Changes() {
+		if !change.Transient {
+			h.env.StorageStore(change.Key, change.Value)
+		}
+	}
+	resp := Response{Output: output}
+	for _, log := range simEnv.Logs() {
+		resp.Logs = append(resp.Logs, Log{Topics: log.Topics, Data: log.Data})
+	}
+	return resp
+}
+
+// run runs the precompile, turning panics into errors as RunPrecompile does.
+func run(pc concrete.Precompile, env api.Environment, input []byte) (output []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("runtime panic: %v", r)
+			}
+		}
+	}()
+	return pc.Run(env, input)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		if err := req.Input.UnmarshalText([]byte(r.URL.Query().Get("input"))); err != nil {
+			http.Error(w, fmt.Sprintf("invalid input: %v", err), http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Call(req.Input))
+}
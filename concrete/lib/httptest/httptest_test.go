@@ -0,0 +1,132 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package httptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	nethttptest "net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/lib"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	counterKey   = common.Hash{1}
+	counterTopic = common.Hash{2}
+	errTooLarge  = errors.New("too large")
+)
+
+// newCounter returns a precompile adding its input to a counter in storage and
+// logging the new value. Values over 0xff revert.
+func newCounter() *lib.MethodDispatcher {
+	dispatcher := lib.NewMethodDispatcher()
+	dispatcher.RegisterSignature("add(uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		value := new(big.Int).Add(env.StorageLoad(counterKey).Big(), new(big.Int).SetBytes(args))
+		env.StorageStore(counterKey, common.BigToHash(value))
+		if value.Cmp(big.NewInt(0xff)) > 0 {
+			return nil, errTooLarge
+		}
+		env.Log([]common.Hash{counterTopic}, common.BigToHash(value).Bytes())
+		return common.BigToHash(value).Bytes(), nil
+	}, false)
+	dispatcher.RegisterSignature("fail()", func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, lib.RevertError(lib.Selector("Failed()"))
+	}, true)
+	dispatcher.RegisterSignature("crash()", func(env api.Environment, args []byte) ([]byte, error) {
+		panic("crash")
+	}, true)
+	return dispatcher
+}
+
+func post(t *testing.T, url string, input []byte) Response {
+	body, err := json.Marshal(Request{Input: input})
+	require.NoError(t, err)
+	httpResp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	require.Equal(t, http.StatusOK, httpResp.StatusCode)
+	var resp Response
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&resp))
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	r := require.New(t)
+	handler := NewHandler(newCounter(), common.HexToAddress("0xc0ffee"))
+	server := nethttptest.NewServer(handler)
+	defer server.Close()
+
+	add := lib.Selector("add(uint256)")
+	input := append(add[:], common.BigToHash(big.NewInt(0x80)).Bytes()...)
+
+	resp := post(t, server.URL, input)
+	r.Empty(resp.Error)
+	r.Equal(common.BigToHash(big.NewInt(0x80)).Bytes(), []byte(resp.Output))
+	r.Equal([]Log{{Topics: []common.Hash{counterTopic}, Data: common.BigToHash(big.NewInt(0x80)).Bytes()}}, resp.Logs)
+
+	// Writes of reverted calls are discarded
+	resp = post(t, server.URL, input)
+	r.Equal(errTooLarge.Error(), resp.Error)
+	r.Equal([]byte(errTooLarge.Error()), []byte(resp.Revert))
+	r.Empty(resp.Logs)
+	r.Equal(common.BigToHash(big.NewInt(0x80)), handler.Environment().StorageLoad(counterKey))
+
+	fail := lib.Selector("fail()")
+	failed := lib.Selector("Failed()")
+	resp = post(t, server.URL, fail[:])
+	r.Equal(failed[:], []byte(resp.Revert))
+
+	crash := lib.Selector("crash()")
+	resp = post(t, server.URL, crash[:])
+	r.Equal("runtime panic: crash", resp.Error)
+
+	resp = post(t, server.URL, []byte{1, 2, 3, 4})
+	r.Equal(lib.ErrMethodNotFound.Error(), resp.Error)
+
+	// GET requests take the input as query parameter
+	one := hexutil.Encode(append(add[:], common.BigToHash(big.NewInt(1)).Bytes()...))
+	httpResp, err := http.Get(server.URL + "?input=" + one)
+	r.NoError(err)
+	defer httpResp.Body.Close()
+	r.Equal(http.StatusOK, httpResp.StatusCode)
+	r.NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	r.Equal(common.BigToHash(big.NewInt(0x81)).Bytes(), []byte(resp.Output))
+
+	httpResp, err = http.Get(server.URL + "?input=xyz")
+	r.NoError(err)
+	r.Equal(http.StatusBadRequest, httpResp.StatusCode)
+	httpResp, err = http.Post(server.URL, "application/json", bytes.NewReader([]byte(`{"input": 1}`)))
+	r.NoError(err)
+	r.Equal(http.StatusBadRequest, httpResp.StatusCode)
+	req, err := http.NewRequest(http.MethodPut, server.URL, nil)
+	r.NoError(err)
+	httpResp, err = http.DefaultClient.Do(req)
+	r.NoError(err)
+	r.Equal(http.StatusMethodNotAllowed, httpResp.StatusCode)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/concrete"
+)
+
+// Immutables holds the immutable arguments of a precompile, fixed when it is
+// created like the immutables of a solidity contract, e.g. configuration that
+// rarely changes and would otherwise be read from storage on every call. They
+// are a sequence of ABI words as written by ArgWriter.
+type Immutables struct {
+	data []byte
+}
+
+func NewImmutables(data []byte) Immutables {
+	return Immutables{data: append([]byte(nil), data...)}
+}
+
+// Bytes returns a copy of the encoded arguments.
+func (i Immutables) Bytes() []byte {
+	return append([]byte(nil), i.data...)
+}
+
+// Reader returns a reader over the arguments from the first one. Every reader
+// is independent, so the arguments can be read again on every call.
+func (i Immutables) Reader() *ArgReader {
+	return NewArgReader(i.data)
+}
+
+// ImmutablesFactory returns a factory creating precompiles with the given
+// immutable arguments, so that each deployment of a precompile, e.g. at a
+// different address or on a different chain, can be configured without
+// storage. Constructors usually decode the arguments once with a Reader and
+// keep the decoded values.
+func ImmutablesFactory(args []byte, newPrecompile func(params concrete.ChainParams, immutables Immutables) concrete.Precompile) concrete.PrecompileFactory {
+	immutables := NewImmutables(args)
+	return concrete.PrecompileFactoryFunc(func(params concrete.ChainParams) concrete.Precompile {
+		return newPrecompile(params, immutables)
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmutables(t *testing.T) {
+	r := require.New(t)
+	args := NewArgWriter().WriteAddress(common.HexToAddress("0xc0ffee")).WriteUint64(30).Bytes()
+	immutables := NewImmutables(args)
+	args[0] = 0xff
+	r.Equal(32*2, len(immutables.Bytes()))
+
+	// Readers are independent
+	for ii := 0; ii < 2; ii++ {
+		reader := immutables.Reader()
+		owner, err := reader.ReadAddress()
+		r.NoError(err)
+		r.Equal(common.HexToAddress("0xc0ffee"), owner)
+		fee, err := reader.ReadUint64()
+		r.NoError(err)
+		r.Equal(uint64(30), fee)
+		r.Equal(0, reader.Remaining())
+	}
+}
+
+func TestImmutablesFactory(t *testing.T) {
+	r := require.New(t)
+	newPrecompile := func(params concrete.ChainParams, immutables Immutables) concrete.Precompile {
+		fee, err := immutables.Reader().ReadUint64()
+		r.NoError(err)
+		pc := NewMethodDispatcher()
+		pc.RegisterSignature("fee()", func(env api.Environment, args []byte) ([]byte, error) {
+			return NewArgWriter().WriteUint64(fee + params.ChainID.Uint64()).Bytes(), nil
+		}, true)
+		return pc
+	}
+	params := concrete.ChainParams{ChainID: big.NewInt(1)}
+	precompiles := concrete.NewPrecompiles(params, map[common.Address]concrete.PrecompileFactory{
+		common.HexToAddress("0x01"): ImmutablesFactory(NewArgWriter().WriteUint64(10).Bytes(), newPrecompile),
+		common.HexToAddress("0x02"): ImmutablesFactory(NewArgWriter().WriteUint64(20).Bytes(), newPrecompile),
+	})
+	fee := Selector("fee()")
+	for address, expected := range map[common.Address]uint64{common.HexToAddress("0x01"): 11, common.HexToAddress("0x02"): 21} {
+		ret, err := precompiles[address].Run(nil, fee[:])
+		r.NoError(err)
+		value, err := NewArgReader(ret).ReadUint64()
+		r.NoError(err)
+		r.Equal(expected, value)
+	}
+}
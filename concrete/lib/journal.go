@@ -0,0 +1,115 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+type journalEntry struct {
+	transient bool
+	key       common.Hash
+	previous  common.Hash
+}
+
+// JournaledEnvironment wraps an environment and journals the storage and
+// transient storage writes made through it, so that they can be undone back
+// to a snapshot in the same way as EVM snapshots. Writes are applied to the
+// wrapped environment right away. While a snapshot is open the previous value
+// of a slot is loaded before every write, which is charged as a read. Logs
+// and the effects of calls to other contracts are not journaled and cannot be
+// undone.
+type JournaledEnvironment struct {
+	api.Environment
+	journal   []journalEntry
+	snapshots []int
+}
+
+var _ api.Environment = (*JournaledEnvironment)(nil)
+
+func NewJournaledEnvironment(env api.Environment) *JournaledEnvironment {
+	return &JournaledEnvironment{Environment: env}
+}
+
+// Snapshot returns the id of a snapshot of the current state that writes can
+// be undone to with RevertToSnapshot.
+func (e *JournaledEnvironment) Snapshot() int {
+	e.snapshots = append(e.snapshots, len(e.journal))
+	return len(e.snapshots) - 1
+}
+
+// RevertToSnapshot undoes all the writes made since the snapshot was taken,
+// latest first. The snapshot and all the ones taken after it are discarded,
+// while earlier ones remain valid. It panics for unknown or discarded ids.
+func (e *JournaledEnvironment) RevertToSnapshot(id int) {
+	if id < 0 || id >= len(e.snapshots) {
+		panic("invalid snapshot id")
+	}
+	mark := e.snapshots[id]
+	for ii := len(e.journal) - 1; ii >= mark; ii-- {
+		entry := e.journal[ii]
+		if entry.transient {
+			e.Environment.TransientStore(entry.key, entry.previous)
+		} else {
+			e.Environment.StorageStore(entry.key, entry.previous)
+		}
+	}
+	e.journal = e.journal[:mark]
+	e.snapshots = e.snapshots[:id]
+}
+
+// Try runs fn and undoes its writes if it returns an error, which is returned.
+// Its snapshot is discarded either way, so that writes are no longer journaled
+// once no snapshot is left.
+func (e *JournaledEnvironment) Try(fn func() error) error {
+	id := e.Snapshot()
+	if err := fn(); err != nil {
+		e.RevertToSnapshot(id)
+		return err
+	}
+	e.snapshots = e.snapshots[:id]
+	if len(e.snapshots) == 0 {
+		e.journal = e.journal[:0]
+	}
+	return nil
+}
+
+func (e *JournaledEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	if len(e.snapshots) > 0 {
+		e.journal = append(e.journal, journalEntry{key: key, previous: e.Environment.StorageLoad(key)})
+	}
+	e.Environment.StorageStore(key, value)
+}
+
+func (e *JournaledEnvironment) TransientStore(key common.Hash, value common.Hash) {
+	if len(e.snapshots) > 0 {
+		e.journal = append(e.journal, journalEntry{transient: true, key: key, previous: e.Environment.TransientLoad(key)})
+	}
+	e.Environment.TransientStore(key, value)
+}
+
+func (e *JournaledEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	switch op {
+	case api.StorageStore_OpCode:
+		e.StorageStore(common.BytesToHash(args[0]), common.BytesToHash(args[1]))
+		return nil
+	case api.TransientStore_OpCode:
+		e.TransientStore(common.BytesToHash(args[0]), common.BytesToHash(args[1]))
+		return nil
+	}
+	return e.Environment.Execute(op, args)
+}
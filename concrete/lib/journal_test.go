@@ -0,0 +1,143 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournaledEnvironment(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot1    = common.HexToHash("0x01")
+		slot2    = common.HexToHash("0x02")
+		value1   = common.HexToHash("0x04")
+		value2   = common.HexToHash("0x05")
+		value3   = common.HexToHash("0x06")
+	)
+
+	jEnv := NewJournaledEnvironment(env)
+	jEnv.StorageStore(slot1, value1)
+
+	outer := jEnv.Snapshot()
+	jEnv.StorageStore(slot1, value2)
+	jEnv.TransientStore(slot1, value2)
+
+	inner := jEnv.Snapshot()
+	jEnv.Execute(api.StorageStore_OpCode, [][]byte{slot2.Bytes(), value3.Bytes()})
+	jEnv.Execute(api.TransientStore_OpCode, [][]byte{slot1.Bytes(), value3.Bytes()})
+	jEnv.StorageStore(slot1, value3)
+	r.Equal(value3, env.StorageLoad(slot1))
+	r.Equal(value3, env.StorageLoad(slot2))
+
+	// Reverting the inner snapshot keeps the writes made before it
+	jEnv.RevertToSnapshot(inner)
+	r.Equal(value2, env.StorageLoad(slot1))
+	r.Equal(common.Hash{}, env.StorageLoad(slot2))
+	r.Equal(value2, env.TransientLoad(slot1))
+	r.Panics(func() { jEnv.RevertToSnapshot(inner) })
+
+	// The outer snapshot is still valid
+	jEnv.RevertToSnapshot(outer)
+	r.Equal(value1, env.StorageLoad(slot1))
+	r.Equal(common.Hash{}, env.TransientLoad(slot1))
+	r.Panics(func() { jEnv.RevertToSnapshot(outer) })
+
+	// Reverting an outer snapshot discards the inner ones
+	outer = jEnv.Snapshot()
+	inner = jEnv.Snapshot()
+	jEnv.StorageStore(slot2, value1)
+	jEnv.RevertToSnapshot(outer)
+	r.Equal(common.Hash{}, env.StorageLoad(slot2))
+	r.Panics(func() { jEnv.RevertToSnapshot(inner) })
+}
+
+func TestJournaledEnvironmentTry(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		jEnv     = NewJournaledEnvironment(env)
+		counter  = NewDatastore(jEnv).Get([]byte("counter"))
+		errFail  = errors.New("fail")
+	)
+
+	r.NoError(jEnv.Try(func() error {
+		counter.SetUint64(1)
+		return nil
+	}))
+	r.ErrorIs(jEnv.Try(func() error {
+		counter.SetUint64(2)
+		// Nested attempts roll back independently
+		r.NoError(jEnv.Try(func() error {
+			counter.SetUint64(3)
+			return nil
+		}))
+		return errFail
+	}), errFail)
+	r.Equal(uint64(1), counter.Uint64())
+}
+
+// loadCountingEnvironment counts the storage loads of the wrapped environment.
+type loadCountingEnvironment struct {
+	api.Environment
+	loads int
+}
+
+func (e *loadCountingEnvironment) StorageLoad(key common.Hash) common.Hash {
+	e.loads++
+	return e.Environment.StorageLoad(key)
+}
+
+func TestJournaledEnvironmentTryDiscardsSnapshot(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = &loadCountingEnvironment{Environment: mock.NewMockEnvironment(api.EnvConfig{}, false, contract)}
+		jEnv     = NewJournaledEnvironment(env)
+		slot     = common.HexToHash("0x01")
+	)
+
+	r.NoError(jEnv.Try(func() error {
+		jEnv.StorageStore(slot, common.HexToHash("0x02"))
+		return nil
+	}))
+	r.Empty(jEnv.snapshots)
+	r.Empty(jEnv.journal)
+
+	// Writes made once all attempts returned are not journaled
+	loads := env.loads
+	jEnv.StorageStore(slot, common.HexToHash("0x03"))
+	r.Equal(loads, env.loads)
+	r.Empty(jEnv.journal)
+}
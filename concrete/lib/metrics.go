@@ -0,0 +1,103 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+var ErrPrecompilePanic = errors.New("precompile panicked")
+
+// Method labels of calls that do not name a known method.
+const (
+	FallbackMethodLabel = "fallback"
+	UnknownMethodLabel  = "unknown"
+)
+
+// MetricsCollector receives a measurement for every call observed by the
+// Metrics middleware. It is meant to be adapted to a metrics library such as
+// Prometheus, e.g. as a counter of calls and errors and a histogram of
+// durations labeled by method.
+type MetricsCollector interface {
+	ObserveCall(method string, duration time.Duration, err error)
+}
+
+// metricsPrecompile reports the calls to the next precompile to a collector.
+type metricsPrecompile struct {
+	next      concrete.Precompile
+	collector MetricsCollector
+	names     map[[4]byte]string
+}
+
+var (
+	_ concrete.Precompile = (*metricsPrecompile)(nil)
+	_ concrete.GasCoster  = (*metricsPrecompile)(nil)
+)
+
+// Metrics returns a middleware reporting the method, duration and error of
+// every call to collector. Calls are labeled by the name of their selector in
+// names, e.g. the MethodNames of a MethodDispatcher, and by UnknownMethodLabel
+// for other selectors. If names is nil, calls are labeled by their hex encoded
+// selector instead. Inputs shorter than a selector are labeled by
+// FallbackMethodLabel. Panics are reported as ErrPrecompilePanic and rethrown.
+func Metrics(collector MetricsCollector, names map[[4]byte]string) Middleware {
+	return func(next concrete.Precompile) concrete.Precompile {
+		return &metricsPrecompile{next: next, collector: collector, names: names}
+	}
+}
+
+func (m *metricsPrecompile) method(input []byte) string {
+	if len(input) < 4 {
+		return FallbackMethodLabel
+	}
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	if m.names == nil {
+		return fmt.Sprintf("%#x", selector)
+	}
+	if name, ok := m.names[selector]; ok {
+		return name
+	}
+	return UnknownMethodLabel
+}
+
+func (m *metricsPrecompile) IsStatic(input []byte) bool {
+	return m.next.IsStatic(input)
+}
+
+func (m *metricsPrecompile) GasCost(input []byte) uint64 {
+	return gasCost(m.next, input)
+}
+
+func (m *metricsPrecompile) Run(env api.Environment, input []byte) (ret []byte, err error) {
+	var (
+		method = m.method(input)
+		start  = time.Now()
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			m.collector.ObserveCall(method, time.Since(start), fmt.Errorf("%w: %v", ErrPrecompilePanic, r))
+			panic(r)
+		}
+		m.collector.ObserveCall(method, time.Since(start), err)
+	}()
+	return m.next.Run(env, input)
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+type testCall struct {
+	method string
+	err    error
+}
+
+type testCollector struct {
+	calls []testCall
+}
+
+func (c *testCollector) ObserveCall(method string, duration time.Duration, err error) {
+	c.calls = append(c.calls, testCall{method, err})
+}
+
+func TestMetrics(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		errFail  = errors.New("fail")
+	)
+
+	dispatcher := NewMethodDispatcher()
+	get := dispatcher.RegisterSignature("get()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{1}, nil
+	}, true)
+	fail := dispatcher.RegisterSignature("fail(uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, errFail
+	}, false)
+	crash := dispatcher.RegisterSignature("crash()", func(env api.Environment, args []byte) ([]byte, error) {
+		panic("crash")
+	}, false)
+	dispatcher.SetGasCost(get, FixedGasCost(7))
+
+	collector := &testCollector{}
+	pc := Chain(dispatcher, Metrics(collector, dispatcher.MethodNames()))
+	r.True(pc.IsStatic(get[:]))
+	r.False(pc.IsStatic(fail[:]))
+	r.Equal(uint64(7), gasCost(pc, get[:]))
+
+	ret, err := pc.Run(env, get[:])
+	r.NoError(err)
+	r.Equal([]byte{1}, ret)
+	_, err = pc.Run(env, fail[:])
+	r.ErrorIs(err, errFail)
+	_, err = pc.Run(env, []byte{0xde, 0xad, 0xbe, 0xef})
+	r.ErrorIs(err, ErrMethodNotFound)
+	_, err = pc.Run(env, nil)
+	r.ErrorIs(err, ErrMethodNotFound)
+	r.PanicsWithValue("crash", func() { pc.Run(env, crash[:]) })
+
+	r.Len(collector.calls, 5)
+	r.Equal(testCall{"get()", nil}, collector.calls[0])
+	r.Equal(testCall{"fail(uint256)", errFail}, collector.calls[1])
+	r.Equal(testCall{UnknownMethodLabel, ErrMethodNotFound}, collector.calls[2])
+	r.Equal(testCall{FallbackMethodLabel, ErrMethodNotFound}, collector.calls[3])
+	r.Equal("crash()", collector.calls[4].method)
+	r.ErrorIs(collector.calls[4].err, ErrPrecompilePanic)
+
+	// Without names calls are labeled by selector
+	collector = &testCollector{}
+	pc = Chain(dispatcher, Metrics(collector, nil))
+	pc.Run(env, []byte{0xde, 0xad, 0xbe, 0xef, 0x00})
+	r.Equal([]testCall{{"0xdeadbeef", ErrMethodNotFound}}, collector.calls)
+}
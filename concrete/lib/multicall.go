@@ -0,0 +1,246 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/utils"
+	"github.com/holiman/uint256"
+)
+
+// MulticallSelector is the selector of multicall(bytes[]), which batches calls
+// to the methods of the dispatcher wrapped by a Multicall.
+var MulticallSelector = MustSelector("multicall(bytes[])")
+
+// Multicall is a precompile that runs several calls to the methods of a
+// MethodDispatcher in a single call, in the same way as the Multicall3
+// contract. Calls to multicall(bytes[] calls) run each call, given as its
+// calldata, in order. Other inputs are dispatched directly.
+//
+// Unless failures are allowed, the batch fails with the error of the first
+// failing call and returns `bytes[] results` otherwise. If they are allowed,
+// the writes of failing calls are undone as with a JournaledEnvironment, their
+// logs are dropped and the batch returns `(bool[] success, bytes[] results)`,
+// the result of a failed call being its revert data, or its error message if it
+// has none. As calls to other contracts and creates cannot be undone, they fail
+// with ErrMulticallCall in batches allowing failures.
+type Multicall struct {
+	dispatcher   *MethodDispatcher
+	allowFailure bool
+}
+
+var (
+	_ concrete.Precompile = (*Multicall)(nil)
+	_ concrete.GasCoster  = (*Multicall)(nil)
+)
+
+func NewMulticall(dispatcher *MethodDispatcher, allowFailure bool) *Multicall {
+	return &Multicall{dispatcher: dispatcher, allowFailure: allowFailure}
+}
+
+// calls decodes the calls of a batch, returning false if input is not a call
+// to multicall(bytes[]).
+func (m *Multicall) calls(input []byte) ([][]byte, bool, error) {
+	if !bytes.HasPrefix(input, MulticallSelector[:]) {
+		return nil, false, nil
+	}
+	calls, err := decodeBytesArray(input[4:])
+	return calls, true, err
+}
+
+// IsStatic reports whether all the calls of a batch are static. Malformed
+// batches are considered static so they fail when run.
+func (m *Multicall) IsStatic(input []byte) bool {
+	calls, ok, err := m.calls(input)
+	if !ok {
+		return m.dispatcher.IsStatic(input)
+	}
+	if err != nil {
+		return true
+	}
+	for _, call := range calls {
+		if !m.dispatcher.IsStatic(call) {
+			return false
+		}
+	}
+	return true
+}
+
+// GasCost returns the sum of the gas costs of the calls of a batch.
+func (m *Multicall) GasCost(input []byte) uint64 {
+	calls, ok, err := m.calls(input)
+	if !ok {
+		return m.dispatcher.GasCost(input)
+	}
+	if err != nil {
+		return 0
+	}
+	var gas uint64
+	for _, call := range calls {
+		cost := m.dispatcher.GasCost(call)
+		if gas > math.MaxUint64-cost {
+			return math.MaxUint64
+		}
+		gas += cost
+	}
+	return gas
+}
+
+func (m *Multicall) Run(env api.Environment, input []byte) ([]byte, error) {
+	calls, ok, err := m.calls(input)
+	if !ok {
+		return m.dispatcher.Run(env, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(calls))
+	if !m.allowFailure {
+		for ii, call := range calls {
+			if results[ii], err = m.dispatcher.Run(env, call); err != nil {
+				return nil, err
+			}
+		}
+		return EncodeReturn([]string{"bytes[]"}, results)
+	}
+
+	journal := NewJournaledEnvironment(env)
+	success := make([]bool, len(calls))
+	for ii, call := range calls {
+		callEnv := &multicallEnvironment{JournaledEnvironment: journal}
+		err := journal.Try(func() error {
+			var err error
+			results[ii], err = m.dispatcher.Run(callEnv, call)
+			return err
+		})
+		if err != nil {
+			results[ii] = multicallRevertData(err)
+		} else {
+			callEnv.emitLogs()
+			success[ii] = true
+		}
+	}
+	return EncodeReturn([]string{"bool[]", "bytes[]"}, success, results)
+}
+
+var ErrMulticallCall = errors.New("call or create in multicall allowing failures")
+
+// multicallEnvironment runs a call of a batch allowing failures. Its writes are
+// journaled and its logs are kept in memory until the call succeeds, so both
+// are dropped if it fails. Calls and creates fail with ErrMulticallCall.
+type multicallEnvironment struct {
+	*JournaledEnvironment
+	logs []SimulatedLog
+}
+
+var _ api.Environment = (*multicallEnvironment)(nil)
+
+// emitLogs emits the logs kept so far to the wrapped environment.
+func (e *multicallEnvironment) emitLogs() {
+	for _, log := range e.logs {
+		e.JournaledEnvironment.Log(log.Topics, log.Data)
+	}
+	e.logs = nil
+}
+
+func (e *multicallEnvironment) Log(topics []common.Hash, data []byte) {
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	e.logs = append(e.logs, SimulatedLog{Topics: topics, Data: dataCopy})
+}
+
+func (e *multicallEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	switch op {
+	case api.Log_OpCode:
+		topics := make([]common.Hash, len(args)-1)
+		for ii, arg := range args[:len(topics)] {
+			topics[ii] = common.BytesToHash(arg)
+		}
+		e.Log(topics, args[len(args)-1])
+		return nil
+	case api.Call_OpCode, api.CallDelegate_OpCode:
+		return [][]byte{nil, utils.EncodeError(ErrMulticallCall)}
+	case api.Create_OpCode, api.Create2_OpCode:
+		return [][]byte{nil, nil, utils.EncodeError(ErrMulticallCall)}
+	}
+	return e.JournaledEnvironment.Execute(op, args)
+}
+
+func (e *multicallEnvironment) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
+	return nil, ErrMulticallCall
+}
+
+func (e *multicallEnvironment) CallDelegate(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	return nil, ErrMulticallCall
+}
+
+func (e *multicallEnvironment) Create(data []byte, value *uint256.Int) ([]byte, common.Address, error) {
+	return nil, common.Address{}, ErrMulticallCall
+}
+
+func (e *multicallEnvironment) Create2(data []byte, endowment *uint256.Int, salt *uint256.Int) ([]byte, common.Address, error) {
+	return nil, common.Address{}, ErrMulticallCall
+}
+
+func multicallRevertData(err error) []byte {
+	var dataErr *concrete.RevertDataError
+	if errors.As(err, &dataErr) {
+		return dataErr.Data
+	}
+	return []byte(err.Error())
+}
+
+// decodeBytesArray decodes the ABI encoding of a bytes[] argument.
+func decodeBytesArray(args []byte) ([][]byte, error) {
+	offset, err := NewArgReader(args).ReadUint64()
+	if err != nil {
+		return nil, err
+	}
+	if offset > uint64(len(args)) {
+		return nil, ErrArgUnderflow
+	}
+	array := args[offset:]
+	reader := NewArgReader(array)
+	length, err := reader.ReadUint64()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(reader.Remaining()/32) {
+		return nil, ErrArgUnderflow
+	}
+	heads := array[32:]
+	items := make([][]byte, length)
+	for ii := range items {
+		offset, err := reader.ReadUint64()
+		if err != nil {
+			return nil, err
+		}
+		if offset > uint64(len(heads)) {
+			return nil, ErrArgUnderflow
+		}
+		if items[ii], err = NewArgReader(heads[offset:]).ReadBytes(); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
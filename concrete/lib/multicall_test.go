@@ -0,0 +1,190 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func unpackReturn(t *testing.T, types []string, data []byte) []interface{} {
+	var args abi.Arguments
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	values, err := args.Unpack(data)
+	require.NoError(t, err)
+	return values
+}
+
+func TestMulticall(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		failed   = Selector("Failed()")
+	)
+	d := NewMethodDispatcher()
+	set := d.RegisterSignature("set(uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		value, err := NewArgReader(args).ReadHash()
+		if err != nil {
+			return nil, err
+		}
+		env.StorageStore(slot, value)
+		return nil, nil
+	}, false)
+	get := d.RegisterSignature("get()", func(env api.Environment, args []byte) ([]byte, error) {
+		return env.StorageLoad(slot).Bytes(), nil
+	}, true)
+	fail := d.RegisterSignature("fail()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, common.HexToHash("0xff"))
+		return nil, RevertError(failed)
+	}, false)
+	d.SetGasCost(set, FixedGasCost(100))
+	d.SetGasCost(get, FixedGasCost(10))
+
+	setInput := func(value int64) []byte {
+		return append(set[:], common.BigToHash(big.NewInt(value)).Bytes()...)
+	}
+	batch := func(calls ...[]byte) []byte {
+		return append(MulticallSelector[:], packReturn(t, []string{"bytes[]"}, calls)...)
+	}
+
+	m := NewMulticall(d, false)
+	input := batch(setInput(5), get[:])
+	r.False(m.IsStatic(input))
+	r.True(m.IsStatic(batch(get[:], get[:])))
+	r.Equal(uint64(110), m.GasCost(input))
+	ret, err := m.Run(env, input)
+	r.NoError(err)
+	r.Equal([]interface{}{[][]byte{{}, common.BigToHash(big.NewInt(5)).Bytes()}}, unpackReturn(t, []string{"bytes[]"}, ret))
+
+	// The batch fails with the error of the first failing call
+	_, err = m.Run(env, batch(get[:], fail[:], setInput(6)))
+	var dataErr *concrete.RevertDataError
+	r.ErrorAs(err, &dataErr)
+	r.Equal(failed[:], dataErr.Data)
+	_, err = m.Run(env, batch([]byte{0x01}))
+	r.ErrorIs(err, ErrMethodNotFound)
+
+	// Other inputs are dispatched directly
+	r.True(m.IsStatic(get[:]))
+	r.Equal(uint64(10), m.GasCost(get[:]))
+	ret, err = m.Run(env, get[:])
+	r.NoError(err)
+	r.Equal(env.StorageLoad(slot).Bytes(), ret)
+
+	// Malformed batches fail without running any call
+	malformed := append(MulticallSelector[:], common.BigToHash(big.NewInt(1000)).Bytes()...)
+	r.True(m.IsStatic(malformed))
+	r.Zero(m.GasCost(malformed))
+	_, err = m.Run(env, malformed)
+	r.ErrorIs(err, ErrArgUnderflow)
+}
+
+func TestMulticallAllowFailure(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		failed   = Selector("Failed()")
+	)
+	d := NewMethodDispatcher()
+	set := d.RegisterSignature("set(uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		value, err := NewArgReader(args).ReadHash()
+		if err != nil {
+			return nil, err
+		}
+		env.StorageStore(slot, value)
+		return nil, nil
+	}, false)
+	get := d.RegisterSignature("get()", func(env api.Environment, args []byte) ([]byte, error) {
+		return env.StorageLoad(slot).Bytes(), nil
+	}, true)
+	fail := d.RegisterSignature("fail()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, common.HexToHash("0xff"))
+		return nil, RevertError(failed)
+	}, false)
+
+	m := NewMulticall(d, true)
+	calls := [][]byte{
+		append(set[:], common.BigToHash(big.NewInt(7)).Bytes()...),
+		fail[:],
+		get[:],
+		{0x01, 0x02, 0x03, 0x04},
+	}
+	ret, err := m.Run(env, append(MulticallSelector[:], packReturn(t, []string{"bytes[]"}, calls)...))
+	r.NoError(err)
+	values := unpackReturn(t, []string{"bool[]", "bytes[]"}, ret)
+	r.Equal([]bool{true, false, true, false}, values[0])
+	// The write of the failed call is undone before the next one runs
+	r.Equal([][]byte{{}, failed[:], common.BigToHash(big.NewInt(7)).Bytes(), []byte(ErrMethodNotFound.Error())}, values[1])
+	r.Equal(common.BigToHash(big.NewInt(7)), env.StorageLoad(slot))
+}
+
+func TestMulticallAllowFailureEffects(t *testing.T) {
+	var (
+		r       = require.New(t)
+		env, db = newEventsTestEnv()
+		topic   = common.HexToHash("0x01")
+		failed  = Selector("Failed()")
+	)
+	d := NewMethodDispatcher()
+	note := d.RegisterSignature("note()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.Log([]common.Hash{topic}, []byte{0x01})
+		return nil, nil
+	}, false)
+	transfer := d.RegisterSignature("transfer()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.Log([]common.Hash{topic}, []byte{0x02})
+		return nil, RevertError(failed)
+	}, false)
+	call := d.RegisterSignature("call()", func(env api.Environment, args []byte) ([]byte, error) {
+		return env.Call(common.HexToAddress("0x02"), nil, env.GetGasLeft(), new(uint256.Int))
+	}, false)
+
+	m := NewMulticall(d, true)
+	calls := [][]byte{transfer[:], note[:], call[:]}
+	ret, err := m.Run(env, append(MulticallSelector[:], packReturn(t, []string{"bytes[]"}, calls)...))
+	r.NoError(err)
+	values := unpackReturn(t, []string{"bool[]", "bytes[]"}, ret)
+	r.Equal([]bool{false, true, false}, values[0])
+	r.Equal([]byte(ErrMulticallCall.Error()), values[1].([][]byte)[2])
+
+	// The log of the failed call is dropped
+	logs := db.Logs()
+	r.Len(logs, 1)
+	r.Equal([]common.Hash{topic}, logs[0].Topics)
+	r.Equal([]byte{0x01}, logs[0].Data)
+}
@@ -31,3 +31,23 @@ func (pc *BlankPrecompile) Run(API api.Environment, input []byte) ([]byte, error
 }
 
 var _ concrete.Precompile = &BlankPrecompile{}
+
+// FuncPrecompile adapts a plain function into a precompile, the same way
+// http.HandlerFunc adapts a function into a handler. IsStatic always returns
+// static, whatever the input.
+func FuncPrecompile(run func(env api.Environment, input []byte) ([]byte, error), static bool) concrete.Precompile {
+	return &funcPrecompile{run: run, static: static}
+}
+
+type funcPrecompile struct {
+	run    func(env api.Environment, input []byte) ([]byte, error)
+	static bool
+}
+
+func (pc *funcPrecompile) IsStatic(input []byte) bool {
+	return pc.static
+}
+
+func (pc *funcPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	return pc.run(env, input)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncPrecompile(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+	)
+
+	upper := FuncPrecompile(func(env api.Environment, input []byte) ([]byte, error) {
+		return bytes.ToUpper(input), nil
+	}, true)
+	r.True(upper.IsStatic(nil))
+	r.True(upper.IsStatic([]byte{0x01}))
+	ret, _, err := concrete.RunPrecompile(upper, env, []byte("abc"), 1000, new(uint256.Int))
+	r.NoError(err)
+	r.Equal([]byte("ABC"), ret)
+
+	// Errors revert with their message
+	failing := FuncPrecompile(func(env api.Environment, input []byte) ([]byte, error) {
+		return nil, errors.New("failed")
+	}, false)
+	r.False(failing.IsStatic(nil))
+	_, _, err = concrete.RunPrecompile(failing, env, nil, 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrExecutionReverted)
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+// ProxyImplementationSlot is the storage slot holding the implementation
+// address of a Proxy, i.e. keccak256("eip1967.proxy.implementation") - 1 as in
+// EIP-1967.
+var ProxyImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+var (
+	proxySetImplementationSelector = MustSelector("setImplementation(address)")
+	proxyImplementationSelector    = MustSelector("implementation()")
+	proxyNoImplementationSelector  = MustSelector("ProxyNoImplementation()")
+)
+
+const proxyUpgradedEvent = "Upgraded(address)"
+
+// Proxy is a precompile forwarding its input to an implementation address
+// read from its own storage and returning the result, as a minimal upgrade
+// pattern. Calls to setImplementation(address) and implementation() are
+// handled by the proxy itself and are not forwarded, setImplementation being
+// restricted to the owner of the access control.
+//
+// Static proxies forward calls with CallStatic and can be called from static
+// contexts, other proxies forward calls with Call along with the value of the
+// call.
+type Proxy struct {
+	ac     *AccessControl
+	static bool
+}
+
+var _ concrete.Precompile = (*Proxy)(nil)
+
+func NewProxy(ac *AccessControl, static bool) *Proxy {
+	return &Proxy{ac: ac, static: static}
+}
+
+func (p *Proxy) Implementation(env api.Environment) common.Address {
+	return common.BytesToAddress(env.StorageLoad(ProxyImplementationSlot).Bytes())
+}
+
+// SetImplementation sets the implementation address if the caller is the owner
+// and emits the Upgraded event of EIP-1967.
+func (p *Proxy) SetImplementation(env api.Environment, implementation common.Address) error {
+	if err := p.ac.CheckOwner(env); err != nil {
+		return err
+	}
+	env.StorageStore(ProxyImplementationSlot, common.BytesToHash(implementation.Bytes()))
+	EmitEvent(env, proxyUpgradedEvent, []common.Hash{common.BytesToHash(implementation.Bytes())}, nil)
+	return nil
+}
+
+func (p *Proxy) IsStatic(input []byte) bool {
+	switch {
+	case bytes.HasPrefix(input, proxySetImplementationSelector[:]):
+		return false
+	case bytes.Equal(input, proxyImplementationSelector[:]):
+		return true
+	default:
+		return p.static
+	}
+}
+
+func (p *Proxy) Run(env api.Environment, input []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(input, proxySetImplementationSelector[:]):
+		implementation, err := NewArgReader(input[4:]).ReadAddress()
+		if err != nil {
+			return nil, err
+		}
+		return nil, p.SetImplementation(env, implementation)
+	case bytes.Equal(input, proxyImplementationSelector[:]):
+		return NewArgWriter().WriteAddress(p.Implementation(env)).Bytes(), nil
+	}
+
+	implementation := p.Implementation(env)
+	if implementation == (common.Address{}) {
+		return nil, RevertError(proxyNoImplementationSelector)
+	}
+	var ret []byte
+	var err error
+	if p.static {
+		ret, err = env.CallStatic(implementation, input, env.GetGasLeft())
+	} else {
+		ret, err = env.Call(implementation, input, env.GetGasLeft(), env.GetCallValue())
+	}
+	if err != nil && len(ret) > 0 {
+		// Bubble up the revert data of the implementation
+		return nil, &concrete.RevertDataError{Data: ret}
+	}
+	return ret, err
+}
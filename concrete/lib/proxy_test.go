@@ -0,0 +1,132 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxy(t *testing.T) {
+	var (
+		r              = require.New(t)
+		owner          = common.HexToAddress("0x01")
+		implementation = common.HexToAddress("0xbeef")
+		address        = common.HexToAddress("0xc0ffee0001")
+		contract       = api.NewContract(common.Address{}, owner, address, new(uint256.Int))
+		statedb        = mock.NewMockStateDB().(*state.StateDB)
+		caller         = api.NewMockCaller()
+		env            = api.NewEnvironment(api.EnvConfig{}, false, statedb, api.NewMockBlockContext(), caller, contract)
+		ac             = NewAccessControl()
+		proxy          = NewProxy(ac, false)
+		setSelector    = MustSelector("setImplementation(address)")
+		getSelector    = MustSelector("implementation()")
+		input          = []byte{0x12, 0x34, 0x56, 0x78, 0x01}
+	)
+	contract.Value = uint256.NewInt(5)
+	ac.SetOwner(env, owner)
+
+	var calledAddr common.Address
+	var calledInput []byte
+	var calledValue *uint256.Int
+	caller.SetCallFn(func(addr common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, uint64, error) {
+		calledAddr, calledInput, calledValue = addr, data, value
+		return []byte("result"), gas, nil
+	})
+
+	// Calls revert until an implementation is set
+	_, err := proxy.Run(env, input)
+	var dataErr *concrete.RevertDataError
+	r.True(errors.As(err, &dataErr))
+	r.Equal(MustSelector("ProxyNoImplementation()"), [4]byte(dataErr.Data))
+
+	setInput := append(setSelector[:], NewArgWriter().WriteAddress(implementation).Bytes()...)
+	r.False(proxy.IsStatic(setInput))
+	_, err = proxy.Run(env, setInput)
+	r.NoError(err)
+	r.Equal(implementation, proxy.Implementation(env))
+	r.Equal(common.BytesToHash(implementation.Bytes()), env.StorageLoad(ProxyImplementationSlot))
+	r.Len(statedb.Logs(), 2)
+
+	r.True(proxy.IsStatic(getSelector[:]))
+	ret, err := proxy.Run(env, getSelector[:])
+	r.NoError(err)
+	r.Equal(common.BytesToHash(implementation.Bytes()).Bytes(), ret)
+
+	// Other calls are forwarded with the same input and value
+	r.False(proxy.IsStatic(input))
+	ret, err = proxy.Run(env, input)
+	r.NoError(err)
+	r.Equal([]byte("result"), ret)
+	r.Equal(implementation, calledAddr)
+	r.Equal(input, calledInput)
+	r.Equal(uint256.NewInt(5), calledValue)
+
+	// Revert data of the implementation is bubbled up
+	caller.SetCallFn(func(addr common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, uint64, error) {
+		return []byte{0xde, 0xad}, gas, errors.New("execution reverted")
+	})
+	_, err = proxy.Run(env, input)
+	r.True(errors.As(err, &dataErr))
+	r.Equal([]byte{0xde, 0xad}, dataErr.Data)
+
+	// Only the owner can set the implementation
+	contract.Caller = common.HexToAddress("0x02")
+	_, err = proxy.Run(env, setInput)
+	r.Error(err)
+	r.Error(proxy.SetImplementation(env, common.Address{}))
+	r.Equal(implementation, proxy.Implementation(env))
+}
+
+func TestStaticProxy(t *testing.T) {
+	var (
+		r              = require.New(t)
+		implementation = common.HexToAddress("0xbeef")
+		address        = common.HexToAddress("0xc0ffee0001")
+		contract       = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		statedb        = mock.NewMockStateDB()
+		caller         = api.NewMockCaller()
+		proxy          = NewProxy(NewAccessControl(), true)
+		input          = []byte{0x12, 0x34, 0x56, 0x78}
+	)
+	statedb.SetState(address, ProxyImplementationSlot, common.BytesToHash(implementation.Bytes()))
+	env := api.NewEnvironment(api.EnvConfig{IsStatic: true}, false, statedb, api.NewMockBlockContext(), caller, contract)
+
+	// Static proxies can be called from static contexts
+	var calledAddr common.Address
+	caller.SetCallStaticFn(func(addr common.Address, data []byte, gas uint64) ([]byte, uint64, error) {
+		calledAddr = addr
+		return []byte("result"), gas, nil
+	})
+	r.True(proxy.IsStatic(input))
+	ret, err := proxy.Run(env, input)
+	r.NoError(err)
+	r.Equal([]byte("result"), ret)
+	r.Equal(implementation, calledAddr)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+var rateLimitSlotPrefix = []byte("concrete.lib.RateLimit")
+
+// rateLimitedPrecompile counts the calls to the next precompile per caller and
+// block and rejects calls past a limit.
+type rateLimitedPrecompile struct {
+	next  concrete.Precompile
+	limit uint64
+}
+
+var (
+	_ concrete.Precompile = (*rateLimitedPrecompile)(nil)
+	_ concrete.GasCoster  = (*rateLimitedPrecompile)(nil)
+)
+
+// RateLimit returns a middleware allowing at most limit non-static calls per
+// caller per block, reverting with ErrRateLimited past it. Calls are counted in
+// storage slots derived from the caller and the block number, which must not
+// be used by the wrapped precompile. Counters of past blocks are never read
+// again, so they do not need to be cleared. Calls reverting do not count, and
+// static calls are not limited, as they cannot write their counter.
+func RateLimit(limit uint64) Middleware {
+	return func(next concrete.Precompile) concrete.Precompile {
+		return &rateLimitedPrecompile{next: next, limit: limit}
+	}
+}
+
+// RateLimitSlot returns the storage slot counting the calls of caller in the
+// block with the given number.
+func RateLimitSlot(caller common.Address, blockNumber uint64) common.Hash {
+	return crypto.Keccak256Hash(
+		rateLimitSlotPrefix,
+		caller.Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(blockNumber)).Bytes(),
+	)
+}
+
+func (r *rateLimitedPrecompile) IsStatic(input []byte) bool {
+	return r.next.IsStatic(input)
+}
+
+func (r *rateLimitedPrecompile) GasCost(input []byte) uint64 {
+	return gasCost(r.next, input)
+}
+
+func (r *rateLimitedPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	if r.next.IsStatic(input) {
+		return r.next.Run(env, input)
+	}
+	ctx := NewContext(env)
+	slot := RateLimitSlot(ctx.Caller(), ctx.BlockNumber())
+	count := env.StorageLoad(slot).Big().Uint64()
+	if count >= r.limit {
+		return nil, ErrRateLimited
+	}
+	env.StorageStore(slot, common.BigToHash(new(big.Int).SetUint64(count+1)))
+	return r.next.Run(env, input)
+}
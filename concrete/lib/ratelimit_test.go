@@ -0,0 +1,81 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	var (
+		r        = require.New(t)
+		caller   = common.HexToAddress("0x01")
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, caller, address, new(uint256.Int))
+		block    = api.NewMockBlockContext()
+		statedb  = mock.NewMockStateDB()
+		pc       = NewMethodDispatcher()
+	)
+	var calls int
+	write := pc.RegisterSignature("write()", func(env api.Environment, args []byte) ([]byte, error) {
+		calls++
+		return nil, nil
+	}, false)
+	view := pc.RegisterSignature("view()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{0x01}, nil
+	}, true)
+	limited := Chain(pc, RateLimit(2))
+
+	block.SetBlockNumber(10)
+	env := api.NewEnvironment(api.EnvConfig{}, false, statedb, block, api.NewMockCaller(), contract)
+	for ii := 0; ii < 2; ii++ {
+		_, err := limited.Run(env, write[:])
+		r.NoError(err)
+	}
+	_, err := limited.Run(env, write[:])
+	r.ErrorIs(err, ErrRateLimited)
+	r.Equal(2, calls)
+	r.Equal(common.BigToHash(common.Big2), env.StorageLoad(RateLimitSlot(caller, 10)))
+
+	// Static calls are not limited
+	ret, err := limited.Run(env, view[:])
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+
+	// Other callers have their own counter
+	other := api.NewContract(common.Address{}, common.HexToAddress("0x02"), address, new(uint256.Int))
+	env = api.NewEnvironment(api.EnvConfig{}, false, statedb, block, api.NewMockCaller(), other)
+	_, err = limited.Run(env, write[:])
+	r.NoError(err)
+
+	// Counters are reset in the next block
+	block.SetBlockNumber(11)
+	env = api.NewEnvironment(api.EnvConfig{}, false, statedb, block, api.NewMockCaller(), contract)
+	_, err = limited.Run(env, write[:])
+	r.NoError(err)
+	r.Equal(4, calls)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+)
+
+// PanicLogger receives the panics recovered by the Recover middleware, along
+// with the stack of the panicking goroutine, which is nil when built with
+// tinygo.
+type PanicLogger interface {
+	LogPanic(value interface{}, stack []byte)
+}
+
+// envErrors is implemented by environments that record the errors they panic
+// with to abort execution, such as *api.Env.
+type envErrors interface {
+	RevertError() error
+	NonRevertError() error
+}
+
+// envPanicErrors are the errors environments panic with to abort execution.
+var envPanicErrors = []error{
+	api.ErrOutOfGas,
+	api.ErrExecutionReverted,
+	api.ErrWriteProtection,
+	api.ErrEnvNotTrusted,
+	api.ErrGasUintOverflow,
+	api.ErrFeatureDisabled,
+	api.ErrInvalidOpCode,
+	api.ErrInvalidInput,
+}
+
+// isEnvPanic reports whether a recovered value aborts execution on behalf of
+// the environment, e.g. when running out of gas, rather than being a bug of
+// the precompile.
+func isEnvPanic(env api.Environment, value interface{}) bool {
+	if env != nil {
+		if e, ok := env.(envErrors); ok && (e.RevertError() != nil || e.NonRevertError() != nil) {
+			return true
+		}
+	}
+	err, ok := value.(error)
+	if !ok {
+		return false
+	}
+	for _, envErr := range envPanicErrors {
+		if errors.Is(err, envErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// recoverPrecompile recovers from the panics of the next precompile.
+type recoverPrecompile struct {
+	next   concrete.Precompile
+	logger PanicLogger
+}
+
+var (
+	_ concrete.Precompile = (*recoverPrecompile)(nil)
+	_ concrete.GasCoster  = (*recoverPrecompile)(nil)
+)
+
+// Recover returns a middleware recovering from the panics of the next
+// precompile, e.g. a nil dereference, so they cannot crash the node. A panic
+// in Run reverts with ErrPrecompilePanic, without exposing the panic value. A
+// panic in IsStatic reports the input as not static and a panic in GasCost
+// charges all the gas, so the call fails. Every recovered panic is passed to
+// logger with its stack if logger is not nil.
+//
+// Chain calls IsStatic on every layer, so to also protect IsStatic wrap the
+// whole chain, e.g. lib.Recover(logger)(lib.Chain(pc, middlewares...)).
+//
+// Panics the environment uses to abort execution, such as running out of gas
+// or reverting, are rethrown.
+func Recover(logger PanicLogger) Middleware {
+	return func(next concrete.Precompile) concrete.Precompile {
+		return &recoverPrecompile{next: next, logger: logger}
+	}
+}
+
+func (p *recoverPrecompile) log(value interface{}) {
+	if p.logger != nil {
+		p.logger.LogPanic(value, panicStack())
+	}
+}
+
+func (p *recoverPrecompile) IsStatic(input []byte) (static bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.log(r)
+			static = false
+		}
+	}()
+	return p.next.IsStatic(input)
+}
+
+func (p *recoverPrecompile) GasCost(input []byte) (gas uint64) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.log(r)
+			gas = math.MaxUint64
+		}
+	}()
+	return gasCost(p.next, input)
+}
+
+func (p *recoverPrecompile) Run(env api.Environment, input []byte) (ret []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if isEnvPanic(env, r) {
+				panic(r)
+			}
+			p.log(r)
+			ret, err = nil, ErrPrecompilePanic
+		}
+	}()
+	return p.next.Run(env, input)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+type testPanicLogger struct {
+	values []interface{}
+	stacks [][]byte
+}
+
+func (l *testPanicLogger) LogPanic(value interface{}, stack []byte) {
+	l.values = append(l.values, value)
+	l.stacks = append(l.stacks, stack)
+}
+
+type panickingPrecompile struct {
+	concrete.Precompile
+}
+
+func (p *panickingPrecompile) IsStatic(input []byte) bool {
+	panic("static")
+}
+
+func (p *panickingPrecompile) GasCost(input []byte) uint64 {
+	panic("gas")
+}
+
+func TestRecover(t *testing.T) {
+	var (
+		r       = require.New(t)
+		address = common.HexToAddress("0xc0ffee0001")
+		newEnv  = func() *api.Env {
+			contract := api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+			return mock.NewMockEnvironment(api.EnvConfig{}, true, contract)
+		}
+	)
+
+	dispatcher := NewMethodDispatcher()
+	get := dispatcher.RegisterSignature("get()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{1}, nil
+	}, true)
+	deref := dispatcher.RegisterSignature("deref()", func(env api.Environment, args []byte) ([]byte, error) {
+		var contract *api.Contract
+		return contract.Input, nil
+	}, false)
+	exhaust := dispatcher.RegisterSignature("exhaust()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.UseGas(math.MaxUint64)
+		return nil, nil
+	}, false)
+	revert := dispatcher.RegisterSignature("revert()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.Revert(errors.New("failed"))
+		return nil, nil
+	}, false)
+
+	logger := &testPanicLogger{}
+	pc := Chain(dispatcher, Recover(logger))
+	r.True(pc.IsStatic(get[:]))
+
+	ret, _, err := concrete.RunPrecompile(pc, newEnv(), get[:], 1000, new(uint256.Int))
+	r.NoError(err)
+	r.Equal([]byte{1}, ret)
+	r.Empty(logger.values)
+
+	// Runtime panics revert without exposing the panic value
+	ret, remaining, err := concrete.RunPrecompile(pc, newEnv(), deref[:], 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrExecutionReverted)
+	r.Equal([]byte(ErrPrecompilePanic.Error()), ret)
+	r.Equal(uint64(1000), remaining)
+	r.Len(logger.values, 1)
+	r.Contains(logger.values[0].(error).Error(), "nil pointer dereference")
+	r.Contains(string(logger.stacks[0]), "runtime/debug.Stack")
+
+	// Panics of the environment are not recovered
+	_, remaining, err = concrete.RunPrecompile(pc, newEnv(), exhaust[:], 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrOutOfGas)
+	r.Zero(remaining)
+	ret, _, err = concrete.RunPrecompile(pc, newEnv(), revert[:], 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrExecutionReverted)
+	r.Equal([]byte("failed"), ret)
+	r.Len(logger.values, 1)
+
+	// Panics outside of Run make the call fail
+	pc = Recover(nil)(Chain(&panickingPrecompile{dispatcher}, Metrics(&testCollector{}, nil)))
+	r.False(pc.IsStatic(get[:]))
+	r.Equal(uint64(math.MaxUint64), gasCost(pc, get[:]))
+	_, remaining, err = concrete.RunPrecompile(pc, newEnv(), get[:], 1000, new(uint256.Int))
+	r.ErrorIs(err, api.ErrOutOfGas)
+	r.Zero(remaining)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+var ErrReentrantCall = errors.New("reentrant call")
+
+var DefaultReentrancyGuardSlot = common.BytesToHash(crypto.Keccak256([]byte("concrete.lib.ReentrancyGuard")))
+
+var reentrancyGuardEntered = common.BigToHash(common.Big1)
+
+// ReentrancyGuard wraps a precompile and reverts with ErrReentrantCall if it is
+// re-entered while running a non-static method. The guard flag is kept in a
+// reserved storage slot, which must not be used by the wrapped precompile.
+type ReentrancyGuard struct {
+	pc   concrete.Precompile
+	slot common.Hash
+}
+
+var _ concrete.Precompile = (*ReentrancyGuard)(nil)
+
+func NewReentrancyGuard(pc concrete.Precompile) *ReentrancyGuard {
+	return NewReentrancyGuardWithSlot(pc, DefaultReentrancyGuardSlot)
+}
+
+func NewReentrancyGuardWithSlot(pc concrete.Precompile, slot common.Hash) *ReentrancyGuard {
+	return &ReentrancyGuard{pc: pc, slot: slot}
+}
+
+func (g *ReentrancyGuard) IsStatic(input []byte) bool {
+	return g.pc.IsStatic(input)
+}
+
+// GasCost forwards to the wrapped precompile if it implements
+// concrete.GasCoster.
+func (g *ReentrancyGuard) GasCost(input []byte) uint64 {
+	return gasCost(g.pc, input)
+}
+
+func (g *ReentrancyGuard) Run(env api.Environment, input []byte) ([]byte, error) {
+	// Static methods cannot mutate state, so there is nothing to guard
+	if g.pc.IsStatic(input) {
+		return g.pc.Run(env, input)
+	}
+	if env.StorageLoad(g.slot) == reentrancyGuardEntered {
+		return nil, ErrReentrantCall
+	}
+	env.StorageStore(g.slot, reentrancyGuardEntered)
+	ret, err := g.pc.Run(env, input)
+	env.StorageStore(g.slot, common.Hash{})
+	return ret, err
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReentrancyGuard(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		pc       = NewMethodDispatcher()
+		guard    = NewReentrancyGuardWithSlot(pc, slot)
+	)
+
+	var reentryErr error
+	reenter := pc.RegisterSignature("reenter()", func(env api.Environment, args []byte) ([]byte, error) {
+		input := Selector("reenter()")
+		_, reentryErr = guard.Run(env, input[:])
+		return nil, nil
+	}, false)
+	view := pc.RegisterSignature("view()", func(env api.Environment, args []byte) ([]byte, error) {
+		return []byte{0x01}, nil
+	}, true)
+	callView := pc.RegisterSignature("callView()", func(env api.Environment, args []byte) ([]byte, error) {
+		r.Equal(reentrancyGuardEntered, env.StorageLoad(slot))
+		return guard.Run(env, view[:])
+	}, false)
+
+	_, err := guard.Run(env, reenter[:])
+	r.NoError(err)
+	r.ErrorIs(reentryErr, ErrReentrantCall)
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+
+	// Static methods are not guarded
+	ret, err := guard.Run(env, callView[:])
+	r.NoError(err)
+	r.Equal([]byte{0x01}, ret)
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrNameRegistered  = errors.New("precompile name already registered")
+	ErrAddressTaken    = errors.New("precompile address already taken")
+	ErrAddressReserved = errors.New("precompile address is reserved")
+	ErrAddressOutside  = errors.New("precompile address outside of the registry range")
+	ErrRangeFull       = errors.New("no free precompile address left in the registry range")
+	ErrInvalidRange    = errors.New("invalid address range")
+)
+
+// PrecompileAddressPrefix is the first byte of the addresses derived by
+// PrecompileAddress, keeping them well above the addresses of the builtin
+// precompiles and away from those of regular accounts.
+const PrecompileAddressPrefix = 0xcc
+
+var (
+	// DerivedAddressStart and DerivedAddressEnd bound the addresses derived by
+	// PrecompileAddress, e.g. to create a registry with RegisterDerived.
+	DerivedAddressStart = common.BytesToAddress(common.RightPadBytes([]byte{PrecompileAddressPrefix}, common.AddressLength))
+	DerivedAddressEnd   = common.BytesToAddress(append([]byte{PrecompileAddressPrefix}, common.MaxAddress[1:]...))
+)
+
+// PrecompileAddress derives the address of a precompile from its name. The
+// address is the last 19 bytes of the keccak256 hash of the name prefixed
+// with PrecompileAddressPrefix, so the same name always gets the same address.
+func PrecompileAddress(name string) common.Address {
+	var addr common.Address
+	addr[0] = PrecompileAddressPrefix
+	copy(addr[1:], crypto.Keccak256([]byte(name))[32-common.AddressLength+1:])
+	return addr
+}
+
+type addressRange struct {
+	start, end *uint256.Int
+}
+
+func newAddressRange(start, end common.Address) (addressRange, error) {
+	rng := addressRange{
+		start: new(uint256.Int).SetBytes(start.Bytes()),
+		end:   new(uint256.Int).SetBytes(end.Bytes()),
+	}
+	if rng.start.Gt(rng.end) {
+		return addressRange{}, fmt.Errorf("%w: %s is after %s", ErrInvalidRange, start.Hex(), end.Hex())
+	}
+	return rng, nil
+}
+
+func (r addressRange) contains(addr *uint256.Int) bool {
+	return !addr.Lt(r.start) && !addr.Gt(r.end)
+}
+
+type registryEntry struct {
+	name string
+	pc   concrete.Precompile
+}
+
+// Registry maps names to precompiles and assigns each one an address in a
+// configurable range, so that all the precompiles of a chain can be declared
+// in a single place. Addresses are assigned in registration order, each
+// precompile getting the lowest address of the range that is neither taken
+// nor reserved, so the same registrations always produce the same addresses.
+type Registry struct {
+	rng      addressRange
+	next     *uint256.Int
+	reserved []addressRange
+	names    map[string]common.Address
+	entries  map[common.Address]registryEntry
+	order    []common.Address
+}
+
+// NewRegistry creates a registry that assigns addresses from start to end,
+// both included.
+func NewRegistry(start, end common.Address) (*Registry, error) {
+	rng, err := newAddressRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &Registry{
+		rng:     rng,
+		next:    new(uint256.Int).Set(rng.start),
+		names:   make(map[string]common.Address),
+		entries: make(map[common.Address]registryEntry),
+	}, nil
+}
+
+// Reserve excludes the addresses from start to end, both included, from
+// automatic assignment. Reserved addresses can still be registered explicitly
+// with RegisterAt. Reserving an address that is already taken is an error.
+func (r *Registry) Reserve(start, end common.Address) error {
+	rng, err := newAddressRange(start, end)
+	if err != nil {
+		return err
+	}
+	for _, addr := range r.order {
+		if rng.contains(new(uint256.Int).SetBytes(addr.Bytes())) {
+			return fmt.Errorf("%w: %s is registered as %s", ErrAddressTaken, addr.Hex(), r.entries[addr].name)
+		}
+	}
+	r.reserved = append(r.reserved, rng)
+	return nil
+}
+
+func (r *Registry) isReserved(addr *uint256.Int) bool {
+	for _, rng := range r.reserved {
+		if rng.contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Register adds a precompile under the given name at the next free address of
+// the range and returns that address.
+func (r *Registry) Register(name string, pc concrete.Precompile) (common.Address, error) {
+	if _, ok := r.names[name]; ok {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrNameRegistered, name)
+	}
+	for ; r.rng.contains(r.next); r.next.AddUint64(r.next, 1) {
+		addr := common.Address(r.next.Bytes20())
+		if _, ok := r.entries[addr]; ok || r.isReserved(r.next) {
+			continue
+		}
+		r.add(name, addr, pc)
+		return addr, nil
+	}
+	return common.Address{}, fmt.Errorf("%w: cannot register %s", ErrRangeFull, name)
+}
+
+// RegisterAt adds a precompile under the given name at a fixed address. The
+// address must be in the range of the registry and not taken, but it can be
+// reserved.
+func (r *Registry) RegisterAt(name string, addr common.Address, pc concrete.Precompile) error {
+	if _, ok := r.names[name]; ok {
+		return fmt.Errorf("%w: %s", ErrNameRegistered, name)
+	}
+	if !r.rng.contains(new(uint256.Int).SetBytes(addr.Bytes())) {
+		return fmt.Errorf("%w: %s", ErrAddressOutside, addr.Hex())
+	}
+	if entry, ok := r.entries[addr]; ok {
+		return fmt.Errorf("%w: %s is registered as %s", ErrAddressTaken, addr.Hex(), entry.name)
+	}
+	r.add(name, addr, pc)
+	return nil
+}
+
+// RegisterDerived adds a precompile under the given name at the address
+// derived from it by PrecompileAddress and returns that address. The registry
+// range must include the derived addresses, e.g. by creating it from
+// DerivedAddressStart to DerivedAddressEnd. Two names deriving the same
+// address fail with ErrAddressTaken.
+func (r *Registry) RegisterDerived(name string, pc concrete.Precompile) (common.Address, error) {
+	addr := PrecompileAddress(name)
+	if err := r.RegisterAt(name, addr, pc); err != nil {
+		return common.Address{}, err
+	}
+	return addr, nil
+}
+
+func (r *Registry) add(name string, addr common.Address, pc concrete.Precompile) {
+	r.names[name] = addr
+	r.entries[addr] = registryEntry{name: name, pc: pc}
+	r.order = append(r.order, addr)
+}
+
+// Lookup returns the name and the precompile registered at an address.
+func (r *Registry) Lookup(addr common.Address) (string, concrete.Precompile, bool) {
+	entry, ok := r.entries[addr]
+	return entry.name, entry.pc, ok
+}
+
+// Resolve returns the address of the precompile registered under a name.
+func (r *Registry) Resolve(name string) (common.Address, bool) {
+	addr, ok := r.names[name]
+	return addr, ok
+}
+
+// Addresses returns the addresses of all the precompiles in registration
+// order.
+func (r *Registry) Addresses() []common.Address {
+	return append([]common.Address{}, r.order...)
+}
+
+// Precompiles returns all the precompiles by address, e.g. to add them to a
+// concrete.GenericPrecompileRegistry with AddPrecompiles.
+func (r *Registry) Precompiles() concrete.PrecompileMap {
+	precompiles := make(concrete.PrecompileMap, len(r.entries))
+	for addr, entry := range r.entries {
+		precompiles[addr] = entry.pc
+	}
+	return precompiles
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := require.New(t)
+	var (
+		start = common.HexToAddress("0x80")
+		end   = common.HexToAddress("0x85")
+		pcA   = &testPrecompile{static: true}
+		pcB   = &testPrecompile{}
+		pcC   = &testPrecompile{}
+	)
+
+	_, err := NewRegistry(end, start)
+	r.ErrorIs(err, ErrInvalidRange)
+
+	registry, err := NewRegistry(start, end)
+	r.NoError(err)
+	r.NoError(registry.Reserve(common.HexToAddress("0x81"), common.HexToAddress("0x82")))
+
+	addrA, err := registry.Register("a", pcA)
+	r.NoError(err)
+	r.Equal(common.HexToAddress("0x80"), addrA)
+
+	// Reserved addresses are skipped
+	addrB, err := registry.Register("b", pcB)
+	r.NoError(err)
+	r.Equal(common.HexToAddress("0x83"), addrB)
+
+	// Explicit addresses can be reserved but not taken or out of range
+	r.NoError(registry.RegisterAt("c", common.HexToAddress("0x81"), pcC))
+	r.ErrorIs(registry.RegisterAt("d", addrB, pcC), ErrAddressTaken)
+	r.ErrorIs(registry.RegisterAt("d", common.HexToAddress("0x86"), pcC), ErrAddressOutside)
+	r.ErrorIs(registry.RegisterAt("a", common.HexToAddress("0x84"), pcC), ErrNameRegistered)
+	_, err = registry.Register("a", pcC)
+	r.ErrorIs(err, ErrNameRegistered)
+	r.ErrorIs(registry.Reserve(common.HexToAddress("0x83"), common.HexToAddress("0x84")), ErrAddressTaken)
+
+	name, pc, ok := registry.Lookup(addrB)
+	r.True(ok)
+	r.Equal("b", name)
+	r.Equal(pcB, pc)
+	_, _, ok = registry.Lookup(common.HexToAddress("0x84"))
+	r.False(ok)
+
+	addr, ok := registry.Resolve("c")
+	r.True(ok)
+	r.Equal(common.HexToAddress("0x81"), addr)
+	_, ok = registry.Resolve("d")
+	r.False(ok)
+
+	r.NoError(registry.RegisterAt("d", common.HexToAddress("0x84"), pcC))
+	addrE, err := registry.Register("e", pcC)
+	r.NoError(err)
+	r.Equal(common.HexToAddress("0x85"), addrE)
+	_, err = registry.Register("f", pcC)
+	r.ErrorIs(err, ErrRangeFull)
+
+	r.Equal([]common.Address{addrA, addrB, common.HexToAddress("0x81"), common.HexToAddress("0x84"), addrE}, registry.Addresses())
+
+	// The precompiles can be installed in a node registry
+	precompiles := concrete.NewRegistry()
+	precompiles.AddPrecompiles(0, registry.Precompiles())
+	installed, ok := precompiles.Precompile(addrA, 1)
+	r.True(ok)
+	r.Equal(pcA, installed)
+	r.Len(precompiles.PrecompiledAddresses(0), 5)
+}
+
+func TestRegistryIsDeterministic(t *testing.T) {
+	r := require.New(t)
+	register := func() []common.Address {
+		registry, err := NewRegistry(common.HexToAddress("0xc0ffee0000"), common.HexToAddress("0xc0ffeeffff"))
+		r.NoError(err)
+		r.NoError(registry.Reserve(common.HexToAddress("0xc0ffee0000"), common.HexToAddress("0xc0ffee00ff")))
+		for _, name := range []string{"token", "oracle", "bridge"} {
+			_, err := registry.Register(name, &testPrecompile{})
+			r.NoError(err)
+		}
+		return registry.Addresses()
+	}
+	addresses := register()
+	r.Equal(common.HexToAddress("0xc0ffee0100"), addresses[0])
+	r.Equal(addresses, register())
+}
+
+func TestPrecompileAddress(t *testing.T) {
+	r := require.New(t)
+
+	// Derived addresses must never change across builds
+	addr := PrecompileAddress("counter")
+	r.Equal(common.HexToAddress("0xcc5aed6f46f5f582f476a886b91b834b0ddf5854"), addr)
+	r.Equal(byte(PrecompileAddressPrefix), addr[0])
+	r.NotEqual(addr, PrecompileAddress("Counter"))
+
+	registry, err := NewRegistry(DerivedAddressStart, DerivedAddressEnd)
+	r.NoError(err)
+	counterAddr, err := registry.RegisterDerived("counter", &testPrecompile{})
+	r.NoError(err)
+	r.Equal(addr, counterAddr)
+	resolved, ok := registry.Resolve("counter")
+	r.True(ok)
+	r.Equal(addr, resolved)
+
+	_, err = registry.RegisterDerived("counter", &testPrecompile{})
+	r.ErrorIs(err, ErrNameRegistered)
+
+	// Names colliding with a taken address are rejected
+	r.NoError(registry.RegisterAt("squatter", PrecompileAddress("token"), &testPrecompile{}))
+	_, err = registry.RegisterDerived("token", &testPrecompile{})
+	r.ErrorIs(err, ErrAddressTaken)
+	r.ErrorContains(err, "squatter")
+
+	// Derived addresses are outside of other registry ranges
+	registry, err = NewRegistry(common.HexToAddress("0x80"), common.HexToAddress("0xff"))
+	r.NoError(err)
+	_, err = registry.RegisterDerived("counter", &testPrecompile{})
+	r.ErrorIs(err, ErrAddressOutside)
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReturnBuilder ABI encodes the return values of a method as a tuple, with the
+// head/tail layout of abi.Arguments.Pack: static values are encoded in place
+// and dynamic ones are replaced by the offset of their encoding after the
+// heads. Supported types are those of EmitTypedEvent plus fixed size (T[N])
+// and dynamic (T[]) arrays of them, given as slices or arrays.
+type ReturnBuilder struct {
+	types  []string
+	values []interface{}
+}
+
+func NewReturnBuilder() *ReturnBuilder {
+	return &ReturnBuilder{}
+}
+
+// Add appends a return value of the given solidity type.
+func (b *ReturnBuilder) Add(typ string, value interface{}) *ReturnBuilder {
+	b.types = append(b.types, typ)
+	b.values = append(b.values, value)
+	return b
+}
+
+// Build returns the encoded return data.
+func (b *ReturnBuilder) Build() ([]byte, error) {
+	return EncodeReturn(b.types, b.values...)
+}
+
+// EncodeReturn ABI encodes values of the given solidity types as a tuple, in
+// the same way as ReturnBuilder.
+func EncodeReturn(types []string, values ...interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("expected %d return values, got %d", len(types), len(values))
+	}
+	return encodeTuple(types, values)
+}
+
+func encodeTuple(types []string, values []interface{}) ([]byte, error) {
+	var (
+		heads   = make([][]byte, len(types))
+		tails   = make([][]byte, len(types))
+		headLen = 0
+	)
+	for ii, typ := range types {
+		enc, dynamic, err := encodeABIValue(strings.ReplaceAll(typ, " ", ""), values[ii])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %d of type %s: %w", ii, typ, err)
+		}
+		if dynamic {
+			tails[ii] = enc
+			headLen += 32
+		} else {
+			heads[ii] = enc
+			headLen += len(enc)
+		}
+	}
+	data := make([]byte, 0, headLen)
+	offset := headLen
+	for ii, head := range heads {
+		if head == nil {
+			head = common.BigToHash(big.NewInt(int64(offset))).Bytes()
+			offset += len(tails[ii])
+		}
+		data = append(data, head...)
+	}
+	for _, tail := range tails {
+		data = append(data, tail...)
+	}
+	return data, nil
+}
+
+// encodeABIValue returns the encoding of a value and whether its type is
+// dynamic. Dynamic values are length prefixed where the ABI requires it.
+func encodeABIValue(typ string, value interface{}) ([]byte, bool, error) {
+	if !strings.HasSuffix(typ, "]") {
+		// Accept fixed size byte arrays as returned by abi.Arguments.Unpack
+		if v := reflect.ValueOf(value); strings.HasPrefix(typ, "bytes") && v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+			// Copied element by element as older tinygo versions do not
+			// implement reflect.Copy
+			b := make([]byte, v.Len())
+			for ii := range b {
+				b[ii] = byte(v.Index(ii).Uint())
+			}
+			value = b
+		}
+		enc, dynamic, err := encodeEventValue(typ, value)
+		if err != nil || !dynamic {
+			return enc, dynamic, err
+		}
+		return encodeDynamicValue(enc), true, nil
+	}
+
+	open := strings.LastIndex(typ, "[")
+	if open <= 0 {
+		return nil, false, fmt.Errorf("unsupported type %s", typ)
+	}
+	elemType, lengthStr := typ[:open], typ[open+1:len(typ)-1]
+	items := reflect.ValueOf(value)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return nil, false, fmt.Errorf("expected slice or array, got %T", value)
+	}
+	types := make([]string, items.Len())
+	values := make([]interface{}, items.Len())
+	for ii := range values {
+		types[ii] = elemType
+		values[ii] = items.Index(ii).Interface()
+	}
+
+	if lengthStr == "" {
+		enc, err := encodeTuple(types, values)
+		if err != nil {
+			return nil, false, err
+		}
+		length := common.BigToHash(big.NewInt(int64(len(values)))).Bytes()
+		return append(length, enc...), true, nil
+	}
+	length, err := strconv.Atoi(lengthStr)
+	if err != nil || length < 1 {
+		return nil, false, fmt.Errorf("unsupported type %s", typ)
+	}
+	if len(values) != length {
+		return nil, false, fmt.Errorf("expected %d elements, got %d", length, len(values))
+	}
+	enc, err := encodeTuple(types, values)
+	if err != nil {
+		return nil, false, err
+	}
+	return enc, isDynamicABIType(elemType), nil
+}
+
+// isDynamicABIType reports whether values of a type are encoded in the tail.
+// Fixed size arrays are dynamic only if their elements are.
+func isDynamicABIType(typ string) bool {
+	if typ == "string" || typ == "bytes" || strings.HasSuffix(typ, "[]") {
+		return true
+	}
+	if open := strings.LastIndex(typ, "["); open > 0 && strings.HasSuffix(typ, "]") {
+		return isDynamicABIType(typ[:open])
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func packReturn(t *testing.T, types []string, values ...interface{}) []byte {
+	var args abi.Arguments
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	data, err := args.Pack(values...)
+	require.NoError(t, err)
+	unpacked, err := args.Unpack(data)
+	require.NoError(t, err)
+	require.Equal(t, len(values), len(unpacked))
+	for ii := range values {
+		require.Equal(t, values[ii], unpacked[ii])
+	}
+	return data
+}
+
+func TestReturnBuilder(t *testing.T) {
+	var (
+		addr    = common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+		hash    = [32]byte{1, 2, 3}
+		amounts = []*big.Int{big.NewInt(1), big.NewInt(-2), big.NewInt(3)}
+		names   = []string{"alice", "", "a string that takes more than one word to encode"}
+		nested  = [][]uint64{{1, 2}, {}, {3}}
+		pairs   = [2][]uint8{{1}, {2, 3}}
+		fixed   = [3]bool{true, false, true}
+	)
+	tests := []struct {
+		name   string
+		types  []string
+		values []interface{}
+	}{
+		{"empty", nil, nil},
+		{"static", []string{"uint256", "address", "bool", "bytes32", "int64"}, []interface{}{big.NewInt(42), addr, true, hash, int64(-7)}},
+		{"dynamic", []string{"string", "bytes"}, []interface{}{"hello", []byte{0xde, 0xad, 0xbe, 0xef}}},
+		{"mixed", []string{"uint64", "string", "address", "bytes", "bool"}, []interface{}{uint64(9), "concrete", addr, make([]byte, 65), false}},
+		{"dynamicArrays", []string{"int256[]", "string[]", "uint64[][]"}, []interface{}{amounts, names, nested}},
+		{"fixedArrays", []string{"bool[3]", "uint8[][2]", "uint16"}, []interface{}{fixed, pairs, uint16(5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			builder := NewReturnBuilder()
+			for ii, typ := range tt.types {
+				builder.Add(typ, tt.values[ii])
+			}
+			data, err := builder.Build()
+			r.NoError(err)
+			r.Equal(common.Bytes2Hex(packReturn(t, tt.types, tt.values...)), common.Bytes2Hex(data))
+		})
+	}
+}
+
+func TestReturnBuilderErrors(t *testing.T) {
+	r := require.New(t)
+	_, err := NewReturnBuilder().Add("uint256", "not a number").Build()
+	r.ErrorContains(err, "invalid value 0 of type uint256")
+	_, err = NewReturnBuilder().Add("bool", true).Add("uint8[2]", []uint8{1}).Build()
+	r.ErrorContains(err, "expected 2 elements, got 1")
+	_, err = NewReturnBuilder().Add("tuple", nil).Build()
+	r.ErrorContains(err, "unsupported type tuple")
+	_, err = EncodeReturn([]string{"bool"})
+	r.ErrorContains(err, "expected 1 return values, got 0")
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+var ErrInvalidSignature = errors.New("invalid method signature")
+
+// CanonicalSignature returns the canonical form of a method signature used to
+// compute its selector, e.g. "transfer(address,uint256)" for
+// "transfer(address to, uint amount)". Whitespace and parameter names are
+// removed, and int, uint, fixed and ufixed are expanded to int256, uint256,
+// fixed128x18 and ufixed128x18. Tuples are written as parenthesized lists of
+// types. An error is returned if the signature is malformed or uses unknown
+// types.
+func CanonicalSignature(signature string) (string, error) {
+	fail := func(format string, args ...interface{}) (string, error) {
+		return "", fmt.Errorf("%w %q: %s", ErrInvalidSignature, signature, fmt.Sprintf(format, args...))
+	}
+	open := strings.IndexByte(signature, '(')
+	if open < 0 {
+		return fail("missing parameter list")
+	}
+	name := strings.TrimSpace(signature[:open])
+	if !isIdentifier(name) {
+		return fail("invalid method name %q", name)
+	}
+	params, rest, err := parseTupleType(signature[open:])
+	if err != nil {
+		return fail("%v", err)
+	}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		return fail("unexpected %q after parameter list", rest)
+	}
+	return name + params, nil
+}
+
+// ParseSelector returns the 4-byte selector of a method signature, or an error
+// if the signature is malformed.
+func ParseSelector(signature string) ([4]byte, error) {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return [4]byte{}, err
+	}
+	var selector [4]byte
+	copy(selector[:], crypto.Keccak256([]byte(canonical))[:4])
+	return selector, nil
+}
+
+// MustSelector is like ParseSelector but panics if the signature is malformed,
+// e.g. to declare selectors as package level variables.
+func MustSelector(signature string) [4]byte {
+	selector, err := ParseSelector(signature)
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+// parseTupleType parses the parenthesized list of types at the start of s and
+// returns its canonical form along with the rest of s.
+func parseTupleType(s string) (string, string, error) {
+	s = strings.TrimLeft(s, " \t\r\n")
+	if !strings.HasPrefix(s, "(") {
+		return "", "", errors.New("expected (")
+	}
+	s = strings.TrimLeft(s[1:], " \t\r\n")
+	if strings.HasPrefix(s, ")") {
+		return "()", s[1:], nil
+	}
+	var types []string
+	for {
+		typ, rest, err := parseParam(s)
+		if err != nil {
+			return "", "", err
+		}
+		types = append(types, typ)
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		switch {
+		case rest == "":
+			return "", "", errors.New("unterminated parameter list")
+		case rest[0] == ',':
+			s = rest[1:]
+		case rest[0] == ')':
+			return "(" + strings.Join(types, ",") + ")", rest[1:], nil
+		default:
+			return "", "", fmt.Errorf("unexpected %q in parameter list", rest[:1])
+		}
+	}
+}
+
+// parseParam parses a type followed by an optional parameter name at the start
+// of s and returns the canonical type along with the rest of s.
+func parseParam(s string) (string, string, error) {
+	s = strings.TrimLeft(s, " \t\r\n")
+	var typ string
+	if strings.HasPrefix(s, "(") {
+		tuple, rest, err := parseTupleType(s)
+		if err != nil {
+			return "", "", err
+		}
+		typ, s = tuple, rest
+	} else {
+		end := strings.IndexAny(s, " \t\r\n,()[]")
+		if end < 0 {
+			end = len(s)
+		}
+		elementary, err := canonicalElementaryType(s[:end])
+		if err != nil {
+			return "", "", err
+		}
+		typ, s = elementary, s[end:]
+	}
+
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if !strings.HasPrefix(s, "[") {
+			break
+		}
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return "", "", errors.New("unterminated array type")
+		}
+		length := strings.TrimSpace(s[1:end])
+		if length != "" {
+			if n, err := strconv.ParseUint(length, 10, 64); err != nil || n == 0 {
+				return "", "", fmt.Errorf("invalid array length %q", length)
+			}
+		}
+		typ += "[" + length + "]"
+		s = s[end+1:]
+	}
+
+	// Skip the parameter name, if any
+	end := strings.IndexAny(s, " \t\r\n,()")
+	if end < 0 {
+		end = len(s)
+	}
+	if end > 0 {
+		if !isIdentifier(s[:end]) {
+			return "", "", fmt.Errorf("invalid parameter name %q", s[:end])
+		}
+		s = s[end:]
+	}
+	return typ, s, nil
+}
+
+// canonicalElementaryType returns the canonical name of an elementary ABI type,
+// expanding aliases such as uint.
+func canonicalElementaryType(typ string) (string, error) {
+	invalid := fmt.Errorf("invalid type %q", typ)
+	switch typ {
+	case "address", "bool", "string", "bytes", "function":
+		return typ, nil
+	case "uint", "int":
+		return typ + "256", nil
+	case "fixed", "ufixed":
+		return typ + "128x18", nil
+	case "":
+		return "", errors.New("missing type")
+	}
+
+	if size, ok := strings.CutPrefix(typ, "bytes"); ok {
+		if n, err := strconv.Atoi(size); err != nil || n < 1 || n > 32 || strconv.Itoa(n) != size {
+			return "", invalid
+		}
+		return typ, nil
+	}
+	for _, prefix := range []string{"uint", "int"} {
+		if bits, ok := strings.CutPrefix(typ, prefix); ok {
+			if !isIntegerBits(bits) {
+				return "", invalid
+			}
+			return typ, nil
+		}
+	}
+	for _, prefix := range []string{"ufixed", "fixed"} {
+		if spec, ok := strings.CutPrefix(typ, prefix); ok {
+			bits, decimals, found := strings.Cut(spec, "x")
+			if !found || !isIntegerBits(bits) {
+				return "", invalid
+			}
+			if n, err := strconv.Atoi(decimals); err != nil || n < 0 || n > 80 || strconv.Itoa(n) != decimals {
+				return "", invalid
+			}
+			return typ, nil
+		}
+	}
+	return "", invalid
+}
+
+// isIntegerBits reports whether bits is a valid width of an integer type, i.e.
+// a multiple of 8 between 8 and 256 without leading zeros.
+func isIntegerBits(bits string) bool {
+	n, err := strconv.Atoi(bits)
+	return err == nil && n >= 8 && n <= 256 && n%8 == 0 && strconv.Itoa(n) == bits
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for ii, c := range name {
+		switch {
+		case c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		case ii > 0 && c >= '0' && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalSignature(t *testing.T) {
+	r := require.New(t)
+
+	valid := map[string]string{
+		"transfer(address,uint256)":               "transfer(address,uint256)",
+		" transfer ( address to,\tuint amount ) ": "transfer(address,uint256)",
+		"get()":  "get()",
+		"get( )": "get()",
+		"f(int,fixed,ufixed,bytes32,bytes,string)":    "f(int256,fixed128x18,ufixed128x18,bytes32,bytes,string)",
+		"f(uint8[] values, bytes32[2][] hashes)":      "f(uint8[],bytes32[2][])",
+		"f(uint8 [ ], address [3])":                   "f(uint8[],address[3])",
+		"swap((address,uint) order, (bool,(int8))[])": "swap((address,uint256),(bool,(int8))[])",
+		"f(ufixed64x10,fixed8x0)":                     "f(ufixed64x10,fixed8x0)",
+		"_$f1(bool _b, function $cb)":                 "_$f1(bool,function)",
+	}
+	for signature, canonical := range valid {
+		got, err := CanonicalSignature(signature)
+		r.NoError(err, signature)
+		r.Equal(canonical, got, signature)
+	}
+
+	invalid := []string{
+		"",
+		"transfer",
+		"(address)",
+		"1transfer(address)",
+		"transfer(address",
+		"transfer(address,)",
+		"transfer(,address)",
+		"transfer(address))",
+		"transfer(address) returns (bool)",
+		"transfer(adress)",
+		"f(uint7)",
+		"f(uint264)",
+		"f(uint08)",
+		"f(bytes0)",
+		"f(bytes33)",
+		"f(fixed128x81)",
+		"f(fixed128)",
+		"f(uint[0])",
+		"f(uint[-1])",
+		"f(uint[)",
+		"f(address to from)",
+		"f(address 1to)",
+	}
+	for _, signature := range invalid {
+		_, err := CanonicalSignature(signature)
+		r.ErrorIs(err, ErrInvalidSignature, signature)
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	r := require.New(t)
+
+	selector, err := ParseSelector("transfer(address to, uint amount)")
+	r.NoError(err)
+	r.Equal([4]byte{0xa9, 0x05, 0x9c, 0xbb}, selector)
+	r.Equal(selector, MustSelector("transfer(address,uint256)"))
+	r.Equal(selector, Selector("transfer(address, uint)"))
+	r.Equal([4]byte{0x70, 0xa0, 0x82, 0x31}, MustSelector("balanceOf(address)"))
+
+	_, err = ParseSelector("transfer(address")
+	r.ErrorIs(err, ErrInvalidSignature)
+	r.Panics(func() { MustSelector("transfer(address") })
+	r.Panics(func() {
+		NewMethodDispatcher().RegisterSignature("transfer(adress,uint256)", nil, false)
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+var ErrSimulatedCall = errors.New("call or create in simulation")
+
+// StateChange is a storage write journaled by a SimulatedEnvironment.
+type StateChange struct {
+	Transient bool
+	Key       common.Hash
+	Previous  common.Hash
+	Value     common.Hash
+}
+
+// SimulatedLog is a log emitted in a SimulatedEnvironment.
+type SimulatedLog struct {
+	Topics []common.Hash
+	Data   []byte
+}
+
+// SimulatedEnvironment wraps an environment and keeps all storage writes and
+// logs in memory instead of applying them, so they are discarded once the
+// environment is dropped. Calls and creates cannot be rolled back and panic
+// with ErrSimulatedCall. Static calls are forwarded, but the callee does not
+// see the simulated writes.
+type SimulatedEnvironment struct {
+	api.Environment
+	storage   map[common.Hash]common.Hash
+	transient map[common.Hash]common.Hash
+	reads     []common.Hash
+	changes   []StateChange
+	logs      []SimulatedLog
+}
+
+var _ api.Environment = (*SimulatedEnvironment)(nil)
+
+func NewSimulatedEnvironment(env api.Environment) *SimulatedEnvironment {
+	return &SimulatedEnvironment{
+		Environment: env,
+		storage:     make(map[common.Hash]common.Hash),
+		transient:   make(map[common.Hash]common.Hash),
+	}
+}
+
+// Reads returns the storage keys read so far, in order.
+func (e *SimulatedEnvironment) Reads() []common.Hash {
+	return e.reads
+}
+
+// Changes returns the storage and transient storage writes so far, in order.
+func (e *SimulatedEnvironment) Changes() []StateChange {
+	return e.changes
+}
+
+// Logs returns the logs emitted so far, in order.
+func (e *SimulatedEnvironment) Logs() []SimulatedLog {
+	return e.logs
+}
+
+func (e *SimulatedEnvironment) StorageLoad(key common.Hash) common.Hash {
+	e.reads = append(e.reads, key)
+	if value, ok := e.storage[key]; ok {
+		return value
+	}
+	return e.Environment.StorageLoad(key)
+}
+
+func (e *SimulatedEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	previous, ok := e.storage[key]
+	if !ok {
+		previous = e.Environment.StorageLoad(key)
+	}
+	e.storage[key] = value
+	e.changes = append(e.changes, StateChange{Key: key, Previous: previous, Value: value})
+}
+
+func (e *SimulatedEnvironment) TransientLoad(key common.Hash) common.Hash {
+	if value, ok := e.transient[key]; ok {
+		return value
+	}
+	return e.Environment.TransientLoad(key)
+}
+
+func (e *SimulatedEnvironment) TransientStore(key common.Hash, value common.Hash) {
+	previous, ok := e.transient[key]
+	if !ok {
+		previous = e.Environment.TransientLoad(key)
+	}
+	e.transient[key] = value
+	e.changes = append(e.changes, StateChange{Transient: true, Key: key, Previous: previous, Value: value})
+}
+
+func (e *SimulatedEnvironment) Log(topics []common.Hash, data []byte) {
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	e.logs = append(e.logs, SimulatedLog{Topics: topics, Data: dataCopy})
+}
+
+// GetExternalStorage sees the simulated writes when reading the storage of the
+// wrapped contract itself.
+func (e *SimulatedEnvironment) GetExternalStorage(address common.Address, key common.Hash) common.Hash {
+	if address == e.GetAddress() {
+		return e.StorageLoad(key)
+	}
+	return e.Environment.GetExternalStorage(address, key)
+}
+
+func (e *SimulatedEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	switch op {
+	case api.StorageLoad_OpCode:
+		return [][]byte{e.StorageLoad(common.BytesToHash(args[0])).Bytes()}
+	case api.GetExternalStorage_OpCode:
+		return [][]byte{e.GetExternalStorage(common.BytesToAddress(args[0]), common.BytesToHash(args[1])).Bytes()}
+	case api.StorageStore_OpCode:
+		e.StorageStore(common.BytesToHash(args[0]), common.BytesToHash(args[1]))
+		return nil
+	case api.TransientLoad_OpCode:
+		return [][]byte{e.TransientLoad(common.BytesToHash(args[0])).Bytes()}
+	case api.TransientStore_OpCode:
+		e.TransientStore(common.BytesToHash(args[0]), common.BytesToHash(args[1]))
+		return nil
+	case api.Log_OpCode:
+		topics := make([]common.Hash, len(args)-1)
+		for ii, arg := range args[:len(topics)] {
+			topics[ii] = common.BytesToHash(arg)
+		}
+		e.Log(topics, args[len(args)-1])
+		return nil
+	case api.Call_OpCode, api.CallDelegate_OpCode, api.Create_OpCode, api.Create2_OpCode:
+		panic(ErrSimulatedCall)
+	}
+	return e.Environment.Execute(op, args)
+}
+
+func (e *SimulatedEnvironment) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
+	panic(ErrSimulatedCall)
+}
+
+func (e *SimulatedEnvironment) CallDelegate(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	panic(ErrSimulatedCall)
+}
+
+func (e *SimulatedEnvironment) Create(data []byte, value *uint256.Int) ([]byte, common.Address, error) {
+	panic(ErrSimulatedCall)
+}
+
+func (e *SimulatedEnvironment) Create2(data []byte, endowment *uint256.Int, salt *uint256.Int) ([]byte, common.Address, error) {
+	panic(ErrSimulatedCall)
+}
+
+// Simulate runs a precompile in a SimulatedEnvironment and returns its output
+// and the storage writes it made, without applying them to env.
+func Simulate(pc concrete.Precompile, env api.Environment, input []byte) ([]byte, []StateChange, error) {
+	simEnv := NewSimulatedEnvironment(env)
+	output, err := pc.Run(simEnv, input)
+	return output, simEnv.Changes(), err
+}
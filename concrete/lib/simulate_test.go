@@ -0,0 +1,134 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot1    = common.HexToHash("0x01")
+		slot2    = common.HexToHash("0x02")
+		value1   = common.HexToHash("0x03")
+		value2   = common.HexToHash("0x04")
+		pc       = NewMethodDispatcher()
+	)
+
+	env.StorageStore(slot1, value1)
+
+	write := pc.RegisterSignature("write()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot1, value2)
+		env.StorageStore(slot2, value1)
+		env.TransientStore(slot1, value1)
+		EmitEvent(env, "Written()", nil, nil)
+		return env.StorageLoad(slot1).Bytes(), nil
+	}, false)
+	create := pc.RegisterSignature("create()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.Create(nil, new(uint256.Int))
+		return nil, nil
+	}, false)
+
+	output, writes, err := Simulate(pc, env, write[:])
+	r.NoError(err)
+	r.Equal(value2.Bytes(), output)
+	r.Equal([]StateChange{
+		{Key: slot1, Previous: value1, Value: value2},
+		{Key: slot2, Previous: common.Hash{}, Value: value1},
+		{Transient: true, Key: slot1, Previous: common.Hash{}, Value: value1},
+	}, writes)
+
+	// Nothing is applied to the wrapped environment
+	r.Equal(value1, env.StorageLoad(slot1))
+	r.Equal(common.Hash{}, env.StorageLoad(slot2))
+	r.Equal(common.Hash{}, env.TransientLoad(slot1))
+
+	r.PanicsWithValue(ErrSimulatedCall, func() { Simulate(pc, env, create[:]) })
+}
+
+func TestSimulatedEnvironment(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		simEnv   = NewSimulatedEnvironment(env)
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+	)
+
+	// Raw opcodes go through the simulated state too
+	simEnv.Execute(api.StorageStore_OpCode, [][]byte{slot.Bytes(), value.Bytes()})
+	r.Equal(value.Bytes(), simEnv.Execute(api.StorageLoad_OpCode, [][]byte{slot.Bytes()})[0])
+	r.Equal([]common.Hash{slot}, simEnv.Reads())
+	r.Len(simEnv.Changes(), 1)
+
+	simEnv.Execute(api.Log_OpCode, [][]byte{value.Bytes(), {0x01}})
+	r.Equal([]SimulatedLog{{Topics: []common.Hash{value}, Data: []byte{0x01}}}, simEnv.Logs())
+
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+	r.PanicsWithValue(ErrSimulatedCall, func() { simEnv.Execute(api.Call_OpCode, nil) })
+
+	// Reading the own storage as external storage sees the simulated writes
+	r.Equal(value, simEnv.GetExternalStorage(address, slot))
+	r.Equal(value.Bytes(), simEnv.Execute(api.GetExternalStorage_OpCode, [][]byte{address.Bytes(), slot.Bytes()})[0])
+	r.Equal(common.Hash{}, simEnv.GetExternalStorage(common.Address{0x01}, slot))
+}
+
+func TestExternalStorage(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		caller   = common.HexToAddress("0xc0ffee0002")
+		contract = api.NewContract(common.Address{}, caller, address, new(uint256.Int))
+		statedb  = mock.NewMockStateDB()
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+	)
+	statedb.SetState(caller, slot, value)
+	env := api.NewEnvironment(api.EnvConfig{IsStatic: true}, true, statedb, api.NewMockBlockContext(), api.NewMockCaller(), contract)
+	contract.Gas = 1e6
+
+	// The storage of the caller is readable from static calls, and is
+	// distinct from the storage of the precompile
+	r.Equal(value, env.GetExternalStorage(env.GetCaller(), slot))
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+	r.Equal(common.Hash{}, env.GetExternalStorage(common.Address{0x01}, slot))
+
+	// Reads are charged as cold until the slot is in the access list
+	gas := env.Gas()
+	env.GetExternalStorage(caller, slot)
+	r.Equal(uint64(params.WarmStorageReadCostEIP2929), gas-env.Gas())
+	gas = env.Gas()
+	env.GetExternalStorage(common.Address{0x02}, slot)
+	r.Equal(uint64(params.ColdAccountAccessCostEIP2929-params.WarmStorageReadCostEIP2929+params.ColdSloadCostEIP2929), gas-env.Gas())
+}
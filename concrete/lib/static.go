@@ -0,0 +1,114 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+var ErrStaticWrite = errors.New("state mutation in static call")
+
+var staticWriteOpCodes = map[api.OpCode]bool{
+	api.StorageStore_OpCode:   true,
+	api.TransientStore_OpCode: true,
+	api.Log_OpCode:            true,
+	api.Call_OpCode:           true,
+	api.CallDelegate_OpCode:   true,
+	api.Create_OpCode:         true,
+	api.Create2_OpCode:        true,
+}
+
+// StaticEnvironment wraps an environment and panics with ErrStaticWrite on any
+// call that would mutate state.
+type StaticEnvironment struct {
+	api.Environment
+}
+
+var _ api.Environment = (*StaticEnvironment)(nil)
+
+func NewStaticEnvironment(env api.Environment) *StaticEnvironment {
+	return &StaticEnvironment{Environment: env}
+}
+
+func (e *StaticEnvironment) Execute(op api.OpCode, args [][]byte) [][]byte {
+	if staticWriteOpCodes[op] {
+		panic(ErrStaticWrite)
+	}
+	return e.Environment.Execute(op, args)
+}
+
+func (e *StaticEnvironment) StorageStore(key common.Hash, value common.Hash) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) TransientStore(key common.Hash, value common.Hash) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) Log(topics []common.Hash, data []byte) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) Call(address common.Address, data []byte, gas uint64, value *uint256.Int) ([]byte, error) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) CallDelegate(address common.Address, data []byte, gas uint64) ([]byte, error) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) Create(data []byte, value *uint256.Int) ([]byte, common.Address, error) {
+	panic(ErrStaticWrite)
+}
+
+func (e *StaticEnvironment) Create2(data []byte, endowment *uint256.Int, salt *uint256.Int) ([]byte, common.Address, error) {
+	panic(ErrStaticWrite)
+}
+
+// StaticGuard wraps a precompile and runs the calls it declares static with a
+// StaticEnvironment, so that a static method mutating state panics instead of
+// silently writing. It is meant to be used in tests.
+type StaticGuard struct {
+	pc concrete.Precompile
+}
+
+var _ concrete.Precompile = (*StaticGuard)(nil)
+
+func NewStaticGuard(pc concrete.Precompile) *StaticGuard {
+	return &StaticGuard{pc: pc}
+}
+
+func (g *StaticGuard) IsStatic(input []byte) bool {
+	return g.pc.IsStatic(input)
+}
+
+// GasCost forwards to the wrapped precompile if it implements
+// concrete.GasCoster.
+func (g *StaticGuard) GasCost(input []byte) uint64 {
+	return gasCost(g.pc, input)
+}
+
+func (g *StaticGuard) Run(env api.Environment, input []byte) ([]byte, error) {
+	if g.pc.IsStatic(input) {
+		env = NewStaticEnvironment(env)
+	}
+	return g.pc.Run(env, input)
+}
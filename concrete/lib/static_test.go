@@ -0,0 +1,71 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/concrete/mock"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticGuard(t *testing.T) {
+	var (
+		r        = require.New(t)
+		address  = common.HexToAddress("0xc0ffee0001")
+		contract = api.NewContract(common.Address{}, common.Address{}, address, new(uint256.Int))
+		env      = mock.NewMockEnvironment(api.EnvConfig{}, false, contract)
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+		pc       = NewMethodDispatcher()
+		guard    = NewStaticGuard(pc)
+	)
+
+	view := pc.RegisterSignature("view()", func(env api.Environment, args []byte) ([]byte, error) {
+		return env.StorageLoad(slot).Bytes(), nil
+	}, true)
+	badView := pc.RegisterSignature("badView()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, value)
+		return nil, nil
+	}, true)
+	badViewExecute := pc.RegisterSignature("badViewExecute()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.Execute(api.Log_OpCode, [][]byte{})
+		return nil, nil
+	}, true)
+	write := pc.RegisterSignature("write()", func(env api.Environment, args []byte) ([]byte, error) {
+		env.StorageStore(slot, value)
+		return nil, nil
+	}, false)
+
+	r.PanicsWithValue(ErrStaticWrite, func() { guard.Run(env, badView[:]) })
+	r.PanicsWithValue(ErrStaticWrite, func() { guard.Run(env, badViewExecute[:]) })
+	r.Equal(common.Hash{}, env.StorageLoad(slot))
+
+	// Non-static methods are not guarded
+	_, err := guard.Run(env, write[:])
+	r.NoError(err)
+	ret, err := guard.Run(env, view[:])
+	r.NoError(err)
+	r.Equal(value.Bytes(), ret)
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/concrete"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/holiman/uint256"
+)
+
+var ErrInvalidCalldata = errors.New("invalid calldata")
+
+// calldataType is the layout of an ABI type in calldata.
+type calldataType struct {
+	// Bytes and strings
+	bytes bool
+	// Arrays, with a length of -1 for dynamic arrays
+	elem   *calldataType
+	length int
+	// Tuples
+	components []*calldataType
+}
+
+func (t *calldataType) isDynamic() bool {
+	switch {
+	case t.bytes:
+		return true
+	case t.elem != nil:
+		return t.length < 0 || t.elem.isDynamic()
+	}
+	for _, component := range t.components {
+		if component.isDynamic() {
+			return true
+		}
+	}
+	return false
+}
+
+// headSize returns the size of the type in the head of the tuple it is part
+// of, i.e. a single offset word for dynamic types.
+func (t *calldataType) headSize() int {
+	switch {
+	case t.isDynamic():
+		return 32
+	case t.elem != nil:
+		return t.length * t.elem.headSize()
+	case t.components != nil:
+		size := 0
+		for _, component := range t.components {
+			size += component.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// parseCalldataTypes parses the parameter types of a canonical signature, as
+// returned by CanonicalSignature.
+func parseCalldataTypes(canonical string) ([]*calldataType, error) {
+	open := strings.IndexByte(canonical, '(')
+	if open < 0 {
+		return nil, ErrInvalidSignature
+	}
+	tuple, rest, err := parseCalldataType(canonical[open:])
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" || tuple.elem != nil {
+		return nil, ErrInvalidSignature
+	}
+	return tuple.components, nil
+}
+
+func parseCalldataType(s string) (*calldataType, string, error) {
+	t := &calldataType{}
+	if strings.HasPrefix(s, "(") {
+		s = s[1:]
+		t.components = []*calldataType{}
+		for !strings.HasPrefix(s, ")") {
+			component, rest, err := parseCalldataType(s)
+			if err != nil {
+				return nil, "", err
+			}
+			t.components = append(t.components, component)
+			s = strings.TrimPrefix(rest, ",")
+			if s == "" {
+				return nil, "", ErrInvalidSignature
+			}
+		}
+		s = s[1:]
+	} else {
+		end := strings.IndexAny(s, ",()[]")
+		if end < 0 {
+			end = len(s)
+		}
+		t.bytes = s[:end] == "bytes" || s[:end] == "string"
+		s = s[end:]
+	}
+	for strings.HasPrefix(s, "[") {
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, "", ErrInvalidSignature
+		}
+		length := -1
+		if end > 1 {
+			n, err := strconv.Atoi(s[1:end])
+			if err != nil {
+				return nil, "", ErrInvalidSignature
+			}
+			length = n
+		}
+		t = &calldataType{elem: t, length: length}
+		s = s[end+1:]
+	}
+	return t, s, nil
+}
+
+// maxCalldataDepth is the deepest nesting of dynamic values checked in
+// calldata.
+const maxCalldataDepth = 32
+
+// calldataValidator bounds the work of checking calldata. Offsets can point to
+// the same data several times, e.g. to make nested arrays look much larger
+// than their encoding, so at most one offset is followed per word of the
+// calldata, as many as a canonical encoding has at most.
+type calldataValidator struct {
+	offsets int
+}
+
+// validateSequence checks the encoding of count values laid out as a tuple at
+// the start of data, the type of each given by typeAt.
+func (v *calldataValidator) validateSequence(depth int, count int, typeAt func(ii int) *calldataType, data []byte) error {
+	pos := 0
+	for ii := 0; ii < count; ii++ {
+		t := typeAt(ii)
+		size := t.headSize()
+		if len(data)-pos < size {
+			return errors.New("data too short")
+		}
+		if t.isDynamic() {
+			offset := new(uint256.Int).SetBytes(data[pos : pos+32])
+			if !offset.IsUint64() || offset.Uint64() > uint64(len(data)) {
+				return fmt.Errorf("offset %s out of bounds", offset.Dec())
+			}
+			if v.offsets == 0 {
+				return errors.New("too many offsets")
+			}
+			v.offsets--
+			if err := v.validateDynamic(depth+1, t, data[offset.Uint64():]); err != nil {
+				return err
+			}
+		}
+		pos += size
+	}
+	return nil
+}
+
+// validateArray checks the encoding of length elements of an array at the
+// start of data. Arrays of static elements are only checked to fit in data.
+func (v *calldataValidator) validateArray(depth int, t *calldataType, length uint64, data []byte) error {
+	if !t.elem.isDynamic() {
+		if length*uint64(t.elem.headSize()) > uint64(len(data)) {
+			return errors.New("data too short")
+		}
+		return nil
+	}
+	return v.validateSequence(depth, int(length), func(int) *calldataType { return t.elem }, data)
+}
+
+// validateDynamic checks the encoding of a value of a dynamic type at the start
+// of data.
+func (v *calldataValidator) validateDynamic(depth int, t *calldataType, data []byte) error {
+	if depth > maxCalldataDepth {
+		return errors.New("values nested too deep")
+	}
+	switch {
+	case t.bytes || t.length < 0 && t.elem != nil:
+		if len(data) < 32 {
+			return errors.New("data too short for length")
+		}
+		length := new(uint256.Int).SetBytes(data[:32])
+		data = data[32:]
+		if t.bytes {
+			// Bytes are right padded to a multiple of 32 bytes
+			if !length.IsUint64() || (length.Uint64()+31)/32*32 > uint64(len(data)) {
+				return fmt.Errorf("length %s out of bounds", length.Dec())
+			}
+			return nil
+		}
+		// Every element takes at least a word, which bounds the length
+		if !length.IsUint64() || length.Uint64() > uint64(len(data)/32) {
+			return fmt.Errorf("length %s out of bounds", length.Dec())
+		}
+		return v.validateArray(depth, t, length.Uint64(), data)
+	case t.elem != nil:
+		return v.validateArray(depth, t, uint64(t.length), data)
+	}
+	return v.validateSequence(depth, len(t.components), func(ii int) *calldataType { return t.components[ii] }, data)
+}
+
+// ValidateCalldata checks that args, the ABI encoded arguments of a method call
+// without the selector, are long enough for the parameters of signature and
+// that the offsets and lengths of their dynamic values are within bounds. The
+// values themselves are not checked. Calldata following more offsets than it
+// has words, or nesting dynamic values deeper than 32 levels, is invalid.
+func ValidateCalldata(signature string, args []byte) error {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return err
+	}
+	types, err := parseCalldataTypes(canonical)
+	if err != nil {
+		return err
+	}
+	return validateCalldata(canonical, types, args)
+}
+
+func validateCalldata(canonical string, types []*calldataType, args []byte) error {
+	v := &calldataValidator{offsets: len(args) / 32}
+	if err := v.validateSequence(0, len(types), func(ii int) *calldataType { return types[ii] }, args); err != nil {
+		return fmt.Errorf("%w for %s: %v", ErrInvalidCalldata, canonical, err)
+	}
+	return nil
+}
+
+// validatingPrecompile checks the calldata of the methods it knows the
+// signature of before calling the next precompile.
+type validatingPrecompile struct {
+	next       concrete.Precompile
+	signatures map[[4]byte]string
+	types      map[[4]byte][]*calldataType
+
+	// The result of validating the input of the last GasCost call, which Run
+	// is called with afterwards, so that every input is validated once.
+	mu        sync.Mutex
+	lastInput []byte
+	lastErr   error
+}
+
+var (
+	_ concrete.Precompile = (*validatingPrecompile)(nil)
+	_ concrete.GasCoster  = (*validatingPrecompile)(nil)
+)
+
+// Validate returns a middleware rejecting calls with an ErrInvalidCalldata
+// error if their arguments do not fit the signature of their selector in
+// signatures, e.g. the MethodNames of a MethodDispatcher, as checked by
+// ValidateCalldata. Handlers and their gas functions can then slice their
+// arguments without checking their length. Calls to other selectors are
+// passed through. It panics if a signature is malformed.
+func Validate(signatures map[[4]byte]string) Middleware {
+	types := make(map[[4]byte][]*calldataType, len(signatures))
+	canonicals := make(map[[4]byte]string, len(signatures))
+	for selector, signature := range signatures {
+		canonical, err := CanonicalSignature(signature)
+		if err != nil {
+			panic(err)
+		}
+		if types[selector], err = parseCalldataTypes(canonical); err != nil {
+			panic(err)
+		}
+		canonicals[selector] = canonical
+	}
+	return func(next concrete.Precompile) concrete.Precompile {
+		return &validatingPrecompile{next: next, signatures: canonicals, types: types}
+	}
+}
+
+func (v *validatingPrecompile) IsStatic(input []byte) bool {
+	return v.next.IsStatic(input)
+}
+
+// GasCost returns zero for calls with invalid calldata, which fail without
+// running, so that the gas functions of the next precompile can slice their
+// arguments too.
+func (v *validatingPrecompile) GasCost(input []byte) uint64 {
+	err := v.validate(input)
+	v.mu.Lock()
+	v.lastInput = append(v.lastInput[:0], input...)
+	v.lastErr = err
+	v.mu.Unlock()
+	if err != nil {
+		return 0
+	}
+	return gasCost(v.next, input)
+}
+
+// cachedValidate returns the result of validating the input of the last
+// GasCost call if it is the same as input, and validates input otherwise.
+func (v *validatingPrecompile) cachedValidate(input []byte) error {
+	v.mu.Lock()
+	cached, err := v.lastInput != nil && bytes.Equal(v.lastInput, input), v.lastErr
+	v.lastInput, v.lastErr = v.lastInput[:0], nil
+	v.mu.Unlock()
+	if cached {
+		return err
+	}
+	return v.validate(input)
+}
+
+func (v *validatingPrecompile) validate(input []byte) error {
+	if len(input) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	if types, ok := v.types[selector]; ok {
+		return validateCalldata(v.signatures[selector], types, input[4:])
+	}
+	return nil
+}
+
+func (v *validatingPrecompile) Run(env api.Environment, input []byte) ([]byte, error) {
+	if err := v.cachedValidate(input); err != nil {
+		return nil, err
+	}
+	return v.next.Run(env, input)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package lib
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCalldata(t *testing.T) {
+	r := require.New(t)
+	data := packReturn(t, []string{"address", "bytes", "uint256[]"},
+		common.HexToAddress("0xc0ffee"), []byte("the quick brown fox jumps over the lazy dog"), []*big.Int{big.NewInt(1), big.NewInt(2)})
+	r.NoError(ValidateCalldata("f(address,bytes,uint256[])", data))
+
+	withWord := func(data []byte, index int, value uint64) []byte {
+		data = append([]byte(nil), data...)
+		copy(data[index*32:(index+1)*32], common.BigToHash(new(big.Int).SetUint64(value)).Bytes())
+		return data
+	}
+	for name, invalid := range map[string][]byte{
+		"truncated head": data[:64],
+		"truncated tail": data[:len(data)-32],
+		"offset too big": withWord(data, 1, uint64(len(data)+32)),
+		"huge offset":    append(append(append([]byte(nil), data[:32]...), common.MaxHash.Bytes()...), data[64:]...),
+		"bytes too long": withWord(data, 3, 1000),
+		"array too long": withWord(data, 6, 1<<40),
+	} {
+		err := ValidateCalldata("f(address,bytes,uint256[])", invalid)
+		r.ErrorIs(err, ErrInvalidCalldata, name)
+		r.ErrorContains(err, "f(address,bytes,uint256[])", name)
+	}
+
+	// Bytes must be padded to a multiple of 32 bytes
+	data = packReturn(t, []string{"bytes"}, []byte("fox"))
+	r.NoError(ValidateCalldata("f(bytes)", data))
+	r.ErrorIs(ValidateCalldata("f(bytes)", data[:len(data)-1]), ErrInvalidCalldata)
+
+	// Static tuples and arrays are laid out in the head
+	data = packReturn(t, []string{"uint256[2]", "bool"}, [2]*big.Int{big.NewInt(1), big.NewInt(2)}, true)
+	r.Len(data, 96)
+	r.NoError(ValidateCalldata("f(uint[2] values, bool flag)", data))
+	r.ErrorIs(ValidateCalldata("f(uint[2] values, bool flag)", data[:64]), ErrInvalidCalldata)
+	r.NoError(ValidateCalldata("f((uint256,bool))", data[:64]))
+
+	// Dynamic values nested in arrays have offsets relative to the array
+	data = packReturn(t, []string{"string[]"}, []string{"a", "b"})
+	r.NoError(ValidateCalldata("f(string[])", data))
+	r.ErrorIs(ValidateCalldata("f(string[])", withWord(data, 2, 1000)), ErrInvalidCalldata)
+
+	// Offsets pointing to the same data cannot make the calldata look larger
+	// than it is
+	words := func(values ...uint64) []byte {
+		var data []byte
+		for _, value := range values {
+			data = append(data, common.BigToHash(new(big.Int).SetUint64(value)).Bytes()...)
+		}
+		return data
+	}
+	const k = 10
+	aliased := words(32, k)
+	for ii := 0; ii < k; ii++ {
+		aliased = append(aliased, words(32*k)...)
+	}
+	aliased = append(aliased, words(k)...)
+	for ii := 0; ii < k; ii++ {
+		aliased = append(aliased, words(32*k)...)
+	}
+	aliased = append(aliased, words(0)...)
+	r.ErrorContains(ValidateCalldata("f(uint256[][][])", aliased), "too many offsets")
+	nested := make([][][]*big.Int, k)
+	for ii := range nested {
+		nested[ii] = make([][]*big.Int, k)
+		for jj := range nested[ii] {
+			nested[ii][jj] = []*big.Int{}
+		}
+	}
+	r.NoError(ValidateCalldata("f(uint256[][][])", packReturn(t, []string{"uint256[][][]"}, nested)))
+
+	// Dynamic values are nested at most maxCalldataDepth deep
+	deep := func(depth int) (string, []byte) {
+		data := words(32)
+		for ii := 1; ii < depth; ii++ {
+			data = append(data, words(1, 32)...)
+		}
+		return "f(uint256" + strings.Repeat("[]", depth) + ")", append(data, words(0)...)
+	}
+	r.NoError(ValidateCalldata(deep(maxCalldataDepth)))
+	r.ErrorContains(ValidateCalldata(deep(maxCalldataDepth+1)), "nested too deep")
+
+	r.NoError(ValidateCalldata("f()", nil))
+	r.ErrorIs(ValidateCalldata("f(", nil), ErrInvalidSignature)
+}
+
+func TestValidate(t *testing.T) {
+	r := require.New(t)
+	pc := NewMethodDispatcher()
+	var calls int
+	set := pc.RegisterSignature("set(address,uint256)", func(env api.Environment, args []byte) ([]byte, error) {
+		calls++
+		return args[32:64], nil
+	}, false)
+	pc.SetGasCost(set, func(args []byte) uint64 {
+		return 100 + uint64(args[63])
+	})
+	unnamed := pc.RegisterSignature("unnamed()", func(env api.Environment, args []byte) ([]byte, error) {
+		return nil, nil
+	}, true)
+	names := pc.MethodNames()
+	delete(names, unnamed)
+	validated := Chain(pc, Validate(names))
+
+	input := append(set[:], packReturn(t, []string{"address", "uint256"}, common.HexToAddress("0x01"), big.NewInt(2))...)
+	r.Equal(uint64(102), gasCost(validated, input))
+	ret, err := validated.Run(nil, input)
+	r.NoError(err)
+	r.Equal(common.BigToHash(common.Big2).Bytes(), ret)
+
+	// Short inputs fail before reaching the method or its gas function
+	r.Equal(uint64(0), gasCost(validated, input[:40]))
+	_, err = validated.Run(nil, input[:40])
+	r.ErrorIs(err, ErrInvalidCalldata)
+	r.Equal(1, calls)
+	r.False(validated.IsStatic(input[:40]))
+
+	// Inputs run without their gas cost being computed first are validated too
+	r.Equal(uint64(102), gasCost(validated, input))
+	_, err = validated.Run(nil, input[:40])
+	r.ErrorIs(err, ErrInvalidCalldata)
+	r.Equal(1, calls)
+
+	// Selectors without a signature are not validated
+	_, err = validated.Run(nil, append(unnamed[:], 0x01))
+	r.NoError(err)
+	_, err = validated.Run(nil, []byte{0x01})
+	r.ErrorIs(err, ErrMethodNotFound)
+
+	r.Panics(func() { Validate(map[[4]byte]string{{}: "f("}) })
+}
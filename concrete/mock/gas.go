@@ -0,0 +1,111 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package mock
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/api"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// GasUsage is the gas used and the number of storage slots accessed by a
+// measured call.
+type GasUsage struct {
+	Gas          uint64
+	SlotsRead    int
+	SlotsWritten int
+}
+
+// GasMeter is a mock environment metering gas that counts the storage slots
+// read and written through it, e.g. to benchmark storage accessors.
+type GasMeter struct {
+	statedb *state.StateDB
+	metered *api.Env
+	env     *api.Env
+	usage   GasUsage
+}
+
+func NewGasMeter() *GasMeter {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		panic(err)
+	}
+	contract := api.NewContract(common.Address{}, common.Address{}, common.Address{}, new(uint256.Int))
+	contract.Gas = math.MaxUint64
+	m := &GasMeter{
+		statedb: statedb,
+		metered: api.NewEnvironment(api.EnvConfig{}, true, statedb, api.NewMockBlockContext(), api.NewMockCaller(), contract),
+	}
+	m.env = api.NewProxyEnvironment(func(op api.OpCode, _ *api.Env, args [][]byte) ([][]byte, error) {
+		switch op {
+		case api.StorageLoad_OpCode:
+			m.usage.SlotsRead++
+		case api.StorageStore_OpCode:
+			m.usage.SlotsWritten++
+		}
+		return m.metered.Execute(op, args), nil
+	})
+	return m
+}
+
+// Env returns the environment to run the measured calls with.
+func (m *GasMeter) Env() *api.Env {
+	return m.env
+}
+
+// NewTx commits the storage written so far and starts a new transaction, so
+// every slot is cold again and writes are charged against the committed
+// values.
+func (m *GasMeter) NewTx() {
+	m.statedb.IntermediateRoot(false)
+	address := m.metered.Contract().Address
+	m.statedb.Prepare(params.Rules{IsBerlin: true}, address, common.Address{}, &address, nil, nil, nil)
+}
+
+// Measure calls fn and returns the gas it used and the slots it accessed.
+func (m *GasMeter) Measure(fn func()) GasUsage {
+	m.usage = GasUsage{}
+	gas := m.metered.Gas()
+	fn()
+	m.usage.Gas = gas - m.metered.Gas()
+	return m.usage
+}
+
+// Benchmark measures fn in a new transaction and reports its usage as the
+// gas/op, sloads/op and sstores/op metrics of the benchmark, then runs it b.N
+// times to time it.
+func (m *GasMeter) Benchmark(b *testing.B, fn func()) {
+	m.NewTx()
+	usage := m.Measure(fn)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn()
+	}
+	b.ReportMetric(float64(usage.Gas), "gas/op")
+	b.ReportMetric(float64(usage.SlotsRead), "sloads/op")
+	b.ReportMetric(float64(usage.SlotsWritten), "sstores/op")
+}
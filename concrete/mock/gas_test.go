@@ -0,0 +1,51 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package mock
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasMeter(t *testing.T) {
+	r := require.New(t)
+	meter := NewGasMeter()
+	env := meter.Env()
+	key := common.Hash{0x01}
+
+	usage := meter.Measure(func() { env.StorageStore(key, common.Hash{0x02}) })
+	r.Equal(GasUsage{Gas: params.ColdSloadCostEIP2929 + params.SstoreSetGasEIP2200, SlotsWritten: 1}, usage)
+
+	usage = meter.Measure(func() { env.StorageLoad(key) })
+	r.Equal(GasUsage{Gas: params.WarmStorageReadCostEIP2929, SlotsRead: 1}, usage)
+
+	// Slots are cold again in a new transaction
+	meter.NewTx()
+	usage = meter.Measure(func() {
+		r.Equal(common.Hash{0x02}, env.StorageLoad(key))
+	})
+	r.Equal(GasUsage{Gas: params.ColdSloadCostEIP2929, SlotsRead: 1}, usage)
+	usage = meter.Measure(func() { env.StorageStore(key, common.Hash{0x03}) })
+	r.Equal(GasUsage{Gas: params.SstoreResetGasEIP2200 - params.ColdSloadCostEIP2929, SlotsWritten: 1}, usage)
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package storage computes the storage slots used by lib.Datastore and the
+// tables generated by datamod, so they can be read without the generated code,
+// e.g. from solidity with sload or from off-chain tooling.
+//
+// The layout is as follows:
+//   - A datastore key of up to 32 bytes is used as a slot, left padded with
+//     zeros. Longer keys are hashed with keccak256.
+//   - A table named Name has its base slot at keccak256("datamod.v1." + Name),
+//     plus the base slot offset of the table if it was generated with one.
+//   - The slot of a value in a mapping at slot s is keccak256(key . s), where
+//     key is the encoded key without padding. Rows of tables with several keys
+//     nest one mapping per key, or hash all the keys at once with
+//     keccak256(key1 . key2 . ... . s) for composite keys, which is
+//     keccak256(abi.encodePacked(key1, key2, ..., s)) in solidity. Composite
+//     keys are always encoded big-endian, as solidity does.
+//   - Tables with padded keys hash every key as its 32-byte ABI word instead,
+//     big-endian and sign extended for signed integers, so rows are at
+//     keccak256(abi.encode(key, s)) as in a solidity mapping, or at
+//     keccak256(abi.encode(key1, key2, ..., s)) for composite keys. Dynamic
+//     keys are never padded.
+//   - The fields of a row are packed in order into consecutive slots starting
+//     at the slot of the row. A field that does not fit in the remaining space
+//     of a slot starts at the next one, and fields larger than a slot start at
+//     a slot boundary. Bytes within a slot are counted from the left.
+//     Packing applies to the rows of keyed and keyless tables alike, a table
+//     with a single small value still takes a whole slot per row.
+//   - A table nested in the row at slot s as the value with index i has its
+//     base slot at keccak256(s . uint256(i) . "datamod.v1.table").
+//   - Dynamic values and arrays are stored as in solidity: short bytes in the
+//     slot itself and long bytes and array items from keccak256(s).
+//
+// Rows of values smaller than a slot use the same slots as a solidity struct
+// with the same members in the same order, e.g. the row of a table with values
+// uint8 a, address b and uint128 c takes 2 slots and so does
+// struct { uint8 a; address b; uint128 c; }. Within a slot however solidity
+// places the first member in the lowest-order bytes while the datastore places
+// it in the highest-order ones, so a field of size n at offset b of a slot is
+// read in solidity with (sload(slot) >> FieldShift(b, n)) & (2**(8*n) - 1).
+// Unlike solidity, a field following a value larger than a slot shares the
+// last slot of that value if it fits.
+//
+// Slots are hashed with DefaultHasher, keccak256 unless it is overridden, e.g.
+// on a chain deriving storage slots with a ZK-friendly hash. Slots with
+// another Hasher are derived with the methods of Slots.
+package storage
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/concrete/crypto"
+)
+
+// SlotSize is the size of a storage slot in bytes.
+const SlotSize = 32
+
+// Hasher hashes the concatenation of data to derive a slot.
+type Hasher interface {
+	Hash(data ...[]byte) common.Hash
+}
+
+// HasherFunc adapts a function to a Hasher.
+type HasherFunc func(data ...[]byte) common.Hash
+
+func (f HasherFunc) Hash(data ...[]byte) common.Hash {
+	return f(data...)
+}
+
+// Keccak256 is the Hasher of the storage slots of Ethereum.
+var Keccak256 Hasher = HasherFunc(crypto.Keccak256Hash)
+
+// DefaultHasher is the Hasher of the package level functions, and so of
+// lib.Datastore and the generated tables. It is Keccak256 for compatibility with
+// Ethereum and solidity. It can be replaced before any storage is accessed,
+// e.g. in an init function, as slots derived with different hashers differ.
+var DefaultHasher = Keccak256
+
+// Slots derives slots with a Hasher, following the same layout as the package
+// level functions.
+type Slots struct {
+	Hasher Hasher
+}
+
+// KeySlot returns the slot of a datastore key.
+func (s Slots) KeySlot(key []byte) common.Hash {
+	if len(key) > SlotSize {
+		return s.Hasher.Hash(key)
+	}
+	return common.BytesToHash(key)
+}
+
+// TableSlot returns the base slot of the generated table with the given go
+// name, e.g. "Balances".
+func (s Slots) TableSlot(name string) common.Hash {
+	return s.Hasher.Hash([]byte("datamod.v1." + name))
+}
+
+// MappingSlot returns the slot of the value for key in the mapping at base.
+func (s Slots) MappingSlot(base common.Hash, key []byte) common.Hash {
+	return s.Hasher.Hash(key, base.Bytes())
+}
+
+// TableRowSlot returns the slot of the row with the given encoded keys in the
+// table at base, with one nested mapping per key.
+func (s Slots) TableRowSlot(base common.Hash, keys ...[]byte) common.Hash {
+	slot := base
+	for _, key := range keys {
+		slot = s.MappingSlot(slot, key)
+	}
+	return slot
+}
+
+// CompositeRowSlot returns the slot of the row with the given encoded keys in
+// a table at base with a composite key.
+func (s Slots) CompositeRowSlot(base common.Hash, keys ...[]byte) common.Hash {
+	data := make([][]byte, 0, len(keys)+1)
+	data = append(data, keys...)
+	data = append(data, base.Bytes())
+	return s.Hasher.Hash(data...)
+}
+
+// NestedTableSlot returns the base slot of the table stored as the value with
+// the given schema index in the row at row.
+func (s Slots) NestedTableSlot(row common.Hash, index int) common.Hash {
+	return s.Hasher.Hash(row.Bytes(), common.BigToHash(big.NewInt(int64(index))).Bytes(), []byte("datamod.v1.table"))
+}
+
+// IndexSlot returns the base slot of the key index of an iterable table at
+// base.
+func (s Slots) IndexSlot(base common.Hash) common.Hash {
+	return s.Hasher.Hash(base.Bytes(), []byte("datamod.v1.index"))
+}
+
+// DataSlot returns the first slot of the data of long bytes and of the items
+// of a contiguous array stored at slot.
+func (s Slots) DataSlot(slot common.Hash) common.Hash {
+	return s.Hasher.Hash(slot.Bytes())
+}
+
+func defaultSlots() Slots {
+	return Slots{Hasher: DefaultHasher}
+}
+
+// KeySlot returns the slot of a datastore key.
+func KeySlot(key []byte) common.Hash {
+	return defaultSlots().KeySlot(key)
+}
+
+// TableSlot returns the base slot of the generated table with the given go
+// name, e.g. "Balances".
+func TableSlot(name string) common.Hash {
+	return defaultSlots().TableSlot(name)
+}
+
+// MappingSlot returns the slot of the value for key in the mapping at base.
+func MappingSlot(base common.Hash, key []byte) common.Hash {
+	return defaultSlots().MappingSlot(base, key)
+}
+
+// TableRowSlot returns the slot of the row with the given encoded keys in the
+// table at base, with one nested mapping per key.
+func TableRowSlot(base common.Hash, keys ...[]byte) common.Hash {
+	return defaultSlots().TableRowSlot(base, keys...)
+}
+
+// CompositeRowSlot returns the slot of the row with the given encoded keys in
+// a table at base with a composite key.
+func CompositeRowSlot(base common.Hash, keys ...[]byte) common.Hash {
+	return defaultSlots().CompositeRowSlot(base, keys...)
+}
+
+// NestedTableSlot returns the base slot of the table stored as the value with
+// the given schema index in the row at row.
+func NestedTableSlot(row common.Hash, index int) common.Hash {
+	return defaultSlots().NestedTableSlot(row, index)
+}
+
+// IndexSlot returns the base slot of the key index of an iterable table at
+// base.
+func IndexSlot(base common.Hash) common.Hash {
+	return defaultSlots().IndexSlot(base)
+}
+
+// OffsetSlot returns the slot n slots after base, wrapping around at 2^256.
+func OffsetSlot(base common.Hash, n uint64) common.Hash {
+	slot := new(big.Int).Add(base.Big(), new(big.Int).SetUint64(n))
+	return common.BigToHash(slot)
+}
+
+// DataSlot returns the first slot of the data of long bytes and of the items
+// of a contiguous array stored at slot.
+func DataSlot(slot common.Hash) common.Hash {
+	return defaultSlots().DataSlot(slot)
+}
+
+// FieldOffsets returns the byte offset of every field of a row from the start
+// of its first slot, given the fields sizes in bytes.
+func FieldOffsets(sizes []int) []int {
+	var (
+		offset  = 0
+		offsets = make([]int, len(sizes))
+	)
+	for ii, size := range sizes {
+		if size > SlotSize {
+			if offset%SlotSize != 0 {
+				offset = (offset/SlotSize + 1) * SlotSize
+			}
+		} else if offset/SlotSize != (offset+size-1)/SlotSize {
+			offset = (offset/SlotSize + 1) * SlotSize
+		}
+		offsets[ii] = offset
+		offset += size
+	}
+	return offsets
+}
+
+// FieldSlot returns the slot holding the start of the field at the given byte
+// offset of the row at row, and the offset of the field within that slot.
+func FieldSlot(row common.Hash, offset int) (common.Hash, int) {
+	return OffsetSlot(row, uint64(offset/SlotSize)), offset % SlotSize
+}
+
+// FieldShift returns the number of bits a slot must be shifted right by to move
+// the field of the given size at offset within the slot into its lowest-order
+// bytes, as solidity stores the members of packed structs.
+func FieldShift(offset, size int) int {
+	return 8 * (SlotSize - offset - size)
+}
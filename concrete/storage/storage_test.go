@@ -0,0 +1,107 @@
+// Copyright 2023 The concrete-geth Authors
+//
+// The concrete-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The concrete library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the concrete library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !tinygo
+
+// This file will ignored when building with tinygo to prevent compatibility
+// issues.
+
+package storage
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlots(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(common.Hash{31: 1}, KeySlot([]byte{1}))
+	long := make([]byte, 33)
+	r.Equal(crypto.Keccak256Hash(long), KeySlot(long))
+	r.Equal(crypto.Keccak256Hash([]byte("datamod.v1.Balances")), TableSlot("Balances"))
+
+	// Mappings with 32-byte keys match solidity, e.g. mapping(uint256 => ...)
+	// at slot 3
+	base := common.BigToHash(big.NewInt(3))
+	key := common.BigToHash(big.NewInt(7))
+	r.Equal(crypto.Keccak256Hash(key.Bytes(), base.Bytes()), MappingSlot(base, key.Bytes()))
+	r.Equal(MappingSlot(MappingSlot(base, []byte{1}), []byte{2}), TableRowSlot(base, []byte{1}, []byte{2}))
+	r.Equal(base, TableRowSlot(base))
+	r.Equal(crypto.Keccak256Hash([]byte{1}, []byte{2}, base.Bytes()), CompositeRowSlot(base, []byte{1}, []byte{2}))
+	r.NotEqual(TableRowSlot(base, []byte{1}, []byte{2}), CompositeRowSlot(base, []byte{1}, []byte{2}))
+
+	r.Equal(crypto.Keccak256Hash(base.Bytes(), common.BigToHash(big.NewInt(2)).Bytes(), []byte("datamod.v1.table")), NestedTableSlot(base, 2))
+	r.NotEqual(NestedTableSlot(base, 1), NestedTableSlot(base, 2))
+	r.Equal(crypto.Keccak256Hash(base.Bytes(), []byte("datamod.v1.index")), IndexSlot(base))
+	r.Equal(crypto.Keccak256Hash(base.Bytes()), DataSlot(base))
+
+	r.Equal(common.BigToHash(big.NewInt(5)), OffsetSlot(base, 2))
+	r.Equal(common.Hash{31: 1}, OffsetSlot(common.MaxHash, 2))
+}
+
+func TestSlotsHasher(t *testing.T) {
+	r := require.New(t)
+	// A hasher other than keccak256, e.g. of a chain using a ZK-friendly hash
+	sha := HasherFunc(func(data ...[]byte) common.Hash {
+		hasher := sha256.New()
+		for _, b := range data {
+			hasher.Write(b)
+		}
+		return common.BytesToHash(hasher.Sum(nil))
+	})
+	slots := Slots{Hasher: sha}
+	base := common.BigToHash(big.NewInt(3))
+	r.Equal(common.Hash(sha256.Sum256([]byte("datamod.v1.Balances"))), slots.TableSlot("Balances"))
+	r.Equal(sha.Hash([]byte{1}, base.Bytes()), slots.MappingSlot(base, []byte{1}))
+	r.Equal(common.Hash{31: 1}, slots.KeySlot([]byte{1}))
+	r.NotEqual(TableSlot("Balances"), slots.TableSlot("Balances"))
+	r.Equal(Slots{Hasher: Keccak256}.NestedTableSlot(base, 2), NestedTableSlot(base, 2))
+
+	// The package level functions use the default hasher
+	defer func(hasher Hasher) { DefaultHasher = hasher }(DefaultHasher)
+	DefaultHasher = sha
+	r.Equal(slots.TableSlot("Balances"), TableSlot("Balances"))
+	r.Equal(slots.TableRowSlot(base, []byte{1}, []byte{2}), TableRowSlot(base, []byte{1}, []byte{2}))
+	r.Equal(slots.CompositeRowSlot(base, []byte{1}), CompositeRowSlot(base, []byte{1}))
+	r.Equal(slots.IndexSlot(base), IndexSlot(base))
+	r.Equal(slots.DataSlot(base), DataSlot(base))
+}
+
+func TestFieldOffsets(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]int{0, 32, 64, 96, 97, 128}, FieldOffsets([]int{32, 32, 32, 1, 20, 16}))
+	// Fields larger than a slot start on a slot boundary
+	r.Equal([]int{0, 32, 288}, FieldOffsets([]int{2, 256, 64}))
+	// Fields that do not fit in the remaining space start at the next slot
+	r.Equal([]int{0, 8, 16, 24, 32}, FieldOffsets([]int{8, 8, 8, 8, 1}))
+	r.Equal([]int{0, 32}, FieldOffsets([]int{20, 20}))
+	r.Empty(FieldOffsets(nil))
+
+	slot, offset := FieldSlot(common.Hash{}, 97)
+	r.Equal(common.Hash{31: 3}, slot)
+	r.Equal(1, offset)
+
+	// The layout of struct { uint8 a; address b; uint128 c; }
+	r.Equal([]int{0, 1, 32}, FieldOffsets([]int{1, 20, 16}))
+	r.Equal(248, FieldShift(0, 1))
+	r.Equal(88, FieldShift(1, 20))
+	r.Equal(128, FieldShift(0, 16))
+}